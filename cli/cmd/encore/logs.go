@@ -114,12 +114,24 @@ func mapCloudFieldNamesToExpected(jsonBytes []byte) []byte {
 	_, hasTimestamp := unmarshaled["timestamp"]
 	_, hasExpectedTimeField := unmarshaled[zerolog.TimestampFieldName]
 
+	bunyanLevel, hasNumericLevel := unmarshaled["level"].(float64)
+	_, hasBunyanVersion := unmarshaled["v"]
+	_, hasMsg := unmarshaled["msg"]
+
 	// GCP logs have a severity field and a timestamp field and not the default level and timestamp
 	if hasSeverity && !hasExpectedLevelField && hasTimestamp && !hasExpectedTimeField {
 		unmarshaled[zerolog.LevelFieldName] = unmarshaled["severity"]
 		delete(unmarshaled, "severity")
 		unmarshaled[zerolog.TimestampFieldName] = unmarshaled["timestamp"]
 		delete(unmarshaled, "timestamp")
+	} else if hasNumericLevel && hasBunyanVersion && hasMsg {
+		// Bunyan logs use numeric levels and "msg" for the message
+		unmarshaled[zerolog.LevelFieldName] = bunyanLevelName(bunyanLevel)
+		unmarshaled[zerolog.MessageFieldName] = unmarshaled["msg"]
+		delete(unmarshaled, "msg")
+		for _, k := range []string{"v", "name", "hostname", "pid"} {
+			delete(unmarshaled, k)
+		}
 	} else {
 		// No changes, return the original bytes unmodified
 		return jsonBytes
@@ -132,6 +144,24 @@ func mapCloudFieldNamesToExpected(jsonBytes []byte) []byte {
 	return newBytes
 }
 
+// bunyanLevelName maps a Bunyan numeric level to the zerolog level name.
+func bunyanLevelName(level float64) string {
+	switch {
+	case level <= 10:
+		return zerolog.TraceLevel.String()
+	case level <= 20:
+		return zerolog.DebugLevel.String()
+	case level <= 30:
+		return zerolog.InfoLevel.String()
+	case level <= 40:
+		return zerolog.WarnLevel.String()
+	case level <= 50:
+		return zerolog.ErrorLevel.String()
+	default:
+		return zerolog.FatalLevel.String()
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(logsCmd)
 	logsCmd.Flags().StringVarP(&logsEnv, "env", "e", "", "Environment name to stream logs from (defaults to the primary environment)")
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBunyanLevelName(t *testing.T) {
+	tests := []struct {
+		level float64
+		want  string
+	}{
+		{10, "trace"},
+		{20, "debug"},
+		{30, "info"},
+		{35, "warn"},
+		{40, "warn"},
+		{50, "error"},
+		{60, "fatal"},
+	}
+	for _, test := range tests {
+		if got := bunyanLevelName(test.level); got != test.want {
+			t.Errorf("bunyanLevelName(%v) = %q, want %q", test.level, got, test.want)
+		}
+	}
+}
+
+func TestMapCloudFieldNamesToExpected_Bunyan(t *testing.T) {
+	line := `{"v":0,"level":50,"name":"my-app","hostname":"host","pid":1,"time":"2024-01-02T03:04:05Z","msg":"failed","key":"val"}`
+	var got map[string]any
+	if err := json.Unmarshal(mapCloudFieldNamesToExpected([]byte(line)), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"level":   "error",
+		"time":    "2024-01-02T03:04:05Z",
+		"message": "failed",
+		"key":     "val",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mapped log entry mismatch (-want +got):\n%s", diff)
+	}
+
+	// Entries that are not in the Bunyan format are left as they are.
+	other := `{"level":50,"msg":"no version"}`
+	if got := string(mapCloudFieldNamesToExpected([]byte(other))); got != other {
+		t.Errorf("got %q, want it unchanged", got)
+	}
+}
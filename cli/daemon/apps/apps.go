@@ -349,6 +349,11 @@ func (i *Instance) GlobalCORS() (appfile.CORS, error) {
 
 }
 
+// ErrorFormat returns the format of API error responses for the app.
+func (i *Instance) ErrorFormat() (string, error) {
+	return appfile.ErrorFormat(i.root)
+}
+
 func (i *Instance) Watch(fn WatchFunc) (WatchSubscriptionID, error) {
 	if err := i.beginWatch(); err != nil {
 		return 0, err
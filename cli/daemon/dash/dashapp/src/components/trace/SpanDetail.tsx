@@ -139,6 +139,17 @@ const SpanDetail: FunctionComponent<Props> = (props) => {
           </div>
         </div>
 
+        {req.truncated && (
+          <div className="body-sm text-red flex items-center">
+            <div>{icons.exclamation("h-5 w-auto")}</div>
+            <span className="mx-1">
+              {req.truncated.events} event{req.truncated.events !== 1 ? "s" : ""} (
+              {req.truncated.bytes} bytes) dropped from the trace after it reached its size
+              limit, starting {latencyStr(req.truncated.time - req.start_time)} into the request.
+            </span>
+          </div>
+        )}
+
         <div className="h-full overflow-auto">
           <div className="mt-6">
             <EventMap trace={props.trace} req={req} onStackTrace={props.onStackTrace} />
@@ -52,6 +52,7 @@ export interface Request {
   raw_resp_headers: KeyValue[];
   ext_request_id: string;
   ext_correlation_id: string;
+  truncated?: Truncation;
 
   err: Base64EncodedBytes | null;
   err_stack: Stack | null;
@@ -59,6 +60,12 @@ export interface Request {
   children: Request[];
 }
 
+export interface Truncation {
+  time: number;
+  events: number;
+  bytes: number;
+}
+
 export interface DBTransaction {
   type: "DBTransaction";
   goid: number;
@@ -189,6 +196,7 @@ export interface LogMessage {
   msg: string;
   fields: LogField[];
   stack: Stack;
+  span_id?: string; // set if logged under another span than its request
 }
 
 export interface LogField {
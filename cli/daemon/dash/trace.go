@@ -73,6 +73,8 @@ type Request struct {
 	ExtRequestID     string                     `json:"ext_request_id"`
 	ExtCorrelationID string                     `json:"ext_correlation_id"`
 
+	Truncated *Truncation `json:"truncated,omitempty"` // nil if no events were dropped
+
 	// Deprecated: Use RequestPayload, ResponsePayload etc instead.
 	Inputs  [][]byte `json:"inputs"`
 	Outputs [][]byte `json:"outputs"` // Deprecated: same as above
@@ -83,6 +85,14 @@ type Request struct {
 	Children []*Request `json:"children"`
 }
 
+// Truncation describes the events dropped from a trace
+// once it reached its size limit.
+type Truncation struct {
+	Time   int64  `json:"time"` // when the first event was dropped
+	Events uint64 `json:"events"`
+	Bytes  uint64 `json:"bytes"`
+}
+
 type Goroutine struct {
 	Type      string `json:"type"`
 	Goid      uint32 `json:"goid"`
@@ -166,6 +176,24 @@ type LogMessage struct {
 	Message string     `json:"msg"`
 	Fields  []LogField `json:"fields"`
 	Stack   Stack      `json:"stack"`
+
+	// SpanID is the span the message was logged under, if it is
+	// not the request's span, such as with rlog.LogWithSpan.
+	SpanID string `json:"span_id,omitempty"`
+}
+
+// UserSpan is a span started by the application.
+// Spans started within it are included as its children.
+type UserSpan struct {
+	Type      string      `json:"type"` // "UserSpan"
+	ID        string      `json:"id"`
+	Goid      uint32      `json:"goid"`
+	StartTime int64       `json:"start_time"`
+	EndTime   *int64      `json:"end_time,omitempty"`
+	Name      string      `json:"name"`
+	Fields    []LogField  `json:"fields"`
+	Stack     Stack       `json:"stack"`
+	Children  []*UserSpan `json:"children"`
 }
 
 type PubSubPublish struct {
@@ -242,6 +270,7 @@ func (LogMessage) traceEvent()    {}
 func (PubSubPublish) traceEvent() {}
 func (ServiceInit) traceEvent()   {}
 func (CacheOp) traceEvent()       {}
+func (UserSpan) traceEvent()      {}
 
 func TransformTrace(ct *trace.TraceMeta) (*Trace, error) {
 	traceID := traceUUID(ct.ID)
@@ -397,6 +426,13 @@ func (tp *traceParser) parseReq(req *tracepb.Request) (*Request, error) {
 	if req.PublishTime > 0 {
 		r.Published = &req.PublishTime
 	}
+	if req.TruncatedEvents > 0 {
+		r.Truncated = &Truncation{
+			Time:   tp.time(req.TruncatedTime),
+			Events: req.TruncatedEvents,
+			Bytes:  req.TruncatedBytes,
+		}
+	}
 
 	// Only set these values if they're non-nil, to allow the frontend to
 	// distinguish between legacy traces and the new format.
@@ -405,6 +441,7 @@ func (tp *traceParser) parseReq(req *tracepb.Request) (*Request, error) {
 		r.ResponsePayload = sliceOrEmpty(req.ResponsePayload)
 	}
 
+	userSpans := make(map[uint64]*UserSpan)
 	for _, ev := range req.Events {
 		switch e := ev.Data.(type) {
 		case *tracepb.Event_Tx:
@@ -427,7 +464,7 @@ func (tp *traceParser) parseReq(req *tracepb.Request) (*Request, error) {
 			r.Events = append(r.Events, tp.parseGoroutine(e.Goroutine))
 
 		case *tracepb.Event_Log:
-			r.Events = append(r.Events, tp.parseLog(e.Log))
+			r.Events = append(r.Events, tp.parseLog(e.Log, req.SpanId))
 
 		case *tracepb.Event_PublishedMsg:
 			r.Events = append(r.Events, tp.parsePubSubPublish(e.PublishedMsg))
@@ -438,6 +475,15 @@ func (tp *traceParser) parseReq(req *tracepb.Request) (*Request, error) {
 		case *tracepb.Event_Cache:
 			r.Events = append(r.Events, tp.parseCacheOp(e.Cache))
 
+		case *tracepb.Event_UserSpan:
+			s := tp.parseUserSpan(e.UserSpan)
+			userSpans[e.UserSpan.SpanId] = s
+			if parent, ok := userSpans[e.UserSpan.ParentSpanId]; ok {
+				parent.Children = append(parent.Children, s)
+			} else {
+				r.Events = append(r.Events, s)
+			}
+
 		case *tracepb.Event_BodyStream:
 			ev := e.BodyStream
 			if ev.IsResponse {
@@ -460,17 +506,39 @@ func (tp *traceParser) parseGoroutine(g *tracepb.Goroutine) *Goroutine {
 	}
 }
 
-func (tp *traceParser) parseLog(l *tracepb.LogMessage) *LogMessage {
+func (tp *traceParser) parseLog(l *tracepb.LogMessage, reqSpanID uint64) *LogMessage {
 	msg := &LogMessage{
 		Type:    "LogMessage",
 		Goid:    l.Goid,
 		Time:    tp.time(l.Time),
 		Level:   l.Level.String(),
 		Message: l.Msg,
-		Fields:  []LogField{},
+		Fields:  tp.logFields(l.Fields),
 		Stack:   tp.stack(l.Stack),
 	}
-	for _, f := range l.Fields {
+	if l.SpanId != reqSpanID {
+		msg.SpanID = strconv.FormatUint(l.SpanId, 10)
+	}
+	return msg
+}
+
+func (tp *traceParser) parseUserSpan(s *tracepb.UserSpan) *UserSpan {
+	return &UserSpan{
+		Type:      "UserSpan",
+		ID:        strconv.FormatUint(s.SpanId, 10),
+		Goid:      s.Goid,
+		StartTime: tp.time(s.StartTime),
+		EndTime:   tp.maybeTime(s.EndTime),
+		Name:      s.Name,
+		Fields:    tp.logFields(s.Fields),
+		Stack:     tp.stack(s.Stack),
+		Children:  []*UserSpan{}, // prevent marshalling as null
+	}
+}
+
+func (tp *traceParser) logFields(fields []*tracepb.LogField) []LogField {
+	res := []LogField{} // prevent marshalling as null
+	for _, f := range fields {
 		field := LogField{Key: f.Key}
 		switch v := f.Value.(type) {
 		case *tracepb.LogField_ErrorWithStack:
@@ -500,9 +568,9 @@ func (tp *traceParser) parseLog(l *tracepb.LogMessage) *LogMessage {
 		case *tracepb.LogField_Float64:
 			field.Value = v.Float64
 		}
-		msg.Fields = append(msg.Fields, field)
+		res = append(res, field)
 	}
-	return msg
+	return res
 }
 
 func (tp *traceParser) parsePubSubPublish(publish *tracepb.PubsubMsgPublished) *PubSubPublish {
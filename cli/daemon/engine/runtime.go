@@ -2,8 +2,8 @@ package runtime
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
@@ -17,10 +17,11 @@ import (
 type server struct {
 	runMgr *run.Manager
 	ts     *trace.Store
+	chunks *trace.ChunkAssembler
 }
 
 func NewServer(runMgr *run.Manager, ts *trace.Store) http.Handler {
-	s := &server{runMgr: runMgr, ts: ts}
+	s := &server{runMgr: runMgr, ts: ts, chunks: trace.NewChunkAssembler()}
 	return s
 }
 
@@ -46,16 +47,34 @@ func (s *server) RecordTrace(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	data, err := trace.DecodeBody(req.Body, req.Header.Get("Content-Encoding"))
+	if errors.Is(err, trace.ErrUnsupportedEncoding) {
+		w.Header().Set("Accept-Encoding", trace.AcceptEncoding)
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	proc := s.runMgr.FindProc(pid)
 	if proc == nil {
 		http.Error(w, "process "+pid+" not running", http.StatusBadRequest)
 		return
 	}
 
-	data, err := io.ReadAll(req.Body)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	// Traces of long-running operations are sent in chunks;
+	// parse them once all chunks have been received.
+	if h := req.Header.Get("X-Encore-Trace-Chunk"); h != "" {
+		seq, final, err := trace.ParseChunkHeader(h)
+		if err != nil {
+			http.Error(w, "invalid X-Encore-Trace-Chunk header: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var complete bool
+		if data, complete = s.chunks.Add(pid, traceID, seq, final, data); !complete {
+			return
+		}
 	}
 
 	reqs, err := trace.Parse(&log.Logger, traceID, data, trace2.CurrentVersion, proc)
@@ -0,0 +1,28 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"encr.dev/cli/daemon/engine/trace"
+	"encr.dev/cli/daemon/run"
+)
+
+func TestRecordTrace_UnsupportedEncoding(t *testing.T) {
+	srv := NewServer(&run.Manager{}, nil)
+	req := httptest.NewRequest("POST", "/trace", strings.NewReader("data"))
+	req.Header.Set("X-Encore-Env-ID", "proc")
+	req.Header.Set("X-Encore-Trace-ID", "AAAAAAAAAAAAAAAAAAAAAA")
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("got status %d, want %d", w.Code, http.StatusUnsupportedMediaType)
+	}
+	if got := w.Header().Get("Accept-Encoding"); got != trace.AcceptEncoding {
+		t.Errorf("got Accept-Encoding %q, want %q", got, trace.AcceptEncoding)
+	}
+}
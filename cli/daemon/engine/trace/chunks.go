@@ -0,0 +1,146 @@
+package trace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits on the chunks kept while waiting for the rest of their traces.
+const (
+	pendingChunkTTL  = 30 * time.Minute // how long a trace's chunks are kept
+	maxPendingBytes  = 256 << 20        // total size of pending chunks
+	maxPendingTraces = 1000             // number of pending traces
+)
+
+// A ChunkAssembler reassembles traces that are sent in chunks,
+// such as the traces of long-running operations sent while they run.
+//
+// Every chunk of a trace is sent under the same trace id, with the
+// X-Encore-Trace-Chunk header set to its sequence number, suffixed
+// with ";final" for the last chunk. Chunks are split at event
+// boundaries, so the trace data is the concatenation of the chunks
+// in sequence order.
+//
+// The chunks of incomplete traces are kept for up to 30 minutes.
+// When the pending chunks exceed 256 MiB or 1000 traces, the traces
+// that were least recently added to are dropped.
+type ChunkAssembler struct {
+	mu      sync.Mutex
+	pending map[chunkKey]*pendingTrace
+	size    int    // total size of pending chunks
+	adds    uint64 // number of chunks added, for ordering updates
+}
+
+type chunkKey struct {
+	envID string
+	id    ID
+}
+
+type pendingTrace struct {
+	chunks  map[int][]byte
+	final   int // sequence number of the final chunk, or -1 if not received
+	size    int // total size of chunks
+	updated time.Time
+	lastAdd uint64 // the value of adds when last updated
+}
+
+func NewChunkAssembler() *ChunkAssembler {
+	return &ChunkAssembler{pending: make(map[chunkKey]*pendingTrace)}
+}
+
+// ParseChunkHeader parses the value of the X-Encore-Trace-Chunk header.
+func ParseChunkHeader(h string) (seq int, final bool, err error) {
+	if strings.HasSuffix(h, ";final") {
+		h, final = h[:len(h)-len(";final")], true
+	}
+	seq, err = strconv.Atoi(h)
+	if err != nil || seq < 0 {
+		return 0, false, fmt.Errorf("invalid trace chunk %q", h)
+	}
+	return seq, final, nil
+}
+
+// Add adds the chunk with the sequence number seq of the trace id sent
+// by the environment envID. Once all chunks of the trace have been added,
+// it returns the complete trace data and true.
+func (a *ChunkAssembler) Add(envID string, id ID, seq int, final bool, data []byte) ([]byte, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	for k, p := range a.pending {
+		if now.Sub(p.updated) > pendingChunkTTL {
+			a.remove(k)
+		}
+	}
+
+	k := chunkKey{envID: envID, id: id}
+	p, ok := a.pending[k]
+	if !ok {
+		p = &pendingTrace{chunks: make(map[int][]byte), final: -1}
+		a.pending[k] = p
+	}
+	a.size += len(data) - len(p.chunks[seq])
+	p.size += len(data) - len(p.chunks[seq])
+	p.chunks[seq] = data
+	p.updated = now
+	a.adds++
+	p.lastAdd = a.adds
+	if final {
+		p.final = seq
+	}
+	if p.size > maxPendingBytes {
+		// The trace is too large to ever be assembled.
+		a.remove(k)
+		return nil, false
+	}
+	a.evict(k)
+	if p.final < 0 || len(p.chunks) < p.final+1 {
+		return nil, false
+	}
+
+	var size int
+	for i := 0; i <= p.final; i++ {
+		c, ok := p.chunks[i]
+		if !ok {
+			return nil, false
+		}
+		size += len(c)
+	}
+	assembled := make([]byte, 0, size)
+	for i := 0; i <= p.final; i++ {
+		assembled = append(assembled, p.chunks[i]...)
+	}
+	a.remove(k)
+	return assembled, true
+}
+
+// evict removes the least recently updated pending traces other than keep
+// while the pending traces exceed the limits.
+func (a *ChunkAssembler) evict(keep chunkKey) {
+	for a.size > maxPendingBytes || len(a.pending) > maxPendingTraces {
+		var (
+			oldest chunkKey
+			found  *pendingTrace
+		)
+		for k, p := range a.pending {
+			if k != keep && (found == nil || p.lastAdd < found.lastAdd) {
+				oldest, found = k, p
+			}
+		}
+		if found == nil {
+			return
+		}
+		a.remove(oldest)
+	}
+}
+
+func (a *ChunkAssembler) remove(k chunkKey) {
+	if p, ok := a.pending[k]; ok {
+		a.size -= p.size
+		delete(a.pending, k)
+	}
+}
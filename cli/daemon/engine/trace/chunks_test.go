@@ -0,0 +1,80 @@
+package trace
+
+import (
+	"testing"
+)
+
+func TestChunkAssembler(t *testing.T) {
+	a := NewChunkAssembler()
+	id := ID{1}
+
+	// Chunks may arrive out of order; the final chunk
+	// completes the trace once all earlier chunks are added.
+	if _, ok := a.Add("env", id, 2, true, []byte("c")); ok {
+		t.Fatal("trace complete without earlier chunks")
+	}
+	if _, ok := a.Add("env", id, 0, false, []byte("a")); ok {
+		t.Fatal("trace complete without chunk 1")
+	}
+	if _, ok := a.Add("other-env", id, 1, false, []byte("x")); ok {
+		t.Fatal("trace completed by chunk of another environment")
+	}
+	data, ok := a.Add("env", id, 1, false, []byte("b"))
+	if !ok || string(data) != "abc" {
+		t.Fatalf("got trace %q, %v, want %q", data, ok, "abc")
+	}
+	if _, ok := a.pending[chunkKey{envID: "env", id: id}]; ok {
+		t.Error("assembled trace still pending")
+	}
+}
+
+func TestParseChunkHeader(t *testing.T) {
+	tests := []struct {
+		header string
+		seq    int
+		final  bool
+		err    bool
+	}{
+		{header: "0", seq: 0},
+		{header: "12;final", seq: 12, final: true},
+		{header: "-1", err: true},
+		{header: "final", err: true},
+	}
+	for _, tt := range tests {
+		seq, final, err := ParseChunkHeader(tt.header)
+		if (err != nil) != tt.err || seq != tt.seq || final != tt.final {
+			t.Errorf("ParseChunkHeader(%q) = %d, %v, %v", tt.header, seq, final, err)
+		}
+	}
+}
+
+func TestChunkAssembler_Limits(t *testing.T) {
+	a := NewChunkAssembler()
+
+	// Traces beyond the limit on pending traces evict the
+	// least recently updated ones.
+	for i := 0; i < maxPendingTraces+1; i++ {
+		a.Add("env", ID{byte(i), byte(i >> 8)}, 0, false, []byte("a"))
+	}
+	if len(a.pending) != maxPendingTraces {
+		t.Fatalf("got %d pending traces, want %d", len(a.pending), maxPendingTraces)
+	}
+	if _, ok := a.pending[chunkKey{envID: "env", id: ID{0, 0}}]; ok {
+		t.Error("least recently updated trace not evicted")
+	}
+	if a.size != maxPendingTraces {
+		t.Errorf("got %d pending bytes, want %d", a.size, maxPendingTraces)
+	}
+
+	// Traces larger than the limit on pending bytes are dropped.
+	a = NewChunkAssembler()
+	id := ID{1}
+	a.Add("env", id, 0, false, make([]byte, maxPendingBytes/2))
+	a.Add("env", id, 1, false, make([]byte, maxPendingBytes/2+1))
+	if _, ok := a.Add("env", id, 2, true, nil); ok {
+		t.Error("assembled trace larger than the limit")
+	}
+	if a.size != 0 {
+		t.Errorf("got %d pending bytes, want 0", a.size)
+	}
+}
@@ -0,0 +1,41 @@
+package trace
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// AcceptEncoding is the value of the Accept-Encoding header listing the
+// content encodings supported by DecodeBody, for responding to traces
+// sent with an unsupported encoding (RFC 7694).
+const AcceptEncoding = "zstd, snappy"
+
+// ErrUnsupportedEncoding is reported by DecodeBody
+// for content encodings it does not support.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// DecodeBody reads the trace data from body, decompressing it according
+// to the value of its Content-Encoding header, which is either empty or
+// "identity" for uncompressed traces, "zstd", or "snappy" for the snappy
+// framing format, as sent by the runtime's platform client.
+func DecodeBody(body io.Reader, encoding string) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return io.ReadAll(body)
+	case "zstd":
+		d, err := zstd.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		defer d.Close()
+		return io.ReadAll(d)
+	case "snappy":
+		return io.ReadAll(snappy.NewReader(body))
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+}
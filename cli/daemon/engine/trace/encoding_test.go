@@ -0,0 +1,66 @@
+package trace
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/trace"
+)
+
+func TestDecodeBody(t *testing.T) {
+	data := newUserSpanTrace().finish()
+
+	// Each encoding compresses data the way the runtime's platform client does.
+	tests := []struct {
+		encoding string
+		encode   func(t *testing.T, data []byte) []byte
+	}{
+		{"", func(t *testing.T, data []byte) []byte { return data }},
+		{"zstd", func(t *testing.T, data []byte) []byte {
+			enc, err := zstd.NewWriter(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return enc.EncodeAll(data, nil)
+		}},
+		{"snappy", func(t *testing.T, data []byte) []byte {
+			var buf bytes.Buffer
+			w := snappy.NewBufferedWriter(&buf)
+			if _, err := w.Write(data); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			return buf.Bytes()
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			got, err := DecodeBody(bytes.NewReader(tt.encode(t, data)), tt.encoding)
+			if err != nil {
+				t.Fatalf("failed to decode trace: %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("decoded trace differs from the original")
+			}
+			logger := zerolog.New(zerolog.NewTestWriter(t))
+			reqs, err := Parse(&logger, ID{}, got, trace.CurrentVersion, nil)
+			if err != nil {
+				t.Fatalf("failed to parse trace: %v", err)
+			}
+			if len(reqs) != 1 {
+				t.Fatalf("got %d requests, want 1", len(reqs))
+			}
+		})
+	}
+
+	if _, err := DecodeBody(bytes.NewReader(data), "gzip"); !errors.Is(err, ErrUnsupportedEncoding) {
+		t.Errorf("got error %v for gzip, want ErrUnsupportedEncoding", err)
+	}
+}
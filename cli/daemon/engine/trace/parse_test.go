@@ -2,6 +2,7 @@ package trace
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"encore.dev/appruntime/model"
 	"encore.dev/appruntime/trace"
 	"encore.dev/beta/errs"
+	tracepb "encr.dev/proto/encore/engine/trace"
 )
 
 type parseTest[T any] struct {
@@ -108,3 +110,264 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+// userSpanTrace records a traced request containing application-defined
+// spans, encoding the span events as rlog records them.
+type userSpanTrace struct {
+	log *trace.Log
+	req *model.Request
+}
+
+func newUserSpanTrace() *userSpanTrace {
+	req := &model.Request{
+		Type:   model.RPCCall,
+		SpanID: model.SpanID{0, 0, 0, 0, 0, 0, 0, 1},
+		Start:  time.Now(),
+		Traced: true,
+		RPCData: &model.RPCData{
+			Desc:       &model.RPCDesc{Service: "service", Endpoint: "endpoint"},
+			HTTPMethod: "POST",
+			Path:       "/path",
+		},
+	}
+	ut := &userSpanTrace{log: &trace.Log{}, req: req}
+	ut.log.BeginRequest(req, 0)
+	return ut
+}
+
+func (ut *userSpanTrace) start(id, parent model.SpanID, name string, fields ...string) {
+	tb := trace.NewBuffer(64)
+	tb.Bytes(id[:])
+	tb.Bytes(parent[:])
+	tb.UVarint(1) // goctr
+	tb.String(name)
+	addStringFields(&tb, fields)
+	tb.Byte(0) // empty stack
+	ut.log.Add(trace.UserSpanStart, tb.Buf())
+}
+
+func (ut *userSpanTrace) end(id model.SpanID, dur time.Duration, fields ...string) {
+	tb := trace.NewBuffer(64)
+	tb.Bytes(id[:])
+	tb.Int64(int64(dur))
+	addStringFields(&tb, fields)
+	ut.log.Add(trace.UserSpanEnd, tb.Buf())
+}
+
+func (ut *userSpanTrace) finish() []byte {
+	ut.log.FinishRequest(ut.req, &model.Response{HTTPStatus: 200})
+	return ut.log.GetAndClear()
+}
+
+// addStringFields adds the key-value pairs in kv as string log fields.
+func addStringFields(tb *trace.Buffer, kv []string) {
+	tb.UVarint(uint64(len(kv) / 2))
+	for i := 0; i < len(kv); i += 2 {
+		tb.Byte(2) // string field
+		tb.String(kv[i])
+		tb.String(kv[i+1])
+	}
+}
+
+func TestParseUserSpans(t *testing.T) {
+	outerID := model.SpanID{0, 0, 0, 0, 0, 0, 0, 2}
+	innerID := model.SpanID{0, 0, 0, 0, 0, 0, 0, 3}
+	ut := newUserSpanTrace()
+	ut.start(outerID, ut.req.SpanID, "outer", "width", "10")
+	ut.start(innerID, outerID, "inner")
+	time.Sleep(time.Millisecond)
+	ut.end(innerID, time.Millisecond, "ok", "true")
+	ut.end(outerID, 2*time.Millisecond)
+	data := ut.finish()
+
+	logger := zerolog.New(zerolog.NewTestWriter(t))
+	reqs, err := Parse(&logger, ID{}, data, trace.CurrentVersion, nil)
+	if err != nil {
+		t.Fatalf("failed to parse trace: %v", err)
+	}
+	if len(reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(reqs))
+	}
+	var spans []*tracepb.UserSpan
+	for _, ev := range reqs[0].Events {
+		if s := ev.GetUserSpan(); s != nil {
+			spans = append(spans, s)
+		}
+	}
+	if len(spans) != 2 {
+		t.Fatalf("got %d user spans, want 2", len(spans))
+	}
+
+	outer, inner := spans[0], spans[1]
+	tests := []struct {
+		span   *tracepb.UserSpan
+		id     model.SpanID
+		parent model.SpanID
+		name   string
+		dur    time.Duration
+		fields []string
+	}{
+		{outer, outerID, ut.req.SpanID, "outer", 2 * time.Millisecond, []string{"width"}},
+		{inner, innerID, outerID, "inner", time.Millisecond, []string{"ok"}},
+	}
+	for _, tt := range tests {
+		s := tt.span
+		if s.SpanId != bin.Uint64(tt.id[:]) || s.ParentSpanId != bin.Uint64(tt.parent[:]) || s.Name != tt.name {
+			t.Errorf("got span %d (parent %d) named %q, want %d (parent %d) named %q",
+				s.SpanId, s.ParentSpanId, s.Name, bin.Uint64(tt.id[:]), bin.Uint64(tt.parent[:]), tt.name)
+		}
+		if s.EndTime <= s.StartTime || time.Duration(s.Duration) != tt.dur {
+			t.Errorf("span %q: got start %d, end %d, duration %d", s.Name, s.StartTime, s.EndTime, s.Duration)
+		}
+		var keys []string
+		for _, f := range s.Fields {
+			keys = append(keys, f.Key)
+		}
+		if len(keys) != len(tt.fields) || (len(keys) > 0 && keys[0] != tt.fields[0]) {
+			t.Errorf("span %q: got fields %v, want %v", s.Name, keys, tt.fields)
+		}
+	}
+	if inner.StartTime < outer.StartTime || inner.EndTime > outer.EndTime {
+		t.Errorf("inner span [%d, %d] not within outer span [%d, %d]",
+			inner.StartTime, inner.EndTime, outer.StartTime, outer.EndTime)
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	req := &model.Request{
+		Type:   model.RPCCall,
+		SpanID: model.SpanID{0, 0, 0, 0, 0, 0, 0, 1},
+		Start:  time.Now(),
+		Traced: true,
+		RPCData: &model.RPCData{
+			Desc:       &model.RPCDesc{Service: "service", Endpoint: "endpoint"},
+			HTTPMethod: "POST",
+			Path:       "/path",
+		},
+	}
+	query := func(l *trace.Log, id uint64, query string) {
+		l.DBQueryStart(trace.DBQueryStartParams{Query: query, SpanID: req.SpanID, QueryID: id})
+		l.DBQueryEnd(id, nil)
+	}
+
+	tests := []struct {
+		name   string
+		policy trace.TruncationPolicy
+		emit   func(l *trace.Log)
+		want   int // number of queries parsed
+	}{
+		{
+			// A large query start is dropped while its end still fits.
+			name:   "stop",
+			policy: trace.TruncateStop,
+			emit: func(l *trace.Log) {
+				query(l, 1, strings.Repeat("x", 1000))
+				query(l, 2, "SELECT 2")
+			},
+			want: 1,
+		},
+		{
+			// The oldest events are dropped, including query starts
+			// and the goroutine start, while their ends are kept.
+			name:   "drop_oldest",
+			policy: trace.TruncateDropOldest,
+			emit: func(l *trace.Log) {
+				l.GoStart(req.SpanID, 2)
+				for i := uint64(1); i <= 20; i++ {
+					query(l, i, "SELECT 1")
+				}
+				l.GoEnd(req.SpanID, 2)
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := trace.NewFactory(nil, trace.Limits{Policy: tt.policy}).NewLogger().(*trace.Log)
+			l.BeginRequest(req, 0)
+			l.SetMaxSize(l.Len() + 200)
+			tt.emit(l)
+			l.FinishRequest(req, &model.Response{HTTPStatus: 200})
+
+			logger := zerolog.New(zerolog.NewTestWriter(t))
+			reqs, err := Parse(&logger, ID{}, l.GetAndClear(), trace.CurrentVersion, nil)
+			if err != nil {
+				t.Fatalf("failed to parse trace: %v", err)
+			}
+			if len(reqs) != 1 {
+				t.Fatalf("got %d requests, want 1", len(reqs))
+			}
+			r := reqs[0]
+			var queries int
+			for _, ev := range r.Events {
+				if q := ev.GetQuery(); q != nil {
+					queries++
+					if q.EndTime == 0 {
+						t.Errorf("query %q has no end", q.Query)
+					}
+				}
+			}
+			if queries != tt.want {
+				t.Errorf("got %d queries, want %d", queries, tt.want)
+			}
+			if r.TruncatedEvents == 0 || r.TruncatedBytes == 0 || r.TruncatedTime == 0 {
+				t.Errorf("got truncation %d events, %d bytes at %d, want non-zero",
+					r.TruncatedEvents, r.TruncatedBytes, r.TruncatedTime)
+			}
+			if r.EndTime == 0 {
+				t.Error("request end not parsed")
+			}
+		})
+	}
+}
+
+func TestParseExternalLogMessage(t *testing.T) {
+	externalID := model.SpanID{0, 0, 0, 0, 0, 0, 0, 9}
+	logMessage := func(ut *userSpanTrace, typ trace.EventType, spanID model.SpanID) {
+		tb := trace.NewBuffer(64)
+		if typ == trace.ExternalLogMessage {
+			tb.Bytes(ut.req.SpanID[:])
+		}
+		tb.Bytes(spanID[:])
+		tb.UVarint(1) // goctr
+		tb.Byte(2)    // info
+		tb.String("imported")
+		addStringFields(&tb, []string{"source", "legacy"})
+		tb.Byte(0) // empty stack
+		ut.log.Add(typ, tb.Buf())
+	}
+
+	t.Run("external", func(t *testing.T) {
+		ut := newUserSpanTrace()
+		logMessage(ut, trace.ExternalLogMessage, externalID)
+		logger := zerolog.New(zerolog.NewTestWriter(t))
+		reqs, err := Parse(&logger, ID{}, ut.finish(), trace.CurrentVersion, nil)
+		if err != nil {
+			t.Fatalf("failed to parse trace: %v", err)
+		}
+		if len(reqs) != 1 {
+			t.Fatalf("got %d requests, want 1", len(reqs))
+		}
+		var logs []*tracepb.LogMessage
+		for _, ev := range reqs[0].Events {
+			if l := ev.GetLog(); l != nil {
+				logs = append(logs, l)
+			}
+		}
+		if len(logs) != 1 {
+			t.Fatalf("got %d log messages, want 1", len(logs))
+		}
+		if l := logs[0]; l.SpanId != bin.Uint64(externalID[:]) || l.Msg != "imported" || len(l.Fields) != 1 {
+			t.Errorf("got log message for span %d: %q with %d fields", l.SpanId, l.Msg, len(l.Fields))
+		}
+	})
+
+	t.Run("unknown_span", func(t *testing.T) {
+		ut := newUserSpanTrace()
+		logMessage(ut, trace.LogMessage, externalID)
+		logger := zerolog.New(zerolog.NewTestWriter(t))
+		if _, err := Parse(&logger, ID{}, ut.finish(), trace.CurrentVersion, nil); err == nil {
+			t.Fatal("got nil error for log message with unknown span")
+		}
+	})
+}
@@ -127,7 +127,9 @@ func Parse(log *zerolog.Logger, traceID ID, data []byte, version trace.Version,
 		publishMap:   make(map[uint64]*tracepb.PubsubMsgPublished),
 		serviceInits: make(map[uint64]*tracepb.ServiceInit),
 		cacheMap:     make(map[uint64]*tracepb.CacheOp),
+		userSpanMap:  make(map[uint64]*tracepb.UserSpan),
 	}
+	tp.truncated = hasEvent(data, trace.TraceTruncated)
 	if err := tp.Parse(); err != nil {
 		return nil, err
 	}
@@ -159,6 +161,11 @@ type traceParser struct {
 	publishMap   map[uint64]*tracepb.PubsubMsgPublished
 	serviceInits map[uint64]*tracepb.ServiceInit
 	cacheMap     map[uint64]*tracepb.CacheOp
+	userSpanMap  map[uint64]*tracepb.UserSpan
+
+	// truncated is whether events were dropped from the trace,
+	// in which case span end events may lack their start events.
+	truncated bool
 }
 
 func (tp *traceParser) Parse() error {
@@ -179,6 +186,10 @@ func (tp *traceParser) Parse() error {
 			tp.log.Info().Msgf("trace: event #%d: unknown event type %s, skipping", i, ev.String())
 			tp.Skip(size)
 			err = nil
+		} else if errors.Is(err, errOrphanEvent) {
+			tp.log.Debug().Err(err).Msgf("trace: event #%d: start of %s dropped from truncated trace, skipping", i, ev.String())
+			tp.Skip(startOff + size - tp.Offset())
+			err = nil
 		} else if err != nil {
 			return eerror.WithMeta(err, map[string]any{"event#": i, "event": ev.String()})
 		}
@@ -196,7 +207,35 @@ func (tp *traceParser) Parse() error {
 	return nil
 }
 
-var errUnknownEvent = errors.New("unknown event")
+var (
+	errUnknownEvent = errors.New("unknown event")
+	errOrphanEvent  = errors.New("orphan event")
+)
+
+// orphanEnd returns the error for an event ending a span that was not
+// started. Truncated traces may have dropped the start event while keeping
+// the end event, which is then skipped; otherwise the trace is invalid.
+func (tp *traceParser) orphanEnd(err error) error {
+	if tp.truncated {
+		return fmt.Errorf("%w: %v", errOrphanEvent, err)
+	}
+	return err
+}
+
+// hasEvent reports whether the trace data contains an event of type typ.
+func hasEvent(data []byte, typ trace.EventType) bool {
+	for len(data) >= 13 {
+		if trace.EventType(data[0]) == typ {
+			return true
+		}
+		size := 13 + int(bin.Uint32(data[9:13]))
+		if size > len(data) {
+			break
+		}
+		data = data[size:]
+	}
+	return false
+}
 
 func (tp *traceParser) parseEventV3(ev trace.EventType, ts uint64, size int) error {
 	switch ev {
@@ -235,6 +274,8 @@ func (tp *traceParser) parseEventV3(ev trace.EventType, ts uint64, size int) err
 		return tp.httpBodyClosed(ts)
 	case trace.LogMessage:
 		return tp.logMessage(ts)
+	case trace.ExternalLogMessage:
+		return tp.externalLogMessage(ts)
 	case trace.PublishStart:
 		return tp.publishStart(ts)
 	case trace.PublishEnd:
@@ -249,6 +290,12 @@ func (tp *traceParser) parseEventV3(ev trace.EventType, ts uint64, size int) err
 		return tp.cacheOpEnd(ts)
 	case trace.BodyStream:
 		return tp.bodyStream(ts)
+	case trace.UserSpanStart:
+		return tp.userSpanStart(ts)
+	case trace.UserSpanEnd:
+		return tp.userSpanEnd(ts)
+	case trace.TraceTruncated:
+		return tp.traceTruncated(ts)
 	default:
 		return errUnknownEvent
 	}
@@ -548,7 +595,7 @@ func (tp *traceParser) goroutineEnd(ts uint64) error {
 	k := goKey{spanID: spanID, goid: goid}
 	g, ok := tp.goMap[k]
 	if !ok {
-		return eerror.New("trace_parser", "unknown goroutine id", map[string]any{"goid": goid})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown goroutine id", map[string]any{"goid": goid}))
 	}
 	g.EndTime = ts
 	delete(tp.goMap, k)
@@ -561,7 +608,7 @@ func (tp *traceParser) goroutineClear(ts uint64) error {
 	k := goKey{spanID: spanID, goid: goid}
 	g, ok := tp.goMap[k]
 	if !ok {
-		return eerror.New("trace_parser", "unknown goroutine id", map[string]any{"spanID": spanID, "goid": goid})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown goroutine id", map[string]any{"spanID": spanID, "goid": goid}))
 	}
 	g.EndTime = ts
 	delete(tp.goMap, k)
@@ -600,7 +647,7 @@ func (tp *traceParser) transactionEnd(ts uint64) error {
 	_ = tp.Uint64() // spanID
 	tx, ok := tp.txMap[txid]
 	if !ok {
-		return eerror.New("trace_parser", "unknown transaction id", map[string]any{"txid": txid})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown transaction id", map[string]any{"txid": txid}))
 	}
 	_ = uint32(tp.UVarint()) // goid
 	compl := tp.Byte()
@@ -657,10 +704,16 @@ func (tp *traceParser) queryStart(ts uint64) error {
 
 	if txid != 0 {
 		tx, ok := tp.txMap[txid]
-		if !ok {
+		if ok {
+			tx.Queries = append(tx.Queries, q)
+		} else if !tp.truncated {
 			return eerror.New("trace_parser", "unknown transaction id", map[string]any{"txid": txid})
+		} else {
+			// The transaction start was dropped from the trace.
+			req.Events = append(req.Events, &tracepb.Event{
+				Data: &tracepb.Event_Query{Query: q},
+			})
 		}
-		tx.Queries = append(tx.Queries, q)
 	} else {
 		req.Events = append(req.Events, &tracepb.Event{
 			Data: &tracepb.Event_Query{Query: q},
@@ -674,7 +727,7 @@ func (tp *traceParser) queryEnd(ts uint64) error {
 	qid := tp.UVarint()
 	q, ok := tp.queryMap[qid]
 	if !ok {
-		return eerror.New("trace_parser", "unknown query id", map[string]any{"qid": qid})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown query id", map[string]any{"qid": qid}))
 	}
 	q.EndTime = ts
 	q.Err = tp.ByteString()
@@ -723,7 +776,7 @@ func (tp *traceParser) callEnd(ts uint64) error {
 	errMsg := tp.ByteString()
 	c, ok := tp.callMap[callID].(*tracepb.RPCCall)
 	if !ok {
-		return eerror.New("trace_parser", "unknown call ", map[string]any{"callID": callID})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown call ", map[string]any{"callID": callID}))
 	}
 	c.EndTime = ts
 	c.Err = errMsg
@@ -759,7 +812,7 @@ func (tp *traceParser) httpEnd(ts uint64) error {
 	status := tp.UVarint()
 	c, ok := tp.httpMap[callID]
 	if !ok {
-		return eerror.New("trace_parser", "unknown call ", map[string]any{"callID": callID})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown call ", map[string]any{"callID": callID}))
 	}
 	c.EndTime = ts
 	c.Err = errMsg
@@ -783,7 +836,7 @@ func (tp *traceParser) httpBodyClosed(ts uint64) error {
 	_ = tp.ByteString() // close error
 	c, ok := tp.httpMap[callID]
 	if !ok {
-		return eerror.New("trace_parser", "unknown call ", map[string]any{"callID": callID})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown call ", map[string]any{"callID": callID}))
 	}
 	c.BodyClosedTime = ts
 	delete(tp.httpMap, callID)
@@ -896,15 +949,34 @@ func (tp *traceParser) httpEvent() (*tracepb.HTTPTraceEvent, error) {
 
 func (tp *traceParser) logMessage(ts uint64) error {
 	spanID := tp.Uint64()
+	req, ok := tp.reqMap[spanID]
+	if !ok {
+		return eerror.New("trace_parser", "unknown request", map[string]any{"spanID": spanID})
+	}
+	return tp.parseLogMessage(ts, req, spanID)
+}
+
+// externalLogMessage parses a log message recorded with an explicit span id
+// (see rlog.Manager.LogWithSpan), which need not be part of this trace.
+// It is added to the events of the request that logged it, and keeps
+// the explicit span id.
+func (tp *traceParser) externalLogMessage(ts uint64) error {
+	reqSpanID := tp.Uint64()
+	req, ok := tp.reqMap[reqSpanID]
+	if !ok {
+		return eerror.New("trace_parser", "unknown request", map[string]any{"spanID": reqSpanID})
+	}
+	return tp.parseLogMessage(ts, req, tp.Uint64())
+}
+
+// parseLogMessage parses the rest of a log message with the given span id
+// and adds it to the events of req.
+func (tp *traceParser) parseLogMessage(ts uint64, req *tracepb.Request, spanID uint64) error {
 	goid := uint32(tp.UVarint())
 	level := tp.Byte()
 	msg := tp.String()
 	fields := int(tp.UVarint())
-
-	req, ok := tp.reqMap[spanID]
-	if !ok {
-		return eerror.New("trace_parser", "unknown request", map[string]any{"spanID": spanID})
-	} else if fields > 64 {
+	if fields > 64 {
 		return eerror.New("trace_parser", "too many fields", map[string]any{"fields": fields})
 	}
 
@@ -1011,6 +1083,74 @@ func (tp *traceParser) logField() (*tracepb.LogField, error) {
 	return f, nil
 }
 
+// userSpanStart parses the start of an application-defined span.
+// The span is recorded as an event of the request it was started in,
+// even if it is nested in another user span, and log messages logged
+// within it are attributed to that request.
+func (tp *traceParser) userSpanStart(ts uint64) error {
+	spanID := tp.Uint64()
+	parentID := tp.Uint64()
+	req, ok := tp.reqMap[parentID]
+	if !ok {
+		return eerror.New("trace_parser", "unknown request", map[string]any{"spanID": parentID})
+	}
+	span := &tracepb.UserSpan{
+		SpanId:       spanID,
+		ParentSpanId: parentID,
+		Goid:         uint32(tp.UVarint()),
+		StartTime:    ts,
+		Name:         tp.String(),
+	}
+	fields, err := tp.logFields()
+	if err != nil {
+		return err
+	}
+	span.Fields = fields
+	span.Stack = tp.stack(filterNone)
+
+	tp.reqMap[spanID] = req
+	tp.userSpanMap[spanID] = span
+	req.Events = append(req.Events, &tracepb.Event{
+		Data: &tracepb.Event_UserSpan{UserSpan: span},
+	})
+	return nil
+}
+
+func (tp *traceParser) userSpanEnd(ts uint64) error {
+	spanID := tp.Uint64()
+	span, ok := tp.userSpanMap[spanID]
+	if !ok {
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown user span", map[string]any{"spanID": spanID}))
+	}
+	span.EndTime = ts
+	span.Duration = tp.Int64()
+	fields, err := tp.logFields()
+	if err != nil {
+		return err
+	}
+	span.Fields = append(span.Fields, fields...)
+	delete(tp.userSpanMap, spanID)
+	return nil
+}
+
+// logFields parses a list of log fields, as recorded
+// for application-defined spans.
+func (tp *traceParser) logFields() ([]*tracepb.LogField, error) {
+	n := int(tp.UVarint())
+	if n > 64 {
+		return nil, eerror.New("trace_parser", "too many fields", map[string]any{"fields": n})
+	}
+	fields := make([]*tracepb.LogField, 0, n)
+	for i := 0; i < n; i++ {
+		f, err := tp.logField()
+		if err != nil {
+			return nil, eerror.Wrap(err, "trace_parser", "error parsing field", map[string]any{"field#": i})
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
 func (tp *traceParser) publishStart(ts uint64) error {
 	publishID := tp.UVarint()
 	spanID := tp.Uint64()
@@ -1038,7 +1178,7 @@ func (tp *traceParser) publishEnd(ts uint64) error {
 	publishID := tp.UVarint()
 	publish, ok := tp.publishMap[publishID]
 	if !ok {
-		return eerror.New("trace_parser", "unknown publish", map[string]any{"publishID": publishID})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown publish", map[string]any{"publishID": publishID}))
 	}
 	publish.EndTime = ts
 	publish.MessageId = tp.String()
@@ -1073,7 +1213,7 @@ func (tp *traceParser) serviceInitEnd(ts uint64) error {
 	initID := tp.UVarint()
 	svcInit, ok := tp.serviceInits[initID]
 	if !ok {
-		return eerror.New("trace_parser", "unknown service init", map[string]any{"initID": initID})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown service init", map[string]any{"initID": initID}))
 	}
 	svcInit.EndTime = ts
 	svcInit.Err = tp.ByteString()
@@ -1125,7 +1265,7 @@ func (tp *traceParser) cacheOpEnd(ts uint64) error {
 	opID := tp.UVarint()
 	op, ok := tp.cacheMap[opID]
 	if !ok {
-		return eerror.New("trace_parser", "unknown cache", map[string]any{"opID": opID})
+		return tp.orphanEnd(eerror.New("trace_parser", "unknown cache", map[string]any{"opID": opID}))
 	}
 	op.EndTime = ts
 
@@ -1152,6 +1292,26 @@ func (tp *traceParser) cacheOpEnd(ts uint64) error {
 	return nil
 }
 
+// traceTruncated records the events dropped from the trace on the
+// operation's first request, which the trace is shown under.
+// A trace streamed in chunks may report dropped events in each chunk.
+func (tp *traceParser) traceTruncated(ts uint64) error {
+	_ = tp.Byte() // truncation policy
+	events := tp.UVarint()
+	size := tp.UVarint()
+	if len(tp.reqs) == 0 {
+		tp.log.Warn().Msg("trace: truncation marker without request, skipping")
+		return nil
+	}
+	req := tp.reqs[0]
+	if req.TruncatedEvents == 0 || ts < req.TruncatedTime {
+		req.TruncatedTime = ts
+	}
+	req.TruncatedEvents += events
+	req.TruncatedBytes += size
+	return nil
+}
+
 type stackFilter int
 
 const (
@@ -276,6 +276,11 @@ func (mgr *Manager) generateConfig(p generateConfigParams) (*config.Runtime, err
 		return nil, errors.Wrap(err, "failed to get global CORS")
 	}
 
+	errorFormat, err := p.App.ErrorFormat()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get error format")
+	}
+
 	return &config.Runtime{
 		AppID:           p.ConfigAppID,
 		AppSlug:         p.App.PlatformID(),
@@ -294,6 +299,8 @@ func (mgr *Manager) generateConfig(p generateConfigParams) (*config.Runtime, err
 		RedisServers:    redisServers,
 		RedisDatabases:  redisDBs,
 		AuthKeys:        []config.EncoreAuthKey{p.AuthKey},
+		ErrorCauses:     true, // include error causes in responses for local development
+		ErrorFormat:     errorFormat,
 		CORS: &config.CORS{
 			Debug: globalCORS.Debug,
 			AllowOriginsWithCredentials: []string{
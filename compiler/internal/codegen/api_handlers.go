@@ -320,7 +320,7 @@ func (b *rpcBuilder) renderDecodeReq() *Statement {
 			}
 
 		}, func(g *Group) {
-			g.Return(Nil(), Nil(), dec.LastError())
+			g.Return(Nil(), Nil(), dec.ValidationErr())
 		})...)
 
 		g.Return(Id("reqData"), Id("ps"), Nil())
@@ -65,7 +65,7 @@ func (b *Builder) Main(compilerVersion string, mainPkgPath, mainFuncName string)
 	f.Comment("loadApp loads the Encore app runtime.")
 	f.Comment("//go:linkname loadApp encore.dev/appruntime/app/appinit.load")
 	f.Func().Id("loadApp").Params().Op("*").Qual("encore.dev/appruntime/app/appinit", "LoadData").BlockFunc(func(g *Group) {
-		g.Id("static").Op(":=").Op("&").Qual("encore.dev/appruntime/config", "Static").Values(Dict{
+		static := Dict{
 			Id("AuthData"):       b.authDataType(),
 			Id("EncoreCompiler"): Lit(compilerVersion),
 			Id("AppCommit"): Qual("encore.dev/appruntime/config", "CommitInfo").Values(Dict{
@@ -77,7 +77,11 @@ func (b *Builder) Main(compilerVersion string, mainPkgPath, mainFuncName string)
 			Id("Testing"):         False(),
 			Id("TestService"):     Lit(""),
 			Id("BundledServices"): b.computeBundledServices(),
-		})
+		}
+		if rates := b.computeTraceSampleRates(); rates != nil {
+			static[Id("TraceSampleRates")] = rates
+		}
+		g.Id("static").Op(":=").Op("&").Qual("encore.dev/appruntime/config", "Static").Values(static)
 		g.Id("handlers").Op(":=").Add(b.computeHandlerRegistrationConfig(mwNames))
 
 		authHandlerExpr := Nil()
@@ -258,6 +262,24 @@ func (b *Builder) computeBundledServices() Code {
 	})
 }
 
+// computeTraceSampleRates computes the trace sampling rates declared
+// by the RPCs of the app, keyed by "service.endpoint".
+// It returns nil if no RPC declares one.
+func (b *Builder) computeTraceSampleRates() Code {
+	rates := Dict{}
+	for _, svc := range b.res.App.Services {
+		for _, rpc := range svc.RPCs {
+			if rpc.TraceSample != nil {
+				rates[Lit(svc.Name+"."+rpc.Name)] = Lit(*rpc.TraceSample)
+			}
+		}
+	}
+	if len(rates) == 0 {
+		return nil
+	}
+	return Map(String()).Float64().Values(rates)
+}
+
 func (b *Builder) getSvcNum(svc *est.Service) int {
 	sortedNames := make([]string, 0, len(b.res.App.Services))
 	for _, svc := range b.res.App.Services {
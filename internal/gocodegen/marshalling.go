@@ -13,6 +13,7 @@ const UnknownPkgPath = "__unknown_path__"
 
 const (
 	lastErrorField      = "LastError"
+	violationsField     = "Violations"
 	nonEmptyValuesField = "NonEmptyValues"
 )
 
@@ -24,6 +25,7 @@ type MarshallingCodeGenerator struct {
 	structName          string
 	used                bool
 	encoreTypesAsString bool // true if  auth.UID and uuid.UUID should be treated as strings?
+	recordViolations    bool // true if decoding errors are recorded as errs.ValidationErrors
 
 	builtins     []methodDescription
 	seenBuiltins map[methodKey]methodDescription
@@ -64,6 +66,7 @@ func NewMarshallingCodeGenerator(pkgPath, structName string, forClientGen bool)
 		builtins:            nil,
 		seenBuiltins:        make(map[methodKey]methodDescription),
 		encoreTypesAsString: forClientGen,
+		recordViolations:    !forClientGen,
 	}
 }
 
@@ -104,10 +107,13 @@ func (g *MarshallingCodeGenerator) WriteToFile(f *File) {
 	}
 
 	f.Commentf("%s is used to serialize request data into strings and deserialize response data from strings", g.structName)
-	f.Type().Id(g.structName).Struct(
-		Id(lastErrorField).Error().Comment("The last error that occurred"),
-		Id(nonEmptyValuesField).Int().Comment("The number of values this decoder has decoded"),
-	)
+	f.Type().Id(g.structName).StructFunc(func(grp *Group) {
+		grp.Id(lastErrorField).Error().Comment("The last error that occurred")
+		grp.Id(nonEmptyValuesField).Int().Comment("The number of values this decoder has decoded")
+		if g.recordViolations {
+			grp.Id(violationsField).Qual("encore.dev/beta/errs", "ValidationErrors").Comment("The field violations that occurred")
+		}
+	})
 
 	for _, desc := range g.builtins {
 		var params []Code
@@ -136,17 +142,26 @@ func (g *MarshallingCodeGenerator) WriteToFile(f *File) {
 		f.Line()
 	}
 
-	f.Comment("setErr sets the last error within the object if one is not already set")
-	f.Func().Params(Id("e").Op("*").Id(g.structName)).Id("setErr").Params(List(Id("msg"), Id("field")).String(), Err().Error()).Block(
-		If(Err().Op("!=").Nil().Op("&&").Id("e").Dot(lastErrorField).Op("==").Nil()).Block(
-			Id("e").Dot(lastErrorField).Op("=").Qual("fmt", "Errorf").Call(
-				Lit("%s: %s: %w"),
-				Id("field"),
-				Id("msg"),
-				Id("err"),
-			),
-		),
+	setLastErr := Id("e").Dot(lastErrorField).Op("=").Qual("fmt", "Errorf").Call(
+		Lit("%s: %s: %w"),
+		Id("field"),
+		Id("msg"),
+		Id("err"),
 	)
+	if g.recordViolations {
+		f.Comment("setErr records a violation of the field and sets the last error within the object if one is not already set")
+		f.Func().Params(Id("e").Op("*").Id(g.structName)).Id("setErr").Params(List(Id("msg"), Id("field")).String(), Err().Error()).Block(
+			If(Err().Op("!=").Nil()).Block(
+				Id("e").Dot(violationsField).Dot("Addf").Call(Id("field"), Lit("format"), Lit("%s: %v"), Id("msg"), Err()),
+				If(Id("e").Dot(lastErrorField).Op("==").Nil()).Block(setLastErr),
+			),
+		)
+	} else {
+		f.Comment("setErr sets the last error within the object if one is not already set")
+		f.Func().Params(Id("e").Op("*").Id(g.structName)).Id("setErr").Params(List(Id("msg"), Id("field")).String(), Err().Error()).Block(
+			If(Err().Op("!=").Nil().Op("&&").Id("e").Dot(lastErrorField).Op("==").Nil()).Block(setLastErr),
+		)
+	}
 	f.Line()
 
 	if g.usedBody {
@@ -434,6 +449,12 @@ func (w *MarshallingCodeWrapper) LastError() Code {
 	return Id(w.instanceName).Dot(lastErrorField)
 }
 
+// ValidationErr returns the error reporting all the field violations
+// that occurred, as an *errs.Error. It must not be used for client generation.
+func (w *MarshallingCodeWrapper) ValidationErr() Code {
+	return Id(w.instanceName).Dot(violationsField).Dot("Err").Call()
+}
+
 // Add adds code into the wrapped block
 func (w *MarshallingCodeWrapper) Add(c ...Code) {
 	w.code = append(w.code, c...)
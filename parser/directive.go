@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"strconv"
 	"strings"
 
 	"encr.dev/parser/est"
@@ -134,6 +135,12 @@ func parseDirective(pos token.Pos, line string) (directive, error) {
 						}
 					case "method":
 						rpc.Method = strings.Split(parts[1], ",")
+					case "trace_sample":
+						rate, err := strconv.ParseFloat(parts[1], 64)
+						if err != nil || rate < 0 || rate > 1 {
+							return nil, fmt.Errorf("invalid trace_sample %q: must be a number between 0 and 1", parts[1])
+						}
+						rpc.TraceSample = &rate
 					default:
 						return nil, fmt.Errorf("unrecognized encore:api directive field: %q", parts[0])
 					}
@@ -253,6 +260,10 @@ type rpcDirective struct {
 	Method   []string
 	Path     *paths.Path // nil if not specified
 	Tags     selector.Set
+
+	// TraceSample is the fraction of requests to trace, overriding
+	// the application's sampling rate, or nil if not specified.
+	TraceSample *float64
 }
 
 // An authHandlerDirective is the parsed representation of the encore:authhandler directive.
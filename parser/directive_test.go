@@ -14,6 +14,7 @@ import (
 
 func TestParseDirective(t *testing.T) {
 	const staticPos = token.Pos(0)
+	float := func(f float64) *float64 { return &f }
 
 	testcases := []struct {
 		desc        string
@@ -99,6 +100,21 @@ func TestParseDirective(t *testing.T) {
 			line:        "api public tag:foo.bar",
 			expectedErr: `invalid tag format "tag:foo.bar": invalid value`,
 		},
+		{
+			desc:        "api with trace sample rate",
+			line:        "api public trace_sample=0.01",
+			expectedErr: "",
+			expected: &rpcDirective{
+				Access:      est.Public,
+				TokenPos:    staticPos,
+				TraceSample: float(0.01),
+			},
+		},
+		{
+			desc:        "api with out of range trace sample rate",
+			line:        "api public trace_sample=2",
+			expectedErr: `invalid trace_sample "2": must be a number between 0 and 1`,
+		},
 		{
 			desc: "middleware",
 			line: "middleware target=tag:foo,tag:bar",
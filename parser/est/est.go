@@ -251,6 +251,10 @@ type RPC struct {
 	Response    *Param // response data; nil for Raw RPCs
 	Tags        selector.Set
 
+	// TraceSample is the fraction of requests to the RPC to trace,
+	// as declared with "trace_sample=", or nil if not declared.
+	TraceSample *float64
+
 	// SvcStruct is the service struct this RPC is defined on,
 	// or nil otherwise. It is always a pointer receiver.
 	SvcStruct *ServiceStruct
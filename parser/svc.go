@@ -188,6 +188,7 @@ func (p *parser) parseFuncs(pkg *est.Package, svc *est.Service) (isService bool)
 					Path:        path,
 					HTTPMethods: dir.Method,
 					Tags:        dir.Tags,
+					TraceSample: dir.TraceSample,
 				}
 				p.initRPC(rpc)
 
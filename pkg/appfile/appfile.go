@@ -35,6 +35,11 @@ type File struct {
 	// Configure global CORS settings for the application which
 	// will be applied to all API gateways into the application.
 	GlobalCORS *CORS `json:"global_cors,omitempty"`
+
+	// ErrorFormat is the format of API error responses: "json" (the default)
+	// for Encore's JSON encoding of errors, or "problem" for RFC 7807
+	// problem details ("application/problem+json").
+	ErrorFormat string `json:"error_format,omitempty"`
 }
 
 type CORS struct {
@@ -100,3 +105,13 @@ func GlobalCORS(appRoot string) (*CORS, error) {
 	}
 	return f.GlobalCORS, nil
 }
+
+// ErrorFormat returns the format of API error responses
+// for the app located at appRoot.
+func ErrorFormat(appRoot string) (string, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return "", err
+	}
+	return f.ErrorFormat, nil
+}
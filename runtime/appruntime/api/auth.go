@@ -50,10 +50,14 @@ func (d *AuthHandlerDesc[Params]) Authenticate(c IncomingContext) (model.AuthInf
 	go func() {
 		defer close(done)
 		_, authErr = c.server.beginRequest(c.req.Context(), &beginRequestParams{
-			TraceID: c.traceID,
-			SpanID:  call.SpanID,
-			DefLoc:  d.DefLoc,
-			Type:    model.AuthHandler,
+			TraceID:    c.traceID,
+			SpanID:     call.SpanID,
+			ParentID:   c.trace.parent.ParentID,
+			TraceState: c.trace.parent.State,
+			Link:       c.trace.link,
+			Baggage:    baggage(c.req),
+			DefLoc:     d.DefLoc,
+			Type:       model.AuthHandler,
 			Data: &model.RPCData{
 				Desc:               d.rpcDesc(),
 				NonRawPayload:      d.marshalParams(c.server.json, param),
@@ -115,7 +119,7 @@ func (s *Server) runAuthHandler(h Handler, c IncomingContext) (info model.AuthIn
 		if errs.Code(err) == errs.Unauthenticated && !requiresAuth {
 			return model.AuthInfo{}, true
 		} else {
-			errs.HTTPError(c.w, err)
+			c.writeErr(err, 0)
 			return model.AuthInfo{}, false
 		}
 	}
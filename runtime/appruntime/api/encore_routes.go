@@ -16,9 +16,17 @@ func (s *Server) RegisterPubsubSubscriptionHandler(subscriptionID string, handle
 	s.pubsubSubscriptions[subscriptionID] = handler
 }
 
+// RegisterLogLevelHandler registers the handler for inspecting and changing the log level
+//
+// This is an internal Encore API and should not be used.
+func (s *Server) RegisterLogLevelHandler(handler http.Handler) {
+	s.logLevelHandler = handler
+}
+
 func (s *Server) registerEncoreRoutes() {
 	s.encore.HandlerFunc(wildcardMethod, "/healthz", s.handleHealthz)
 	s.encore.Handle("POST", "/pubsub/push/:subscription_id", s.handlePubsubPush)
+	s.encore.HandlerFunc(wildcardMethod, "/loglevel", s.handleLogLevel)
 }
 
 // handleHealthz returns the current health and deployment details of the running Encore application
@@ -71,3 +79,17 @@ func (s *Server) handlePubsubPush(w http.ResponseWriter, req *http.Request, ps h
 	}
 	errs.HTTPError(w, err)
 }
+
+// handleLogLevel inspects or changes the log level of the running application.
+// Only requests authenticated by the Encore Platform are allowed.
+func (s *Server) handleLogLevel(w http.ResponseWriter, req *http.Request) {
+	if !IsEncorePlatformRequest(req.Context()) {
+		errs.HTTPError(w, errs.B().Code(errs.PermissionDenied).Msg("permission denied").Err())
+		return
+	}
+	if s.logLevelHandler == nil {
+		errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("endpoint not found").Err())
+		return
+	}
+	s.logLevelHandler.ServeHTTP(w, req)
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+
+	"encore.dev/beta/errs"
+)
+
+// writeErr writes err in the response to the request with the given status
+// code, or the code computed from err if it is zero, in the error format
+// configured for the app.
+func (c IncomingContext) writeErr(err error, status int) {
+	c.server.writeErr(c.w, c.req, c.respErr(err), status)
+}
+
+func (s *Server) writeErr(w http.ResponseWriter, req *http.Request, err error, status int) {
+	if s.cfg.Runtime.ErrorFormat == "problem" {
+		errs.HTTPProblemWithCode(w, err, status, req.URL.Path)
+	} else {
+		errs.HTTPErrorWithCode(w, err, status)
+	}
+}
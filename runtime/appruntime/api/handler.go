@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"reflect"
 	"runtime/debug"
@@ -12,6 +13,7 @@ import (
 
 	encore "encore.dev"
 	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace/recording"
 	"encore.dev/beta/errs"
 	"encore.dev/middleware"
 )
@@ -97,7 +99,7 @@ func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 
 	reqData, beginErr := d.begin(c)
 	if beginErr != nil {
-		errs.HTTPError(c.w, beginErr)
+		c.writeErr(beginErr, 0)
 		return
 	}
 
@@ -108,7 +110,7 @@ func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 		// If the endpoint is raw it has already written its response;
 		// don't write another.
 		if !d.Raw {
-			errs.HTTPErrorWithCode(c.w, resp.Err, resp.HTTPStatus)
+			c.writeErr(resp.Err, resp.HTTPStatus)
 		}
 		return
 	}
@@ -144,9 +146,13 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 	}
 
 	_, err := c.server.beginRequest(c.ctx, &beginRequestParams{
-		Type:    model.RPCCall,
-		DefLoc:  d.DefLoc,
-		TraceID: c.traceID,
+		Type:       model.RPCCall,
+		DefLoc:     d.DefLoc,
+		TraceID:    c.traceID,
+		ParentID:   c.trace.parent.ParentID,
+		TraceState: c.trace.parent.State,
+		Link:       c.trace.link,
+		Baggage:    baggage(c.req),
 
 		Data: &model.RPCData{
 			Desc:               d.rpcDesc(),
@@ -163,6 +169,8 @@ func (d *Desc[Req, Resp]) begin(c IncomingContext) (reqData Req, beginErr error)
 
 		ExtRequestID:     clampTo64Chars(c.req.Header.Get("X-Request-ID")),
 		ExtCorrelationID: clampTo64Chars(c.req.Header.Get("X-Correlation-ID")),
+		TraceOnlyLogs:    traceOnlyLogsRequested(c.req),
+		DebugLogs:        debugLogsRequested(c.req),
 	})
 	if err != nil {
 		beginErr = errs.B().Code(errs.Internal).Msg("internal error").Err()
@@ -204,6 +212,10 @@ func (d *Desc[Req, Resp]) handleIncoming(c IncomingContext, reqData Req) (resp *
 	respData, httpStatus, err := d.executeEndpoint(c.execContext, invokeHandler)
 
 	resp = newResp(respData, httpStatus, err, d.Raw, c.capturer, respCapturer, c.server.json)
+	if rec, _ := c.req.Context().Value(recorderKey).(*recording.Recorder); rec != nil {
+		// Redact the fields tagged as sensitive from the recording.
+		rec.SetTypedPayloads(d.ReqUserPayload(reqData), resp.TypedPayload)
+	}
 	return resp, respData
 }
 
@@ -231,10 +243,14 @@ func (d *Desc[Req, Resp]) executeEndpoint(c execContext, invokeHandler func(midd
 			defer func() {
 				// Catch middleware panic
 				if e := recover(); e != nil {
-					stack := debug.Stack()
-					resp.Err = errs.B().Code(errs.Internal).Meta("panic_stack", string(stack)).Msgf("panic executing middleware %s.%s: %v\n%s",
-						mw.PkgName, mw.Name, e, stack).Err()
-					resp.HTTPStatus = 500
+					resp.Err = errs.ConvertPanic(req.Context(), errs.PanicInfo{
+						Value:      e,
+						Stack:      debug.Stack(),
+						Service:    d.Service,
+						Endpoint:   d.Endpoint,
+						Middleware: mw.PkgName + "." + mw.Name,
+					})
+					resp.HTTPStatus = errs.HTTPStatus(resp.Err)
 				}
 			}()
 			return mw.Invoke(req, nextFn)
@@ -243,9 +259,13 @@ func (d *Desc[Req, Resp]) executeEndpoint(c execContext, invokeHandler func(midd
 			defer func() {
 				// Catch handler panic
 				if e := recover(); e != nil {
-					stack := debug.Stack()
-					resp.Err = errs.B().Code(errs.Internal).Meta("panic_stack", string(stack)).Msgf("panic handling request: %v\n%s", e, stack).Err()
-					resp.HTTPStatus = 500
+					resp.Err = errs.ConvertPanic(req.Context(), errs.PanicInfo{
+						Value:    e,
+						Stack:    debug.Stack(),
+						Service:  d.Service,
+						Endpoint: d.Endpoint,
+					})
+					resp.HTTPStatus = errs.HTTPStatus(resp.Err)
 				}
 			}()
 			return invokeHandler(req)
@@ -339,7 +359,16 @@ type CallContext struct {
 	server *Server
 }
 
+// Call calls the endpoint from another service, retrying the call
+// when it fails with an error marked as retryable. See retryCall.
 func (d *Desc[Req, Resp]) Call(c CallContext, req Req) (respData Resp, respErr error) {
+	return retryCall(c.ctx, func() (Resp, error) {
+		return d.call(c, req)
+	})
+}
+
+// call makes a single attempt at calling the endpoint from another service.
+func (d *Desc[Req, Resp]) call(c CallContext, req Req) (respData Resp, respErr error) {
 	// TODO: we don't currently support service-to-service calls of raw endpoints.
 	// To fix this we need to improve our request serialization and DI support to
 	// separate the signature for outgoing calls versus handlers.
@@ -458,6 +487,11 @@ func runValidate(userPayload any) error {
 			if _, ok := err.(*errs.Error); ok {
 				return err
 			}
+			// Report collected field violations as a structured error.
+			var ve *errs.ValidationErrors
+			if errors.As(err, &ve) && ve.Len() > 0 {
+				return ve.Err()
+			}
 			return errs.WrapCode(err, errs.InvalidArgument, "validation failed")
 		}
 	}
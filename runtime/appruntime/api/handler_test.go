@@ -140,6 +140,57 @@ func TestDesc_EndToEnd(t *testing.T) {
 	)
 }
 
+func TestDesc_ErrorsTotal(t *testing.T) {
+	cfg := &config.Config{
+		Static:  &config.Static{BundledServices: []string{"service"}},
+		Runtime: &config.Runtime{},
+	}
+	logger := zerolog.Nop()
+	rt := reqtrack.New(logger, nil, trace.DefaultFactory)
+	reg := usermetrics.NewRegistry(rt, uint16(len(cfg.Static.BundledServices)))
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	server := api.NewServer(cfg, rt, nil, encore.NewManager(cfg, rt), logger, reg, json, true, clock.New())
+
+	handle := func(h api.Handler, body string) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		h.Handle(server.NewIncomingContext(w, req, api.UnnamedParams{"value"}, model.TraceID{}, model.AuthInfo{}))
+	}
+	desc := newMockAPIDesc(api.Public)
+	desc.SvcNum = 1
+	handle(desc, `{"Body": "foo"}`)
+	handle(desc, `invalid json`)
+	raw := newRawMockAPIDesc(api.Public, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	raw.SvcNum = 1
+	handle(raw, ``)
+
+	got := make(map[string]uint64)
+	for _, m := range reg.Collect() {
+		if m.Info.Name() != "e_api_errors_total" {
+			continue
+		}
+		var endpoint, code string
+		for _, kv := range m.Labels {
+			switch kv.Key {
+			case "endpoint":
+				endpoint = kv.Value
+			case "code":
+				code = kv.Value
+			}
+		}
+		got[endpoint+"/"+code] += m.Val.([]uint64)[0]
+	}
+	want := map[string]uint64{
+		"endpoint/" + errs.InvalidArgument.String(): 1,
+		"raw/" + errs.Unavailable.String():          1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e_api_errors_total mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestDescGeneratesTrace(t *testing.T) {
 	model.EnableTestMode(t)
 	klock := clock.NewMock()
@@ -250,7 +301,8 @@ func TestDescGeneratesTrace(t *testing.T) {
 	}
 
 	opts := []cmp.Option{
-		cmpopts.IgnoreFields(model.Request{}, "Logger"),
+		// TraceOnlyLogs and DebugLogs are atomic and are checked separately.
+		cmpopts.IgnoreFields(model.Request{}, "Logger", "TraceOnlyLogs", "DebugLogs"),
 		cmp.Comparer(func(a, b reflect.Type) bool { return a == b }),
 	}
 
@@ -290,6 +342,9 @@ func TestDescGeneratesTrace(t *testing.T) {
 			if diff := cmp.Diff(test.want, beginReq, opts...); diff != "" {
 				t.Errorf("beginReq mismatch (-want +got):\n%s", diff)
 			}
+			if beginReq != nil && (beginReq.TraceOnlyLogs.Load() || beginReq.DebugLogs.Load()) {
+				t.Errorf("got TraceOnlyLogs or DebugLogs, want them unset")
+			}
 		})
 	}
 }
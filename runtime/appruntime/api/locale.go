@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encore.dev/beta/errs"
+)
+
+// localer is implemented by auth data types that know
+// the locale of the authenticated user, such as "en" or "pt-BR".
+type localer interface {
+	Locale() string
+}
+
+// localizeErr renders the localized messages of err in the locale of the
+// request: the locale of the authenticated user, if the auth data knows it,
+// and otherwise the registered locale best matching the Accept-Language header.
+func (c IncomingContext) localizeErr(err error) error {
+	var locale string
+	if l, ok := c.auth.UserData.(localer); ok {
+		locale = l.Locale()
+	}
+	if locale == "" {
+		locale = errs.MatchLocale(c.req.Header.Get("Accept-Language"))
+	}
+	return errs.Localize(err, locale)
+}
+
+// respErr prepares err for writing in the response to the request,
+// localizing its messages and including its causes if configured.
+func (c IncomingContext) respErr(err error) error {
+	err = c.localizeErr(err)
+	if c.server.cfg.Runtime.ErrorCauses {
+		err = errs.WithCauses(err)
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+
+	"encore.dev/appruntime/trace/recording"
+)
+
+// recorderKey is the context key for the recorder of the current request.
+const recorderKey ctxKey = "recorder"
+
+// recordedEndpoints returns the set of endpoints whose requests
+// are recorded, or nil if requests to every endpoint are recorded.
+func recordedEndpoints(endpoints []string) map[string]bool {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	m := make(map[string]bool, len(endpoints))
+	for _, e := range endpoints {
+		m[e] = true
+	}
+	return m
+}
+
+// startRecording starts recording req, a request to h, if requests to h
+// are configured to be recorded and the request is sampled. It returns
+// the ResponseWriter and request to handle the request with, and the
+// recorder, which is nil if the request is not recorded.
+func (s *Server) startRecording(h Handler, w http.ResponseWriter, req *http.Request) (http.ResponseWriter, *http.Request, *recording.Recorder) {
+	if s.recordings == nil {
+		return w, req, nil
+	}
+	if s.recordedEndpoints != nil && !s.recordedEndpoints[h.ServiceName()+"."+h.EndpointName()] {
+		return w, req, nil
+	}
+	if rate := s.cfg.Runtime.TraceRecording.SampleRate; rate > 0 && rand.Float64() >= rate {
+		return w, req, nil
+	}
+	rec := recording.NewRecorder(req, h.ServiceName(), h.EndpointName(), s.recordingOpts)
+	req = req.WithContext(context.WithValue(req.Context(), recorderKey, rec))
+	return rec.WrapResponseWriter(w), req, rec
+}
+
+// finishRecording finishes the recording of a request,
+// part of the given trace, and writes it to a file.
+func (s *Server) finishRecording(rec *recording.Recorder, traceID string) {
+	path, err := s.recordings.Write(rec.Finish(traceID))
+	if err != nil {
+		s.rootLogger.Error().Err(err).Msg("could not write request recording")
+		return
+	}
+	s.rootLogger.Debug().Str("path", path).Msg("recorded request")
+}
+
+// ReplayRecording replays rec against the server's endpoints,
+// including private ones, serving the recorded responses to the
+// outgoing HTTP calls made while handling it. See recording.Replayer.
+func (s *Server) ReplayRecording(rec *recording.Recording, simulateLatency bool) (*recording.Result, error) {
+	rp := &recording.Replayer{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			req = req.WithContext(withEncorePlatformSealOfApproval(req.Context()))
+			s.route(s.private, w, req)
+		}),
+		SimulateLatency: simulateLatency,
+	}
+	return rp.Replay(rec)
+}
@@ -9,11 +9,16 @@ import (
 
 	"encore.dev/appruntime/model"
 	"encore.dev/appruntime/trace"
+	"encore.dev/appruntime/trace/propagation"
+	"encore.dev/appruntime/trace/recording"
 	"encore.dev/beta/errs"
 )
 
-func (s *Server) beginOperation() {
-	s.rt.BeginOperation()
+// beginOperation begins the operation handling a request to the given endpoint
+// and HTTP path, which is traced if the request is sampled and not excluded.
+// The caller's sampling decision, parent, takes precedence over the sampler's.
+func (s *Server) beginOperation(service, endpoint, path string, parent trace.Parent) {
+	s.rt.BeginSampledOperation(service, endpoint, path, parent)
 }
 
 func (s *Server) finishOperation() {
@@ -37,6 +42,23 @@ type beginRequestParams struct {
 	// It is copied from the parent request if it is empty.
 	ParentTraceID model.TraceID
 
+	// ParentID is the span ID of the caller, if the request continues
+	// a distributed trace. If it is the zero value it will be copied
+	// from the parent request.
+	ParentID model.SpanID
+
+	// TraceState is the W3C "tracestate" of the caller's trace, if any.
+	// It is copied from the parent request if it is empty.
+	TraceState string
+
+	// Baggage is the W3C Baggage of the caller, if any.
+	// It is copied from the parent request if it is nil.
+	Baggage []model.BaggageMember
+
+	// Link is the trace context of an untrusted caller, if any,
+	// which the request's span is linked to.
+	Link propagation.Context
+
 	// ExtRequestID specifies the externally-provided request id, if any.
 	// If not empty, it will be recorded as part of the "starting request" log message
 	// to facilitate request correlation.
@@ -46,6 +68,14 @@ type beginRequestParams struct {
 	// If not empty, it will be recorded on each log message with "correlation_id" key.
 	// to facilitate request correlation.
 	ExtCorrelationID string
+
+	// TraceOnlyLogs specifies whether the request's log messages
+	// should be recorded in the request trace only.
+	TraceOnlyLogs bool
+
+	// DebugLogs specifies whether the request's debug-level log messages
+	// should be written to the log output regardless of the log level.
+	DebugLogs bool
 }
 
 func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*model.Request, error) {
@@ -62,8 +92,11 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 		Type:             p.Type,
 		TraceID:          p.TraceID,
 		SpanID:           spanID,
+		ParentID:         p.ParentID,
 		ParentTraceID:    p.ParentTraceID,
 		ExtCorrelationID: p.ExtCorrelationID,
+		TraceState:       p.TraceState,
+		Baggage:          p.Baggage,
 		DefLoc:           p.DefLoc,
 		SvcNum:           p.Data.Desc.SvcNum,
 		Start:            s.clock.Now(),
@@ -71,6 +104,9 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 		RPCData:          p.Data,
 	}
 
+	req.TraceOnlyLogs.Store(p.TraceOnlyLogs)
+	req.DebugLogs.Store(p.DebugLogs)
+
 	data := req.RPCData
 
 	// Update request data based on call options, if any
@@ -85,10 +121,17 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 		}
 	}
 
+	if rec, _ := ctx.Value(recorderKey).(*recording.Recorder); rec != nil {
+		req.Recorder = rec
+	}
+
 	// Begin the request, copying data over from the previous request.
 	s.rt.BeginRequest(req)
 	if curr := s.rt.Current(); curr.Trace != nil {
 		curr.Trace.BeginRequest(req, curr.Goctr)
+		if !p.Link.TraceID.IsZero() {
+			curr.Trace.SpanLink(req.SpanID, curr.Goctr, p.Link.TraceID, p.Link.ParentID)
+		}
 	}
 
 	// Now that we have up-to-date information in req (possibly copied from
@@ -137,14 +180,14 @@ func (s *Server) finishRequest(resp *model.Response) {
 		panic("encore: no current request running")
 	}
 
-	if resp.Err != nil {
+	if resp.Err != nil || resp.HTTPStatus >= 400 {
 		switch req.Type {
 		case model.AuthHandler:
 			req.Logger.Error().Err(resp.Err).Msg("auth handler failed")
 		default:
 			e := errs.Convert(resp.Err).(*errs.Error)
 			ev := req.Logger.Error()
-			for k, v := range e.Meta {
+			for k, v := range e.Meta.Scrub() {
 				ev = ev.Interface(k, v)
 			}
 			ev.Str("error", e.ErrorMessage()).Str("code", e.Code.String()).Msg("request failed")
@@ -188,10 +231,19 @@ func (s *Server) finishRequest(resp *model.Response) {
 		curr.Trace.FinishRequest(req, resp)
 	}
 
+	respCode := code(resp.Err, resp.HTTPStatus)
 	s.requestsTotal.With(requestsTotalLabels{
 		endpoint: req.RPCData.Desc.Endpoint,
-		code:     code(resp.Err, resp.HTTPStatus),
+		code:     respCode,
 	}).Increment()
+	// Responses may have an error status without an error, such as
+	// those written by raw endpoints, so count those as failed too.
+	if resp.Err != nil || resp.HTTPStatus >= 400 {
+		s.errorsTotal.With(errorsTotalLabels{
+			endpoint: req.RPCData.Desc.Endpoint,
+			code:     respCode,
+		}).Increment()
+	}
 	s.rt.FinishRequest()
 }
 
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+const (
+	// maxCallAttempts is the maximum number of attempts
+	// at a service-to-service call failing with retryable errors.
+	maxCallAttempts = 3
+
+	// baseCallRetryDelay is the delay before the first retry of a call
+	// whose error does not specify one. It doubles with every attempt.
+	baseCallRetryDelay = 100 * time.Millisecond
+
+	// maxCallRetryDelay is the longest delay to wait before retrying a call.
+	// Errors asking to wait longer are returned to the caller instead.
+	maxCallRetryDelay = 5 * time.Second
+)
+
+// callRetryDelay returns how long to wait before retrying a call that failed
+// with err on the given attempt, starting at 1. It reports false if the call
+// should not be retried, because err asks to wait longer than maxCallRetryDelay.
+func callRetryDelay(err error, attempt int) (time.Duration, bool) {
+	if after := errs.RetryAfter(err); after > 0 {
+		return after, after <= maxCallRetryDelay
+	}
+	delay := baseCallRetryDelay << (attempt - 1)
+	if delay > maxCallRetryDelay {
+		delay = maxCallRetryDelay
+	}
+	return delay, true
+}
+
+// retryCall calls fn, retrying it while it fails with a retryable error,
+// for up to maxCallAttempts attempts. See callRetryDelay.
+//
+// If the final attempt fails with a retryable error, the error's RetryInfo
+// is removed before returning it. Otherwise every caller in a chain of
+// service-to-service calls would retry the error again, multiplying the
+// number of attempts by maxCallAttempts for every hop.
+func retryCall[Resp any](ctx context.Context, fn func() (Resp, error)) (Resp, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := fn()
+		if !errs.IsRetryable(err) {
+			return resp, err
+		} else if attempt >= maxCallAttempts {
+			return resp, withoutRetryInfo(err)
+		}
+		delay, ok := callRetryDelay(err, attempt)
+		if !ok || !sleepCtx(ctx, delay) {
+			return resp, err
+		}
+	}
+}
+
+// withoutRetryInfo returns a copy of err without its RetryInfo details,
+// so that it is no longer retryable.
+func withoutRetryInfo(err error) error {
+	e, ok := err.(*errs.Error)
+	if !ok {
+		return err
+	}
+	e2 := *e
+	e2.TypedDetails = nil
+	for _, d := range e.TypedDetails {
+		if _, ok := d.(errs.RetryInfo); !ok {
+			e2.TypedDetails = append(e2.TypedDetails, d)
+		}
+	}
+	return &e2
+}
+
+// sleepCtx waits for d to elapse. It reports false
+// if ctx is done before that.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
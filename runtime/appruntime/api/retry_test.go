@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+func TestCallRetryDelay(t *testing.T) {
+	tests := []struct {
+		err     error
+		attempt int
+		want    time.Duration
+		wantOK  bool
+	}{
+		{errs.B().Retryable(0).Err(), 1, 100 * time.Millisecond, true},
+		{errs.B().Retryable(0).Err(), 3, 400 * time.Millisecond, true},
+		{errs.B().Retryable(0).Err(), 10, maxCallRetryDelay, true},
+		{errs.B().Retryable(time.Second).Err(), 1, time.Second, true},
+		{errs.B().Retryable(time.Minute).Err(), 1, time.Minute, false},
+	}
+	for _, tt := range tests {
+		got, ok := callRetryDelay(tt.err, tt.attempt)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("callRetryDelay(%v, %d) = %v, %v; want %v, %v", tt.err, tt.attempt, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryCall_Chain(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	leaf := func() (int, error) {
+		calls++
+		return 0, errs.B().Code(errs.Unavailable).Retryable(time.Millisecond).TypedDetail(testDetails{"x"}).Err()
+	}
+	// Service A calls service B, which calls the leaf service C.
+	callB := func() (int, error) { return retryCall(ctx, leaf) }
+	_, err := retryCall(ctx, callB)
+
+	if calls != maxCallAttempts {
+		t.Errorf("got %d calls to leaf, want %d", calls, maxCallAttempts)
+	}
+	if errs.IsRetryable(err) {
+		t.Errorf("error still retryable after exhausting retries: %v", err)
+	}
+	if errs.Code(err) != errs.Unavailable {
+		t.Errorf("got code %v, want %v", errs.Code(err), errs.Unavailable)
+	}
+	if _, ok := errs.TypedDetail[testDetails](err); !ok {
+		t.Errorf("other typed details were dropped: %v", errs.TypedDetails(err))
+	}
+}
+
+type testDetails struct {
+	Field string
+}
+
+func (testDetails) ErrDetails() {}
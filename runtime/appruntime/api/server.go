@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"strings"
@@ -17,6 +18,9 @@ import (
 	"encore.dev/appruntime/model"
 	"encore.dev/appruntime/platform"
 	"encore.dev/appruntime/reqtrack"
+	"encore.dev/appruntime/trace"
+	"encore.dev/appruntime/trace/propagation"
+	"encore.dev/appruntime/trace/recording"
 	"encore.dev/beta/errs"
 	"encore.dev/metrics"
 )
@@ -46,6 +50,10 @@ type IncomingContext struct {
 	// capturer is set in handleIncoming for raw requests
 	// to capture the request body
 	capturer *rawRequestBodyCapturer
+
+	// trace is the trace context of the request,
+	// extracted once when it is received.
+	trace inboundTrace
 }
 
 type Handler interface {
@@ -64,16 +72,39 @@ type requestsTotalLabels struct {
 	code     string // Human-readable HTTP status code.
 }
 
+type errorsTotalLabels struct {
+	endpoint string // Endpoint name.
+	code     string // Error code.
+}
+
 type Server struct {
 	cfg            *config.Config
 	rt             *reqtrack.RequestTracker
 	pc             *platform.Client // if nil, requests are not authenticated against platform
 	encoreMgr      *encore.Manager
 	requestsTotal  *metrics.CounterGroup[requestsTotalLabels, uint64]
+	errorsTotal    *metrics.CounterGroup[errorsTotalLabels, uint64]
 	clock          clock.Clock
 	rootLogger     zerolog.Logger
 	json           jsoniter.API
 	tracingEnabled bool
+	propagators    []propagation.Propagator
+
+	// trustedNetworks are the networks of the callers
+	// whose trace context is adopted.
+	trustedNetworks []*net.IPNet
+
+	// recordings is where requests are recorded to,
+	// or nil if requests are not recorded.
+	recordings *recording.Dir
+
+	// recordedEndpoints are the endpoints whose requests are recorded,
+	// as "service.endpoint", or nil if requests to every endpoint are
+	// recorded according to the sample rate.
+	recordedEndpoints map[string]bool
+
+	// recordingOpts configure the recorders of recorded requests.
+	recordingOpts recording.Options
 
 	authHandler AuthHandler
 
@@ -85,6 +116,7 @@ type Server struct {
 	callCtr uint64
 
 	pubsubSubscriptions map[string]func(r *http.Request) error
+	logLevelHandler     http.Handler
 }
 
 func NewServer(
@@ -107,6 +139,15 @@ func NewServer(
 		},
 	})
 
+	errorsTotal := metrics.NewCounterGroupInternal[errorsTotalLabels, uint64](reg, "e_api_errors_total", metrics.CounterConfig{
+		EncoreInternal_LabelMapper: func(labels errorsTotalLabels) []metrics.KeyValue {
+			return []metrics.KeyValue{
+				{Key: "endpoint", Value: labels.endpoint},
+				{Key: "code", Value: labels.code},
+			}
+		},
+	})
+
 	public := httprouter.New()
 	public.HandleOPTIONS = false
 	public.RedirectFixedPath = false
@@ -128,10 +169,14 @@ func NewServer(
 		rt:             rt,
 		encoreMgr:      encoreMgr,
 		requestsTotal:  requestsTotal,
+		errorsTotal:    errorsTotal,
 		clock:          clock,
 		rootLogger:     rootLogger,
 		json:           json,
 		tracingEnabled: tracingEnabled,
+		propagators:    tracePropagators(cfg, rootLogger),
+
+		trustedNetworks: traceTrustedNetworks(cfg, rootLogger),
 
 		public:  public,
 		private: private,
@@ -140,6 +185,19 @@ func NewServer(
 		pubsubSubscriptions: make(map[string]func(r *http.Request) error),
 	}
 
+	if rec := cfg.Runtime.TraceRecording; rec != nil {
+		if len(rec.Endpoints) == 0 && rec.SampleRate <= 0 {
+			rootLogger.Warn().Msg("trace recording configured without endpoints or a sample rate, not recording requests")
+		} else {
+			s.recordings = recording.NewDir(rec.Dir, rec.MaxFiles, rec.MaxDiskSize)
+			s.recordedEndpoints = recordedEndpoints(rec.Endpoints)
+			s.recordingOpts = recording.Options{
+				MaxBodySize: rec.MaxBodySize,
+				Redactor:    trace.NewPayloadRedactor(cfg.Runtime.TraceRedactedKeys...),
+			}
+		}
+	}
+
 	// Configure CORS
 	corsCfg := &config.CORS{}
 	if cfg.Runtime.CORS != nil {
@@ -161,6 +219,20 @@ func (s *Server) SetAuthHandler(h AuthHandler) {
 	s.authHandler = h
 }
 
+// SetTracePropagators sets the propagators extracting the trace context
+// of incoming requests, tried in order, replacing those configured in
+// the runtime config.
+func (s *Server) SetTracePropagators(propagators ...propagation.Propagator) {
+	s.propagators = propagators
+}
+
+// SetRecordingSensitiveHeaders sets the function reporting whether the
+// values of a header are redacted from request recordings, in addition
+// to recording.DefaultSensitiveHeaders.
+func (s *Server) SetRecordingSensitiveHeaders(sensitive func(name string) bool) {
+	s.recordingOpts.SensitiveHeader = sensitive
+}
+
 type HandlerRegistration struct {
 	Handler    Handler
 	Middleware []*Middleware
@@ -206,7 +278,11 @@ func (s *Server) register(reg HandlerRegistration, logRegistration bool) {
 
 		adapter := func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 			params := toUnnamedParams(ps)
-			traceID, _ := model.GenTraceID()
+			tr := s.extractTrace(req)
+			traceID := tr.parent.TraceID
+			if traceID.IsZero() {
+				traceID, _ = model.GenTraceID()
+			}
 			traceIDStr := traceID.String()
 
 			// Echo the X-Request-ID back to the caller if present,
@@ -235,7 +311,14 @@ func (s *Server) register(reg HandlerRegistration, logRegistration bool) {
 			// Always send the trace id back.
 			w.Header().Set("X-Encore-Trace-ID", traceIDStr)
 
-			s.processRequest(h, s.NewIncomingContext(w, req, params, traceID, model.AuthInfo{}))
+			w, req, recorder := s.startRecording(h, w, req)
+			if recorder != nil {
+				defer s.finishRecording(recorder, traceIDStr)
+			}
+
+			c := s.NewIncomingContext(w, req, params, traceID, model.AuthInfo{})
+			c.trace = tr
+			s.processRequest(h, c)
 		}
 
 		routerPath := h.HTTPRouterPath()
@@ -279,6 +362,13 @@ func (s *Server) handler(w http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	s.route(r, w, req)
+}
+
+// route routes req to the endpoint it is for using r,
+// or the Encore internal router for internal paths.
+func (s *Server) route(r *httprouter.Router, w http.ResponseWriter, req *http.Request) {
+
 	// We use EscapedPath rather than `req.URL.Path` because if the path contains an encoded
 	// forward slash as %2F we don't want the router to treat that as a segment split.
 	//
@@ -304,12 +394,23 @@ func (s *Server) handler(w http.ResponseWriter, req *http.Request) {
 	}
 
 	// Endpoint not found
-	errs.HTTPError(w, errs.B().Code(errs.NotFound).Msg("endpoint not found").Err())
+	s.writeErr(w, req, errs.B().Code(errs.NotFound).Msg("endpoint not found").Err(), 0)
 }
 
 func (s *Server) processRequest(h Handler, c IncomingContext) {
-	c.server.beginOperation()
+	c.server.beginOperation(h.ServiceName(), h.EndpointName(), c.req.URL.Path, c.trace.parentFlag())
 	defer c.server.finishOperation()
+	defer func() {
+		// Handler panics are recovered and reported as errors, so a panic
+		// here escaped the request. Mark the trace as crashed so that the
+		// spans it leaves open are not dropped, and keep panicking.
+		if e := recover(); e != nil {
+			if e != http.ErrAbortHandler {
+				c.server.rt.MarkCrashed(fmt.Sprintf("panic: %v", e))
+			}
+			panic(e)
+		}
+	}()
 
 	info, proceed := s.runAuthHandler(h, c)
 	if proceed {
@@ -324,7 +425,7 @@ func (s *Server) newExecContext(ctx context.Context, ps UnnamedParams, trID mode
 
 func (s *Server) NewIncomingContext(w http.ResponseWriter, req *http.Request, ps UnnamedParams, trID model.TraceID, auth model.AuthInfo) IncomingContext {
 	ec := s.newExecContext(req.Context(), ps, trID, auth)
-	return IncomingContext{ec, w, req, nil}
+	return IncomingContext{execContext: ec, w: w, req: req}
 }
 
 func (s *Server) NewCallContext(ctx context.Context) CallContext {
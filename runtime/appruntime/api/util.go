@@ -1,8 +1,17 @@
 package api
 
 import (
+	"net"
+	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/config"
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+	"encore.dev/appruntime/trace/propagation"
 	"encore.dev/beta/errs"
 )
 
@@ -13,6 +22,137 @@ func clampTo64Chars(str string) string {
 	return str
 }
 
+// traceOnlyLogsHeader is the header that requests that the log messages
+// of a request are recorded in the request trace only.
+const traceOnlyLogsHeader = "X-Encore-Trace-Only-Logs"
+
+// traceOnlyLogsRequested reports whether req requests its log messages
+// to be recorded in the trace only. It is only honored for authenticated
+// requests from the Encore Platform, so that external callers cannot
+// suppress an application's logs.
+func traceOnlyLogsRequested(req *http.Request) bool {
+	return req.Header.Get(traceOnlyLogsHeader) == "1" && IsEncorePlatformRequest(req.Context())
+}
+
+// debugLogsHeader is the header that requests that the debug-level
+// log messages of a request are written to the log output.
+const debugLogsHeader = "X-Encore-Debug-Logs"
+
+// debugLogsRequested reports whether req requests its debug-level log
+// messages to be written to the log output. Like traceOnlyLogsRequested,
+// it is only honored for authenticated requests from the Encore Platform,
+// so that external callers cannot increase an application's log volume.
+func debugLogsRequested(req *http.Request) bool {
+	return req.Header.Get(debugLogsHeader) == "1" && IsEncorePlatformRequest(req.Context())
+}
+
+// inboundTrace is the trace context of an incoming request.
+type inboundTrace struct {
+	// parent is the trace context of the caller whose distributed
+	// trace the request continues, or the zero value if it has none.
+	parent propagation.Context
+
+	// link is the trace context of an untrusted caller, which the
+	// request's span links to rather than continues, or the zero value.
+	link propagation.Context
+}
+
+// parentFlag returns the sampling decision of the caller the request
+// continues the trace of, if any.
+func (t inboundTrace) parentFlag() trace.Parent {
+	if t.parent.TraceID.IsZero() {
+		return trace.NoParent
+	}
+	return trace.ParentFlag(t.parent.Sampled)
+}
+
+// extractTrace extracts the trace context of req with the server's
+// trace propagators. The request continues the distributed trace of its
+// caller only if the caller is trusted, as reported by trustsTraceContext,
+// and links to it otherwise.
+func (s *Server) extractTrace(req *http.Request) inboundTrace {
+	tc, ok := propagation.Extract(req.Header, s.propagators)
+	switch {
+	case !ok:
+		return inboundTrace{}
+	case s.trustsTraceContext(req):
+		return inboundTrace{parent: tc}
+	default:
+		return inboundTrace{link: tc}
+	}
+}
+
+// trustsTraceContext reports whether the trace context of req is adopted
+// as the request's own. It is only for requests from the Encore Platform
+// or from the configured trusted networks, so that other callers cannot
+// choose the trace IDs or sampling of the application's traces.
+func (s *Server) trustsTraceContext(req *http.Request) bool {
+	if IsEncorePlatformRequest(req.Context()) {
+		return true
+	}
+	if len(s.trustedNetworks) == 0 {
+		return false
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range s.trustedNetworks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// traceTrustedNetworks returns the trusted networks configured by cfg,
+// logging and skipping those that are invalid.
+func traceTrustedNetworks(cfg *config.Config, logger zerolog.Logger) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range cfg.Runtime.TraceTrustedNetworks {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logger.Error().Str("network", cidr).Err(err).Msg("invalid trusted trace network, ignoring")
+			continue
+		}
+		networks = append(networks, n)
+	}
+	return networks
+}
+
+// tracePropagators returns the trace propagators configured by cfg,
+// logging and skipping those that are unknown.
+func tracePropagators(cfg *config.Config, logger zerolog.Logger) []propagation.Propagator {
+	names := cfg.Runtime.TracePropagators
+	if len(names) == 0 {
+		names = []string{propagation.NameTraceContext}
+	}
+	propagators := make([]propagation.Propagator, 0, len(names))
+	for _, name := range names {
+		p, ok := propagation.ByName(name)
+		if !ok {
+			logger.Error().Str("propagator", name).Msg("unknown trace propagator, ignoring")
+			continue
+		}
+		propagators = append(propagators, p)
+	}
+	return propagators
+}
+
+// baggage parses the W3C Baggage headers of req,
+// so that the caller's baggage flows with the request.
+func baggage(req *http.Request) []model.BaggageMember {
+	values := req.Header.Values(model.BaggageHeader)
+	if len(values) == 0 {
+		return nil
+	}
+	return model.ParseBaggage(strings.Join(values, ","))
+}
+
 func code(err error, httpStatus int) string {
 	if err != nil {
 		e := errs.Convert(err).(*errs.Error)
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/config"
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+	"encore.dev/appruntime/trace/propagation"
+)
+
+func TestExtractTrace(t *testing.T) {
+	cfg := &config.Config{Runtime: &config.Runtime{
+		TraceTrustedNetworks: []string{"10.0.0.0/8", "not-a-network"},
+	}}
+	s := &Server{
+		propagators:     []propagation.Propagator{propagation.TraceContext{}},
+		trustedNetworks: traceTrustedNetworks(cfg, zerolog.Nop()),
+	}
+	if len(s.trustedNetworks) != 1 {
+		t.Fatalf("got %d trusted networks, want 1", len(s.trustedNetworks))
+	}
+
+	tc := propagation.Context{
+		TraceID:  model.TraceID{0x46, 0x3a, 0xc3, 0x5c, 0x9f, 0x64, 0x13, 0xad, 0x48, 0x48, 0x5a, 0x39, 0x53, 0xbb, 0x61, 0x24},
+		ParentID: model.SpanID{0xa2, 0xfb, 0x46, 0x44, 0x1e, 0xd8, 0x8d, 0x65},
+		Sampled:  true,
+	}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		platform   bool
+		noHeader   bool
+		want       inboundTrace
+		wantParent trace.Parent
+	}{
+		{name: "no trace context", remoteAddr: "10.1.2.3:1234", noHeader: true, wantParent: trace.NoParent},
+		{name: "trusted network", remoteAddr: "10.1.2.3:1234", want: inboundTrace{parent: tc}, wantParent: trace.ParentSampled},
+		{name: "untrusted network", remoteAddr: "203.0.113.7:1234", want: inboundTrace{link: tc}, wantParent: trace.NoParent},
+		{name: "encore platform", remoteAddr: "203.0.113.7:1234", platform: true, want: inboundTrace{parent: tc}, wantParent: trace.ParentSampled},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = test.remoteAddr
+			if !test.noHeader {
+				req.Header.Set("traceparent", "00-463ac35c9f6413ad48485a3953bb6124-a2fb46441ed88d65-01")
+			}
+			if test.platform {
+				req = req.WithContext(withEncorePlatformSealOfApproval(req.Context()))
+			}
+
+			got := s.extractTrace(req)
+			if got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+			if p := got.parentFlag(); p != test.wantParent {
+				t.Errorf("got parent %v, want %v", p, test.wantParent)
+			}
+		})
+	}
+}
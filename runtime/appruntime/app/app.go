@@ -1,9 +1,7 @@
 package app
 
 import (
-	"io"
 	"os"
-	"time"
 
 	"github.com/benbjohnson/clock"
 	jsoniter "github.com/json-iterator/go"
@@ -21,12 +19,13 @@ import (
 	"encore.dev/beta/auth"
 	appCfg "encore.dev/config"
 	"encore.dev/et"
-	"encore.dev/internal/cloud"
 	usermetrics "encore.dev/metrics"
 	"encore.dev/pubsub"
 	"encore.dev/rlog"
+	"encore.dev/sse"
 	"encore.dev/storage/cache"
 	"encore.dev/storage/sqldb"
+	usertrace "encore.dev/trace"
 )
 
 type App struct {
@@ -49,6 +48,8 @@ type App struct {
 	et              *et.Manager
 	metrics         *rtmetrics.Manager
 	metricsRegistry *usermetrics.Registry
+	trace           *usertrace.Manager
+	sse             *sse.Manager
 }
 
 func (app *App) Cfg() *runtimeCfg.Config            { return app.cfg }
@@ -63,24 +64,30 @@ type NewParams struct {
 
 func New(p *NewParams) *App {
 	cfg := p.Cfg
-	var logOutput io.Writer = os.Stderr
-	if cfg.Static.TestAsExternalBinary {
-		// If we're running as a test and as a binary outside of the Encore Daemon, then we want to
-		// log the output via a console logger, rather than the underlying JSON logs.
-		logOutput = zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
-			w.Out = logOutput
-		})
+	output := rlog.NewOutput(os.Stderr)
+	rootLogger := zerolog.New(output).With().Timestamp().Logger()
+	if f := logFormatFor(cfg); f != nil {
+		rootLogger = f.wrapLogger(cfg, rootLogger)
 	}
-	rootLogger := zerolog.New(logOutput).With().Timestamp().Logger()
 	tracingEnabled := trace.Enabled(cfg)
 	var traceFactory trace.Factory = nil
 	if tracingEnabled {
-		traceFactory = trace.DefaultFactory
+		traceFactory = trace.NewFactory(
+			trace.NewPayloadRedactor(cfg.Runtime.TraceRedactedKeys...),
+			trace.LimitsFor(cfg))
 	}
 
 	pc := platform.NewClient(cfg)
 
-	rt := reqtrack.New(rootLogger, pc, traceFactory)
+	// Only send traces to the platform if it's configured to receive them;
+	// they may be exported elsewhere regardless.
+	var tracePlatform *platform.Client
+	if trace.PlatformEnabled(cfg) {
+		tracePlatform = pc
+	}
+	rt := reqtrack.New(rootLogger, tracePlatform, traceFactory)
+	rt.SetTraceSampler(trace.NewSampler(cfg.Runtime.TraceSampling, cfg.Static.TraceSampleRates))
+	rt.StreamTraces(cfg.Runtime.TraceStreaming)
 	json := jsonAPI(cfg)
 	shutdown := newShutdownTracker()
 	encore := encore.NewManager(cfg, rt)
@@ -97,19 +104,37 @@ func New(p *NewParams) *App {
 	ts := testsupport.NewManager(cfg, rt, rootLogger)
 	auth := auth.NewManager(rt)
 	rlog := rlog.NewManager(rt)
+	rlog.RegisterMetrics(metricsRegistry)
+	rlog.SetReleaseID(cfg.Runtime.DeployID)
+	rlog.SetEnvironment(cfg.Runtime.EnvName)
+	rlog.SetIncludeSpanID(!cfg.Runtime.LogDisableSpanID)
+	rlog.SetLogBaggageKeys(cfg.Runtime.LogBaggageKeys...)
+	apiSrv.SetRecordingSensitiveHeaders(rlog.IsSensitiveHeader)
+	rlog.SetOutput(output)
+	rlog.SetConsoleOutput(consoleLogOutput(cfg))
+	configureLogLevels(cfg, rlog, rootLogger)
+	rlog.SetStrictPairs(cfg.Static.Testing)
+	apiSrv.RegisterLogLevelHandler(rlog.LevelHandler())
 	sqldb := sqldb.NewManager(cfg, rt)
 	pubsub := pubsub.NewManager(cfg, rt, ts, apiSrv, rootLogger, json)
 	cache := cache.NewManager(cfg, rt, ts, json)
 	appCfg := appCfg.NewManager(rt, json)
-	etMgr := et.NewManager(cfg, rt)
+	etMgr := et.NewManager(cfg, rt, apiSrv)
+	userTrace := usertrace.NewManager(rt, rlog)
+	sse := sse.NewManager(rt, rlog, json)
 
 	app := &App{
 		cfg, rt, json, rootLogger, apiSrv, service, ts,
 		shutdown,
 		encore, auth, rlog, sqldb, pubsub, cache, appCfg,
-		etMgr, metrics, metricsRegistry,
+		etMgr, metrics, metricsRegistry, userTrace, sse,
 	}
 
+	app.configureLogExport()
+	app.configureLogFile()
+	app.configureTraceExport()
+	app.configureTraceFiles()
+
 	// If this is running inside an Encore app, initialize the singletons
 	// that the package-level funcs rely on. Outside of apps this does nothing.
 	initSingletonsForEncoreApp(app)
@@ -126,6 +151,7 @@ func (app *App) Run() error {
 
 	app.WatchForShutdownSignals()
 	app.RegisterShutdown(app.api.Shutdown)
+	app.RegisterShutdown(app.sse.Shutdown)
 	app.RegisterShutdown(app.sqldb.Shutdown)
 	app.RegisterShutdown(app.pubsub.Shutdown)
 	app.RegisterShutdown(app.service.Shutdown)
@@ -164,16 +190,31 @@ func jsonAPI(cfg *runtimeCfg.Config) jsoniter.API {
 }
 
 // ReconfigureZerologFormat reconfigures the zerolog Logger's output format
-// based on the cloud provider.
+// based on the configured log format and the cloud provider.
 func (app *App) ReconfigureZerologFormat() {
 	// Note: if updating this function, also update
 	// mapCloudFieldNamesToExpected in cli/cmd/encore/logs.go
 	// as that reverses this for log streaming
-	switch app.cfg.Runtime.EnvCloud {
-	case cloud.GCP:
-		zerolog.LevelFieldName = "severity"
-		zerolog.TimestampFieldName = "timestamp"
-		zerolog.TimeFieldFormat = time.RFC3339Nano
-	default:
+	if f := logFormatFor(app.cfg); f != nil {
+		f.configureGlobals()
+	}
+}
+
+// configureLogLevels applies the log levels from the runtime config.
+// Invalid levels are reported and otherwise ignored.
+func configureLogLevels(cfg *runtimeCfg.Config, mgr *rlog.Manager, logger zerolog.Logger) {
+	if s := cfg.Runtime.LogLevel; s != "" {
+		if level, err := rlog.ParseLevel(s); err == nil {
+			mgr.SetLevel(level)
+		} else {
+			logger.Error().Err(err).Msg("invalid log level in runtime config")
+		}
+	}
+	for svc, s := range cfg.Runtime.ServiceLogLevels {
+		if level, err := rlog.ParseLevel(s); err == nil {
+			mgr.SetServiceLevel(svc, level)
+		} else {
+			logger.Error().Err(err).Str("service", svc).Msg("invalid service log level in runtime config")
+		}
 	}
 }
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+
+	"encore.dev/rlog/otlplog"
+)
+
+// configureLogExport sets up exporting log entries to the OpenTelemetry
+// collector configured in the runtime config, if any.
+func (app *App) configureLogExport() {
+	cfg := app.cfg.Runtime.LogExport
+	if cfg == nil || cfg.Endpoint == "" {
+		return
+	}
+
+	exp, err := otlplog.New(otlplog.Config{
+		Endpoint:    cfg.Endpoint,
+		Insecure:    cfg.Insecure,
+		Headers:     cfg.Headers,
+		ServiceName: app.cfg.Runtime.AppSlug,
+		ResourceAttributes: map[string]string{
+			"deployment.environment": app.cfg.Runtime.EnvName,
+			"service.version":        app.cfg.Runtime.DeployID,
+		},
+		ErrorHandler: func(err error) {
+			app.rootLogger.Warn().Err(err).Msg("could not export log entries")
+		},
+	})
+	if err != nil {
+		app.rootLogger.Error().Err(err).Msg("could not set up log export")
+		return
+	}
+	app.rlog.AddSink(exp)
+	app.RegisterShutdown(func(force context.Context) {
+		_ = exp.Shutdown(force)
+	})
+}
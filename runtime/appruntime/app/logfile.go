@@ -0,0 +1,30 @@
+package app
+
+import (
+	"encore.dev/rlog/logfile"
+)
+
+// configureLogFile sets up writing the log output to the rotated
+// log file configured in the runtime config, if any.
+func (app *App) configureLogFile() {
+	cfg := app.cfg.Runtime.LogFile
+	if cfg == nil || cfg.Path == "" {
+		return
+	}
+
+	f, err := logfile.Open(logfile.Config{
+		Path:           cfg.Path,
+		MaxSize:        cfg.MaxSize,
+		RotateInterval: cfg.RotateInterval,
+		MaxBackups:     cfg.MaxBackups,
+		MaxAge:         cfg.MaxAge,
+	})
+	if err != nil {
+		app.rootLogger.Error().Err(err).Msg("could not open log file")
+		return
+	}
+	// The file is not closed on shutdown, as log entries may be
+	// written until the process exits. Writes are unbuffered,
+	// so nothing is lost when it does.
+	app.rlog.AddWriter(f)
+}
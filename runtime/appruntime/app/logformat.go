@@ -0,0 +1,139 @@
+package app
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	runtimeCfg "encore.dev/appruntime/config"
+	"encore.dev/internal/cloud"
+)
+
+// logFormat describes the schema of the JSON log output:
+// the names of the standard fields and how levels are encoded.
+type logFormat struct {
+	levelField string
+	timeField  string
+	msgField   string
+	timeFormat string
+
+	// levelNumbers, if non-nil, causes the level to be written
+	// as a number instead of a string, using the severity
+	// number for each level. Levels not in the map are omitted.
+	levelNumbers map[zerolog.Level]int
+
+	// staticFields, if non-nil, returns fields to include
+	// on every log line.
+	staticFields func(cfg *runtimeCfg.Config) map[string]any
+}
+
+// gcpLogFormat matches the field names expected by Google Cloud Logging.
+var gcpLogFormat = &logFormat{
+	levelField: "severity",
+	timeField:  "timestamp",
+	msgField:   zerolog.MessageFieldName,
+	timeFormat: time.RFC3339Nano,
+}
+
+// bunyanLogFormat matches the Bunyan log record schema
+// (https://github.com/trentm/node-bunyan#core-fields).
+var bunyanLogFormat = &logFormat{
+	levelField: "level",
+	timeField:  "time",
+	msgField:   "msg",
+	timeFormat: time.RFC3339Nano,
+	levelNumbers: map[zerolog.Level]int{
+		zerolog.TraceLevel: 10,
+		zerolog.DebugLevel: 20,
+		zerolog.InfoLevel:  30,
+		zerolog.WarnLevel:  40,
+		zerolog.ErrorLevel: 50,
+		zerolog.FatalLevel: 60,
+		zerolog.PanicLevel: 60,
+	},
+	staticFields: func(cfg *runtimeCfg.Config) map[string]any {
+		hostname, _ := os.Hostname()
+		return map[string]any{
+			"v":        0,
+			"name":     cfg.Runtime.AppSlug,
+			"hostname": hostname,
+			"pid":      os.Getpid(),
+		}
+	},
+}
+
+// logFormatFor reports the log format to use for the given config.
+// It reports nil if the default zerolog format should be used.
+func logFormatFor(cfg *runtimeCfg.Config) *logFormat {
+	// An explicitly configured format takes precedence over the cloud default.
+	switch cfg.Runtime.LogFormat {
+	case "bunyan":
+		return bunyanLogFormat
+	}
+	switch cfg.Runtime.EnvCloud {
+	case cloud.GCP:
+		return gcpLogFormat
+	}
+	return nil
+}
+
+// consoleLogOutput reports whether to write the log output in a
+// human-friendly console format rather than as JSON.
+//
+// The ENCORE_LOG_OUTPUT environment variable ("console" or "json") takes
+// precedence over the runtime config, which makes it possible to switch
+// formats when starting the application without changing its config.
+func consoleLogOutput(cfg *runtimeCfg.Config) bool {
+	mode := os.Getenv("ENCORE_LOG_OUTPUT")
+	if mode == "" {
+		mode = cfg.Runtime.LogOutput
+	}
+	switch mode {
+	case "console":
+		return true
+	case "json":
+		return false
+	}
+
+	// If we're running as a test and as a binary outside of the Encore Daemon, then we want to
+	// log the output via a console logger, rather than the underlying JSON logs.
+	return cfg.Static.TestAsExternalBinary
+}
+
+// configureGlobals updates the zerolog field names to match the format.
+func (f *logFormat) configureGlobals() {
+	zerolog.TimestampFieldName = f.timeField
+	zerolog.MessageFieldName = f.msgField
+	zerolog.TimeFieldFormat = f.timeFormat
+	if f.levelNumbers != nil {
+		// The level is written by levelNumberHook instead.
+		zerolog.LevelFieldName = ""
+	} else {
+		zerolog.LevelFieldName = f.levelField
+	}
+}
+
+// wrapLogger returns a logger that writes the per-logger parts of the format,
+// the numeric level and any static fields, in addition to what logger writes.
+func (f *logFormat) wrapLogger(cfg *runtimeCfg.Config, logger zerolog.Logger) zerolog.Logger {
+	if f.levelNumbers != nil {
+		logger = logger.Hook(levelNumberHook{field: f.levelField, numbers: f.levelNumbers})
+	}
+	if f.staticFields != nil {
+		logger = logger.With().Fields(f.staticFields(cfg)).Logger()
+	}
+	return logger
+}
+
+// levelNumberHook writes the level as a severity number.
+type levelNumberHook struct {
+	field   string
+	numbers map[zerolog.Level]int
+}
+
+func (h levelNumberHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	if n, ok := h.numbers[level]; ok {
+		e.Int(h.field, n)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	runtimeCfg "encore.dev/appruntime/config"
+	"encore.dev/internal/cloud"
+)
+
+func TestLogFormatFor(t *testing.T) {
+	tests := []struct {
+		format string
+		cloud  string
+		want   *logFormat
+	}{
+		{"", "", nil},
+		{"", cloud.GCP, gcpLogFormat},
+		{"bunyan", "", bunyanLogFormat},
+		{"bunyan", cloud.GCP, bunyanLogFormat},
+	}
+	for _, test := range tests {
+		cfg := &runtimeCfg.Config{Runtime: &runtimeCfg.Runtime{LogFormat: test.format, EnvCloud: test.cloud}}
+		if got := logFormatFor(cfg); got != test.want {
+			t.Errorf("logFormatFor(%q, %q) = %+v, want %+v", test.format, test.cloud, got, test.want)
+		}
+	}
+}
+
+func TestBunyanLogFormat(t *testing.T) {
+	levelField, timeField, msgField, timeFormat := zerolog.LevelFieldName, zerolog.TimestampFieldName, zerolog.MessageFieldName, zerolog.TimeFieldFormat
+	t.Cleanup(func() {
+		zerolog.LevelFieldName, zerolog.TimestampFieldName, zerolog.MessageFieldName, zerolog.TimeFieldFormat = levelField, timeField, msgField, timeFormat
+	})
+	bunyanLogFormat.configureGlobals()
+
+	var buf bytes.Buffer
+	cfg := &runtimeCfg.Config{Runtime: &runtimeCfg.Runtime{AppSlug: "my-app"}}
+	logger := bunyanLogFormat.wrapLogger(cfg, zerolog.New(&buf).With().Timestamp().Logger())
+	logger.Warn().Str("key", "val").Msg("hello")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("got log output %q: %v", buf.String(), err)
+	}
+	hostname, _ := os.Hostname()
+	want := map[string]any{
+		"v":        0.0,
+		"level":    40.0,
+		"name":     "my-app",
+		"hostname": hostname,
+		"pid":      float64(os.Getpid()),
+		"msg":      "hello",
+		"key":      "val",
+	}
+	for k, v := range want {
+		if entry[k] != v {
+			t.Errorf("got %s = %v, want %v", k, entry[k], v)
+		}
+	}
+	if _, ok := entry["time"].(string); !ok {
+		t.Errorf("got log entry %v without a time", entry)
+	}
+	if _, ok := entry["message"]; ok {
+		t.Errorf("got log entry %v with a zerolog message field", entry)
+	}
+}
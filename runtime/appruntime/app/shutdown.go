@@ -83,9 +83,22 @@ func (app *App) Shutdown() {
 		}
 		wg.Wait()
 
+		// Requests still running past the graceful shutdown window are
+		// cut short when the process exits, so send their traces now.
+		flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+		app.rt.FlushCrashedTraces(flushCtx, "process shut down")
+		cancelFlush()
+
 		if !devMode {
 			app.rootLogger.Info().Msg("shutdown completed")
 		}
+
+		// Flush the pending log entries last, once nothing else logs,
+		// and regardless of whether the graceful shutdown window has closed.
+		logCtx, cancelLog := context.WithTimeout(context.Background(), 5*time.Second)
+		app.rlog.Shutdown(logCtx)
+		cancelLog()
+
 		close(app.shutdown.completed)
 	})
 }
@@ -13,8 +13,10 @@ import (
 	"encore.dev/metrics"
 	"encore.dev/pubsub"
 	"encore.dev/rlog"
+	"encore.dev/sse"
 	"encore.dev/storage/cache"
 	"encore.dev/storage/sqldb"
+	"encore.dev/trace"
 )
 
 func initSingletonsForEncoreApp(a *App) {
@@ -30,4 +32,6 @@ func initSingletonsForEncoreApp(a *App) {
 	config.Singleton = a.config
 	et.Singleton = a.et
 	metrics.Singleton = a.metricsRegistry
+	trace.Singleton = a.trace
+	sse.Singleton = a.sse
 }
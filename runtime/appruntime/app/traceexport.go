@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/option"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+	"encore.dev/appruntime/trace/filetrace"
+	"encore.dev/appruntime/trace/gcptrace"
+	"encore.dev/appruntime/trace/jaegertrace"
+	"encore.dev/appruntime/trace/otlptrace"
+	"encore.dev/appruntime/trace/xraytrace"
+	"encore.dev/appruntime/trace/zipkintrace"
+)
+
+// configureTraceExport sets up exporting traces to the collector
+// configured in the runtime config, if any.
+func (app *App) configureTraceExport() {
+	if !trace.ExportEnabled(app.cfg) || app.cfg.Static.Testing {
+		return
+	}
+
+	exp, shutdown, err := app.newTraceExporter()
+	if err != nil {
+		app.rootLogger.Error().Err(err).Msg("could not set up trace export")
+		return
+	}
+	app.rt.AddTraceExporter(exp)
+	app.RegisterShutdown(func(force context.Context) {
+		_ = shutdown(force)
+	})
+}
+
+// configureTraceFiles sets up writing traces to the local files
+// configured in the runtime config, if any.
+func (app *App) configureTraceFiles() {
+	if !trace.FilesEnabled(app.cfg) || app.cfg.Static.Testing {
+		return
+	}
+
+	cfg := app.cfg.Runtime.TraceFiles
+	exp, err := filetrace.New(filetrace.Config{
+		Dir:    cfg.Dir,
+		Format: cfg.Format,
+	})
+	if err != nil {
+		app.rootLogger.Error().Err(err).Msg("could not set up trace files")
+		return
+	}
+	app.rt.AddTraceExporter(exp)
+}
+
+// newTraceExporter creates the exporter for the configured trace format.
+func (app *App) newTraceExporter() (trace.Exporter, func(context.Context) error, error) {
+	cfg := app.cfg.Runtime.TraceExport
+	serviceName := app.cfg.Runtime.AppSlug
+	attrs := map[string]string{
+		"deployment.environment": app.cfg.Runtime.EnvName,
+		"service.version":        app.cfg.Runtime.DeployID,
+	}
+
+	switch cfg.Format {
+	case "", "otlp":
+		exp, err := otlptrace.New(otlptrace.Config{
+			Endpoint:           cfg.Endpoint,
+			Protocol:           cfg.Protocol,
+			Insecure:           cfg.Insecure,
+			Headers:            cfg.Headers,
+			ServiceName:        serviceName,
+			ResourceAttributes: attrs,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return exp, exp.Shutdown, nil
+	case "jaeger":
+		exp, err := jaegertrace.New(jaegertrace.Config{
+			Endpoint:           cfg.Endpoint,
+			Headers:            cfg.Headers,
+			ServiceName:        serviceName,
+			ResourceAttributes: attrs,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return exp, exp.Shutdown, nil
+	case "zipkin":
+		exp, err := zipkintrace.New(zipkintrace.Config{
+			Endpoint:           cfg.Endpoint,
+			Headers:            cfg.Headers,
+			ServiceName:        serviceName,
+			ResourceAttributes: attrs,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return exp, exp.Shutdown, nil
+	case "xray":
+		exp, err := xraytrace.New(xraytrace.Config{
+			Endpoint:           cfg.Endpoint,
+			ServiceName:        serviceName,
+			ResourceAttributes: attrs,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		// X-Ray rejects trace ids that do not begin with the time.
+		model.GenerateTimePrefixedTraceIDs = true
+		return exp, exp.Shutdown, nil
+	case "cloudtrace":
+		var opts []option.ClientOption
+		if cfg.Endpoint != "" {
+			opts = append(opts, option.WithEndpoint(cfg.Endpoint))
+		}
+		exp, err := gcptrace.New(context.Background(), gcptrace.Config{
+			ProjectID:          cfg.ProjectID,
+			ServiceName:        serviceName,
+			ResourceAttributes: attrs,
+			ClientOptions:      opts,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return exp, exp.Shutdown, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown trace export format %q", cfg.Format)
+	}
+}
@@ -30,6 +30,11 @@ type Static struct {
 
 	// BundledServices are the services bundled in this binary.
 	BundledServices []string
+
+	// TraceSampleRates are the fractions of requests to trace declared
+	// by endpoints with "trace_sample=", keyed by "service.endpoint".
+	// The sampling rules in Runtime.TraceSampling take precedence.
+	TraceSampleRates map[string]float64
 }
 
 type Runtime struct {
@@ -40,6 +45,7 @@ type Runtime struct {
 	EnvName       string          `json:"env_name"`
 	EnvType       string          `json:"env_type"`
 	EnvCloud      string          `json:"env_cloud"`
+	LogFormat     string          `json:"log_format,omitempty"` // "" (default) or "bunyan"
 	DeployID      string          `json:"deploy_id"`
 	DeployedAt    time.Time       `json:"deploy_time"`
 	TraceEndpoint string          `json:"trace_endpoint,omitempty"`
@@ -58,6 +64,106 @@ type Runtime struct {
 	// meaning connections are closed even if outstanding requests are still in flight.
 	// If zero, it shuts down immediately.
 	ShutdownTimeout time.Duration `json:"shutdown_timeout"`
+
+	// LogLevel is the minimum level of log entries written to the log output,
+	// such as "info". If empty, it defaults to "debug".
+	LogLevel string `json:"log_level,omitempty"`
+
+	// ServiceLogLevels are the minimum log levels of individual services,
+	// keyed by service name. They take precedence over LogLevel.
+	ServiceLogLevels map[string]string `json:"service_log_levels,omitempty"`
+
+	// LogOutput is the format of the log output: "console" for a
+	// human-friendly format, or "json". If empty, the format depends
+	// on how the application is run.
+	LogOutput string `json:"log_output,omitempty"`
+
+	// LogBaggageKeys are the keys of the W3C Baggage members of incoming
+	// requests attached as fields to the log entries emitted within them,
+	// such as "tenant_id". If empty, no baggage is attached to log entries.
+	LogBaggageKeys []string `json:"log_baggage_keys,omitempty"`
+
+	// LogDisableSpanID, if true, omits the "span_id" field from the
+	// warnings and errors logged within traced requests, which otherwise
+	// link them to the exact span of the trace they were logged in.
+	LogDisableSpanID bool `json:"log_disable_span_id,omitempty"`
+
+	// LogExport, if non-nil, configures exporting log entries
+	// to an OpenTelemetry collector, in addition to the log output.
+	LogExport *LogExport `json:"log_export,omitempty"`
+
+	// LogFile, if non-nil, configures writing the log output
+	// to a file with rotation, in addition to the primary log output.
+	LogFile *LogFile `json:"log_file,omitempty"`
+
+	// TraceExport, if non-nil, configures exporting traces to an
+	// OpenTelemetry, Jaeger or Zipkin collector, in addition to the Encore platform.
+	TraceExport *TraceExport `json:"trace_export,omitempty"`
+
+	// TraceSampling, if non-nil, configures which requests are traced.
+	// If nil, every request is traced.
+	TraceSampling *TraceSampling `json:"trace_sampling,omitempty"`
+
+	// TraceStreaming, if non-nil, enables streaming the traces of
+	// long-running operations in chunks, configured as described in
+	// TraceStreaming. If nil, each trace is buffered in full and sent
+	// once its operation completes. The trace endpoint must support
+	// reassembling chunked traces.
+	TraceStreaming *TraceStreaming `json:"trace_streaming,omitempty"`
+
+	// TraceCompression is the compression applied to traces sent to the
+	// trace endpoint: "zstd", "snappy" or "" (default) for none.
+	// If the endpoint does not support it, traces are sent uncompressed.
+	TraceCompression string `json:"trace_compression,omitempty"`
+
+	// TraceRedactedKeys are the JSON names of request, response and
+	// message payload fields whose values are redacted from traces,
+	// such as "password" or "token", matched case-insensitively.
+	// Fields tagged `encore:"sensitive"` are redacted regardless.
+	TraceRedactedKeys []string `json:"trace_redacted_keys,omitempty"`
+
+	// TraceFiles, if non-nil, configures writing each trace to a local
+	// file for offline inspection, in addition to sending it elsewhere.
+	// It is intended for development.
+	TraceFiles *TraceFiles `json:"trace_files,omitempty"`
+
+	// TraceLimits, if non-nil, bounds the size of the trace buffered
+	// for each request. If nil, traces are unbounded, except while
+	// they are streamed as enabled by TraceStreaming.
+	TraceLimits *TraceLimits `json:"trace_limits,omitempty"`
+
+	// TracePropagators are the formats of trace context accepted from
+	// incoming requests, tried in order, so that requests from callers
+	// outside Encore continue their callers' traces: "tracecontext"
+	// (W3C Trace Context), "b3" (the B3 headers of Zipkin and Envoy),
+	// "xray" (AWS X-Ray) and "cloudtrace" (Google Cloud Trace).
+	// If empty, only "tracecontext" is accepted. Only the trace context
+	// of trusted callers is continued; see TraceTrustedNetworks.
+	TracePropagators []string `json:"trace_propagators,omitempty"`
+
+	// TraceTrustedNetworks are the networks, in CIDR notation, of the
+	// callers whose trace context is adopted, such as a service mesh.
+	// The trace context of requests from other callers, except those
+	// from the Encore Platform, is recorded as a link instead, so that
+	// they cannot choose the trace IDs or sampling of the app's traces.
+	TraceTrustedNetworks []string `json:"trace_trusted_networks,omitempty"`
+
+	// TraceRecording, if non-nil, configures recording requests to
+	// files, along with the responses of the outgoing HTTP calls made
+	// while handling them, so that they can be replayed locally.
+	TraceRecording *TraceRecording `json:"trace_recording,omitempty"`
+
+	// ErrorCauses specifies whether API error responses include the
+	// messages of the errors wrapped by the returned error. It is intended
+	// for development and staging environments, as causes can reveal
+	// implementation details.
+	ErrorCauses bool `json:"error_causes,omitempty"`
+
+	// ErrorFormat is the format of API error responses: "json" for
+	// Encore's JSON encoding of errors, or "problem" for RFC 7807 problem
+	// details with the content type "application/problem+json".
+	// If empty, "json" is used.
+	ErrorFormat string `json:"error_format,omitempty"`
 }
 
 // UnsafeAllOriginWithCredentials can be used to specify that all origins are
@@ -315,3 +421,206 @@ type PrometheusRemoteWriteProvider struct {
 }
 
 type LogsBasedMetricsProvider struct{}
+
+// LogExport configures exporting log entries using the OTLP/gRPC protocol.
+type LogExport struct {
+	// Endpoint is the address of the collector, such as "otel-collector:4317".
+	Endpoint string `json:"endpoint"`
+
+	// Insecure disables transport security for the connection to the collector.
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Headers are additional gRPC metadata sent with each export,
+	// typically used for authentication.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// LogFile configures writing the log output to a rotated file.
+type LogFile struct {
+	// Path is the path of the log file.
+	Path string `json:"path"`
+
+	// MaxSize is the maximum size of the log file in bytes
+	// before it is rotated. If zero, it is not rotated by size.
+	MaxSize int64 `json:"max_size,omitempty"`
+
+	// RotateInterval is the maximum time the log file is written to
+	// before it is rotated. If zero, it is not rotated by time.
+	RotateInterval time.Duration `json:"rotate_interval,omitempty"`
+
+	// MaxBackups is the maximum number of rotated log files to retain.
+	// If zero, all rotated log files are retained, subject to MaxAge.
+	MaxBackups int `json:"max_backups,omitempty"`
+
+	// MaxAge is the maximum time to retain rotated log files.
+	// If zero, rotated log files are retained regardless of age.
+	MaxAge time.Duration `json:"max_age,omitempty"`
+}
+
+// TraceExport configures exporting traces to a tracing system.
+type TraceExport struct {
+	// Endpoint is the address of the collector. For OTLP over gRPC
+	// it is a host and port, such as "otel-collector:4317", and for all
+	// other transports the URL to post traces to, such as
+	// "http://otel-collector:4318/v1/traces".
+	Endpoint string `json:"endpoint"`
+
+	// Format is the format traces are exported in: "otlp",
+	// "jaeger" (Jaeger Thrift over HTTP), "zipkin" (Zipkin JSON v2
+	// over HTTP), "xray" (AWS X-Ray segments sent to an X-Ray daemon)
+	// or "cloudtrace" (the Google Cloud Trace API). It defaults to "otlp".
+	//
+	// For "xray", Endpoint is the UDP address of the daemon and defaults
+	// to "127.0.0.1:2000". For "cloudtrace", Endpoint is optional and
+	// overrides the API endpoint, and ProjectID must be set.
+	Format string `json:"format,omitempty"`
+
+	// ProjectID is the Google Cloud project traces are written to,
+	// for the "cloudtrace" format.
+	ProjectID string `json:"project_id,omitempty"`
+
+	// Protocol is the OTLP transport to use, either "grpc" or "http".
+	// It defaults to "grpc", and only applies to the "otlp" format.
+	Protocol string `json:"protocol,omitempty"`
+
+	// Insecure disables transport security for the connection to the collector.
+	// It only applies to the "grpc" protocol.
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Headers are additional headers sent with each export,
+	// typically used for authentication.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// TraceFiles configures writing traces to local files.
+type TraceFiles struct {
+	// Dir is the directory to write trace files to.
+	// It is created if it does not exist.
+	Dir string `json:"dir"`
+
+	// Format is the format of the trace files: "json" (the default)
+	// for the decoded spans, or "binary" for the native trace format,
+	// which can be decoded with the filetrace package.
+	Format string `json:"format,omitempty"`
+}
+
+// TraceLimits bounds the size of traces.
+type TraceLimits struct {
+	// MaxSize is the maximum number of bytes of trace data buffered
+	// for a request. Events beyond it are dropped according to Policy,
+	// and the trace records that it was truncated. Zero means no limit.
+	MaxSize int `json:"max_size,omitempty"`
+
+	// Policy decides which events are dropped once a trace reaches
+	// MaxSize: "stop" (the default) stops recording further events,
+	// keeping the beginning of the trace, and "drop_oldest" drops
+	// the oldest events to make room, keeping the end of the trace.
+	// The starts and ends of requests are never dropped.
+	Policy string `json:"policy,omitempty"`
+}
+
+// TraceRecording configures recording requests to files.
+// The values of sensitive headers and the fields redacted from traces
+// (see TraceRedactedKeys) are redacted from recordings.
+//
+// Requests are only recorded if Endpoints or SampleRate is set.
+type TraceRecording struct {
+	// Dir is the directory to write recordings to.
+	// It is created if it does not exist.
+	Dir string `json:"dir"`
+
+	// Endpoints are the endpoints whose requests are recorded,
+	// as "service.endpoint". If empty, requests to every endpoint
+	// are recorded according to SampleRate.
+	Endpoints []string `json:"endpoints,omitempty"`
+
+	// SampleRate is the fraction of requests recorded, between 0 and 1.
+	// If zero, every request to Endpoints is recorded.
+	SampleRate float64 `json:"sample_rate,omitempty"`
+
+	// MaxBodySize is the maximum number of bytes of each body recorded.
+	// Requests whose bodies are truncated cannot be replayed.
+	// It defaults to 1 MiB.
+	MaxBodySize int `json:"max_body_size,omitempty"`
+
+	// MaxFiles and MaxDiskSize bound the number of recordings kept in Dir
+	// and their total size in bytes, beyond which the oldest recordings
+	// are removed. They default to 1000 recordings and 1 GiB.
+	MaxFiles    int   `json:"max_files,omitempty"`
+	MaxDiskSize int64 `json:"max_disk_size,omitempty"`
+}
+
+// TraceSampling configures which requests are traced.
+// The embedded rule applies to every endpoint not listed in Endpoints.
+type TraceSampling struct {
+	TraceSamplingRule
+
+	// Endpoints overrides the sampling rule of individual endpoints,
+	// keyed by "service.endpoint". Pub/Sub subscriptions are keyed
+	// by "service.subscription". The rules of endpoints listed here
+	// replace the sampling rates the endpoints declare in code.
+	Endpoints map[string]TraceSamplingRule `json:"endpoints,omitempty"`
+
+	// Tail, if non-nil, enables tail-based sampling: requests that are
+	// not sampled are traced provisionally, and their traces are only
+	// kept if they are found to be of interest once they complete.
+	Tail *TailSampling `json:"tail,omitempty"`
+
+	// Exclude lists endpoints that are never traced, such as health
+	// checks, keyed by "service.endpoint" like Endpoints.
+	// Excluded requests do not count towards the sampling rate limits.
+	Exclude []string `json:"exclude,omitempty"`
+
+	// ExcludePaths lists HTTP request paths that are never traced.
+	// A path ending in "*" excludes every path with that prefix.
+	ExcludePaths []string `json:"exclude_paths,omitempty"`
+}
+
+// TraceSamplingRule decides which requests to an endpoint are traced.
+type TraceSamplingRule struct {
+	// Rate is the fraction of requests to trace, between 0 and 1.
+	// If nil, every request is traced, subject to MaxPerSecond.
+	Rate *float64 `json:"rate,omitempty"`
+
+	// MaxPerSecond, if positive, is the maximum number of requests
+	// traced per second, on average.
+	MaxPerSecond float64 `json:"max_per_second,omitempty"`
+}
+
+// TailSampling configures which provisionally traced requests are kept.
+type TailSampling struct {
+	// KeepErrors keeps the traces of requests that end in error.
+	KeepErrors bool `json:"keep_errors,omitempty"`
+
+	// LatencyThreshold, if positive, keeps the traces of requests
+	// that take at least this long to complete.
+	LatencyThreshold time.Duration `json:"latency_threshold,omitempty"`
+}
+
+// TraceStreaming configures streaming the traces of long-running
+// operations, such as streams and batch jobs, in chunks while the
+// operation runs, instead of sending each trace once it completes.
+type TraceStreaming struct {
+	// Disabled disables streaming, as if TraceStreaming were nil,
+	// so that each trace is buffered in full and sent once its
+	// operation completes.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// ChunkSize is the number of bytes of trace data an operation
+	// buffers before it is sent as a chunk. It defaults to 256 KiB.
+	ChunkSize int `json:"chunk_size,omitempty"`
+
+	// MaxBuffered is the maximum number of bytes of trace data an
+	// operation buffers when chunks cannot be sent as fast as they
+	// are recorded. Events beyond it are dropped according to the
+	// policy of TraceLimits. It defaults to 4 MiB.
+	MaxBuffered int `json:"max_buffered,omitempty"`
+
+	// MaxInFlight is the maximum number of chunks waiting to be sent.
+	// It defaults to 8.
+	MaxInFlight int `json:"max_in_flight,omitempty"`
+
+	// Interval is how often operations are checked for chunks to send.
+	// It defaults to one second.
+	Interval time.Duration `json:"interval,omitempty"`
+}
@@ -67,7 +67,7 @@ func (x *Exporter) Export(ctx context.Context, collected []metrics.CollectedMetr
 func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMetric) []*prompb.TimeSeries {
 	data := make([]*prompb.TimeSeries, 0, len(collected))
 
-	doAdd := func(val float64, metricName string, baseLabels []*prompb.Label, svcIdx uint16) {
+	doAdd := func(val float64, metricName string, baseLabels []*prompb.Label, svcIdx uint16, exemplar *metrics.Exemplar) {
 		labels := make([]*prompb.Label, len(baseLabels)+2)
 		copy(labels, baseLabels)
 		labels[len(baseLabels)] = &prompb.Label{
@@ -86,6 +86,7 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 					Timestamp: FromTime(now),
 				},
 			},
+			Exemplars: convertExemplar(exemplar),
 		})
 	}
 
@@ -105,34 +106,34 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 		switch vals := m.Val.(type) {
 		case []float64:
 			if svcNum > 0 {
-				doAdd(vals[0], m.Info.Name(), labels, svcNum-1)
+				doAdd(vals[0], m.Info.Name(), labels, svcNum-1, exemplarAt(m.Exemplars, 0))
 			} else {
 				for i, val := range vals {
-					doAdd(val, m.Info.Name(), labels, uint16(i))
+					doAdd(val, m.Info.Name(), labels, uint16(i), exemplarAt(m.Exemplars, i))
 				}
 			}
 		case []int64:
 			if svcNum > 0 {
-				doAdd(float64(vals[0]), m.Info.Name(), labels, svcNum-1)
+				doAdd(float64(vals[0]), m.Info.Name(), labels, svcNum-1, exemplarAt(m.Exemplars, 0))
 			} else {
 				for i, val := range vals {
-					doAdd(float64(val), m.Info.Name(), labels, uint16(i))
+					doAdd(float64(val), m.Info.Name(), labels, uint16(i), exemplarAt(m.Exemplars, i))
 				}
 			}
 		case []uint64:
 			if svcNum > 0 {
-				doAdd(float64(vals[0]), m.Info.Name(), labels, svcNum-1)
+				doAdd(float64(vals[0]), m.Info.Name(), labels, svcNum-1, exemplarAt(m.Exemplars, 0))
 			} else {
 				for i, val := range vals {
-					doAdd(float64(val), m.Info.Name(), labels, uint16(i))
+					doAdd(float64(val), m.Info.Name(), labels, uint16(i), exemplarAt(m.Exemplars, i))
 				}
 			}
 		case []time.Duration:
 			if svcNum > 0 {
-				doAdd(float64(vals[0]/time.Second), m.Info.Name(), labels, svcNum-1)
+				doAdd(float64(vals[0]/time.Second), m.Info.Name(), labels, svcNum-1, exemplarAt(m.Exemplars, 0))
 			} else {
 				for i, val := range vals {
-					doAdd(float64(val/time.Second), m.Info.Name(), labels, uint16(i))
+					doAdd(float64(val/time.Second), m.Info.Name(), labels, uint16(i), exemplarAt(m.Exemplars, i))
 				}
 			}
 		default:
@@ -144,6 +145,30 @@ func (x *Exporter) getMetricData(now time.Time, collected []metrics.CollectedMet
 	return data
 }
 
+// exemplarAt returns the exemplar at index i, or nil if there is none.
+func exemplarAt(exemplars []*metrics.Exemplar, i int) *metrics.Exemplar {
+	if i < len(exemplars) {
+		return exemplars[i]
+	}
+	return nil
+}
+
+// convertExemplar converts e to a Prometheus exemplar, labelled with
+// the trace and span id in the same format as log entries.
+func convertExemplar(e *metrics.Exemplar) []*prompb.Exemplar {
+	if e == nil {
+		return nil
+	}
+	return []*prompb.Exemplar{{
+		Labels: []*prompb.Label{
+			{Name: "trace_id", Value: e.TraceID.String()},
+			{Name: "span_id", Value: e.SpanID.String()},
+		},
+		Value:     e.Value,
+		Timestamp: FromTime(e.Time),
+	}}
+}
+
 // FromTime returns a new millisecond timestamp from a time.
 func FromTime(t time.Time) int64 {
 	return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"encore.dev/appruntime/metrics/prometheus/prompb"
+	"encore.dev/appruntime/model"
 	"encore.dev/metrics"
 )
 
@@ -113,6 +114,52 @@ func TestGetMetricData(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "exemplar",
+			metric: metrics.CollectedMetric{
+				Info: metricInfo{"test_exemplar", metrics.CounterType, 1},
+				Val:  []uint64{3},
+				Exemplars: []*metrics.Exemplar{
+					{TraceID: model.TraceID{1}, SpanID: model.SpanID{2}, Value: 1, Time: now},
+				},
+			},
+			data: []*prompb.TimeSeries{
+				{
+					Labels: []*prompb.Label{
+						{
+							Name:  "__name__",
+							Value: "test_exemplar",
+						},
+						{
+							Name:  "service",
+							Value: "foo",
+						},
+					},
+					Samples: []*prompb.Sample{
+						{
+							Value:     3,
+							Timestamp: FromTime(now),
+						},
+					},
+					Exemplars: []*prompb.Exemplar{
+						{
+							Labels: []*prompb.Label{
+								{
+									Name:  "trace_id",
+									Value: model.TraceID{1}.String(),
+								},
+								{
+									Name:  "span_id",
+									Value: model.SpanID{2}.String(),
+								},
+							},
+							Value:     1,
+							Timestamp: FromTime(now),
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
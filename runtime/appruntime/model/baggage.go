@@ -0,0 +1,108 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+)
+
+// BaggageHeader is the W3C Baggage header.
+// See https://www.w3.org/TR/baggage/.
+const BaggageHeader = "baggage"
+
+// Limits on the baggage propagated, as recommended by the specification.
+const (
+	maxBaggageMembers = 64
+	maxBaggageLen     = 8192
+)
+
+// BaggageMember is a key-value pair of W3C Baggage,
+// such as a tenant id, that flows with a request across services.
+type BaggageMember struct {
+	Key   string
+	Value string
+}
+
+// ParseBaggage parses a W3C "baggage" value.
+// Invalid members are skipped, as are the properties of each member,
+// and members beyond the limits of the specification are dropped.
+func ParseBaggage(s string) []BaggageMember {
+	if len(s) > maxBaggageLen {
+		s = s[:maxBaggageLen]
+	}
+	var members []BaggageMember
+	for _, m := range strings.Split(s, ",") {
+		if len(members) == maxBaggageMembers {
+			break
+		}
+		if idx := strings.IndexByte(m, ';'); idx >= 0 {
+			m = m[:idx] // drop the properties
+		}
+		key, value, ok := strings.Cut(m, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !isBaggageKey(key) {
+			continue
+		}
+		value, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		members = append(members, BaggageMember{Key: key, Value: value})
+	}
+	return members
+}
+
+// FormatBaggage formats members as a W3C "baggage" value.
+// Members beyond the limits of the specification are dropped.
+func FormatBaggage(members []BaggageMember) string {
+	var b strings.Builder
+	for i, m := range members {
+		if i == maxBaggageMembers {
+			break
+		}
+		if !isBaggageKey(m.Key) {
+			continue
+		}
+		member := m.Key + "=" + escapeBaggageValue(m.Value)
+		if b.Len()+1+len(member) > maxBaggageLen {
+			break
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(member)
+	}
+	return b.String()
+}
+
+// isBaggageKey reports whether s is a valid baggage key,
+// which is a non-empty HTTP token.
+func isBaggageKey(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`"(),/:;<=>?@[\]{}`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeBaggageValue percent-encodes the characters of s
+// that are not allowed in baggage values.
+func escapeBaggageValue(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c > ' ' && c < 0x7f && c != '"' && c != ',' && c != ';' && c != '\\' && c != '%' {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0xf])
+		}
+	}
+	return b.String()
+}
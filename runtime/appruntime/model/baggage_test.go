@@ -0,0 +1,56 @@
+package model
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBaggage(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []BaggageMember
+	}{
+		{"tenant_id=t1", []BaggageMember{{"tenant_id", "t1"}}},
+		{"a=1, b = 2 ;prop=x;flag,c=hello%20world", []BaggageMember{{"a", "1"}, {"b", "2"}, {"c", "hello world"}}},
+		{"invalid,=x,bad key=1,ok=%zz,d=4", []BaggageMember{{"d", "4"}}},
+		{"", nil},
+	}
+	for _, test := range tests {
+		if got := ParseBaggage(test.in); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseBaggage(%q) = %v, want %v", test.in, got, test.want)
+		}
+	}
+
+	many := strings.Repeat("k=v,", 100)
+	if got := len(ParseBaggage(many)); got != maxBaggageMembers {
+		t.Errorf("got %d members, want %d", got, maxBaggageMembers)
+	}
+}
+
+func TestFormatBaggage(t *testing.T) {
+	members := []BaggageMember{{"a", "1"}, {"b", "hello, world;%"}, {"bad key", "x"}}
+	got := FormatBaggage(members)
+	if want := "a=1,b=hello%2C%20world%3B%25"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if parsed := ParseBaggage(got); !reflect.DeepEqual(parsed, members[:2]) {
+		t.Errorf("round trip = %v, want %v", parsed, members[:2])
+	}
+}
+
+func TestWithTraceContext_Baggage(t *testing.T) {
+	req := &Request{TraceID: TraceID{1}, Baggage: []BaggageMember{{"tenant_id", "t1"}}}
+	h := WithTraceContext(nil, req, SpanID{2})
+	if got := h.Get(BaggageHeader); got != "tenant_id=t1" {
+		t.Errorf("got baggage %q, want %q", got, "tenant_id=t1")
+	}
+
+	// Existing baggage headers are kept.
+	orig := http.Header{}
+	orig.Set(BaggageHeader, "other=1")
+	if h := WithTraceContext(orig, req, SpanID{2}); h.Get(BaggageHeader) != "other=1" || h.Get(TraceParentHeader) == "" {
+		t.Errorf("got headers %v", h)
+	}
+}
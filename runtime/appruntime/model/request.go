@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -46,11 +47,31 @@ type Request struct {
 	ParentTraceID    TraceID
 	ExtCorrelationID string // The externally-provided correlation ID, if any.
 
+	// TraceState is the W3C "tracestate" value of the trace the request
+	// is part of, if any, which is propagated to outgoing calls.
+	TraceState string
+
+	// Baggage is the W3C Baggage of the request, if any,
+	// which is propagated to outgoing calls and published messages.
+	Baggage []BaggageMember
+
 	Start  time.Time
 	Logger *zerolog.Logger
 	Traced bool
 	DefLoc int32
 
+	// TraceOnlyLogs specifies whether log messages emitted during
+	// the request are recorded in the request trace only,
+	// and not written to the log output. It is atomic as it can be
+	// changed while the request's goroutines are logging.
+	TraceOnlyLogs atomic.Bool
+
+	// DebugLogs specifies whether debug-level log messages emitted
+	// during the request are written to the log output, regardless
+	// of the configured log level. It is atomic for the same reason
+	// as TraceOnlyLogs.
+	DebugLogs atomic.Bool
+
 	// SvcNum is the 1-based index of the service into the service list.
 	// It's here instead of within RPCData/MsgData/Test for performance.
 	SvcNum uint16
@@ -63,6 +84,21 @@ type Request struct {
 
 	// If we're running a test, this contains the test information.
 	Test *TestData
+
+	// Recorder, if non-nil, records the outgoing HTTP calls
+	// made while handling the request.
+	Recorder HTTPCallRecorder
+}
+
+// HTTPCallRecorder records outgoing HTTP calls.
+type HTTPCallRecorder interface {
+	// BeginCall records the beginning of the call req,
+	// and returns the context to make the call with.
+	BeginCall(ctx context.Context, req *http.Request) context.Context
+
+	// FinishCall records the completion of the call req,
+	// made with the context returned by BeginCall.
+	FinishCall(req *http.Request, resp *http.Response, err error)
 }
 
 // Service reports the current service, if any.
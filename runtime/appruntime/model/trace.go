@@ -3,7 +3,9 @@ package model
 import (
 	"crypto/rand"
 	"encoding/base32"
+	"encoding/binary"
 	"testing"
+	"time"
 	_ "unsafe"
 )
 
@@ -42,6 +44,11 @@ var b32 = base32.NewEncoding(encodeHex).WithPadding(base32.NoPadding)
 // to always generate the constant {0, 0, 0, ..., 1} byte sequence for testing.
 var GenerateConstantValsForTests = false
 
+// GenerateTimePrefixedTraceIDs if true causes GenTraceID to begin trace ids
+// with the current Unix time in seconds, as AWS X-Ray requires.
+// It must be set before any trace ids are generated.
+var GenerateTimePrefixedTraceIDs = false
+
 // GenTraceID generates a new trace id.
 func GenTraceID() (TraceID, error) {
 	if GenerateConstantValsForTests {
@@ -50,6 +57,9 @@ func GenTraceID() (TraceID, error) {
 
 	var traceID TraceID
 	_, err := rand.Read(traceID[:])
+	if GenerateTimePrefixedTraceIDs {
+		binary.BigEndian.PutUint32(traceID[:4], uint32(time.Now().Unix()))
+	}
 	return traceID, err
 }
 
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// The W3C Trace Context headers.
+// See https://www.w3.org/TR/trace-context/.
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+// FormatTraceParent formats a W3C "traceparent" value for the given
+// trace id and parent span id.
+func FormatTraceParent(traceID TraceID, spanID SpanID, sampled bool) string {
+	flags := "00"
+	if sampled {
+		flags = "01"
+	}
+
+	// version "-" trace-id "-" parent-id "-" trace-flags
+	var b [2 + 1 + 32 + 1 + 16 + 1 + 2]byte
+	copy(b[0:2], "00")
+	b[2] = '-'
+	hex.Encode(b[3:35], traceID[:])
+	b[35] = '-'
+	hex.Encode(b[36:52], spanID[:])
+	b[52] = '-'
+	copy(b[53:55], flags)
+	return string(b[:])
+}
+
+// ParseTraceParent parses a W3C "traceparent" value.
+// It reports false if s is not a valid value, including if
+// the trace id or parent span id is all zeroes.
+//
+// Values of future versions are accepted as long as they
+// begin with the fields of version 00, as the specification requires.
+func ParseTraceParent(s string) (traceID TraceID, parentID SpanID, sampled bool, ok bool) {
+	const size = 2 + 1 + 32 + 1 + 16 + 1 + 2
+	if len(s) < size || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return TraceID{}, SpanID{}, false, false
+	}
+	version, flags := s[0:2], s[53:55]
+	if !isLowerHex(version) || version == "ff" || !isLowerHex(flags) ||
+		(version == "00" && len(s) != size) || (len(s) > size && s[size] != '-') {
+		return TraceID{}, SpanID{}, false, false
+	}
+	if !isLowerHex(s[3:35]) || !isLowerHex(s[36:52]) {
+		return TraceID{}, SpanID{}, false, false
+	}
+	_, _ = hex.Decode(traceID[:], []byte(s[3:35]))
+	_, _ = hex.Decode(parentID[:], []byte(s[36:52]))
+	if traceID.IsZero() || parentID.IsZero() {
+		return TraceID{}, SpanID{}, false, false
+	}
+
+	flagBits, _ := hex.DecodeString(flags)
+	return traceID, parentID, flagBits[0]&1 != 0, true
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// WithTraceContext returns h with the W3C Trace Context and Baggage
+// headers set for an outgoing call made by req, with spanID as the
+// parent span. Headers that h already has are left as they are.
+// If req has no trace id, or no headers need setting, it returns h
+// unchanged. Otherwise it returns a copy of h, so that the caller's
+// headers are not modified.
+func WithTraceContext(h http.Header, req *Request, spanID SpanID) http.Header {
+	if req == nil || req.TraceID.IsZero() || spanID.IsZero() {
+		return h
+	}
+	setParent := h.Get(TraceParentHeader) == ""
+	setBaggage := len(req.Baggage) > 0 && h.Get(BaggageHeader) == ""
+	if !setParent && !setBaggage {
+		return h
+	}
+
+	h = h.Clone()
+	if h == nil {
+		h = make(http.Header, 3)
+	}
+	if setParent {
+		h.Set(TraceParentHeader, FormatTraceParent(req.TraceID, spanID, req.Traced))
+		if req.TraceState != "" {
+			h.Set(TraceStateHeader, req.TraceState)
+		}
+	}
+	if setBaggage {
+		h.Set(BaggageHeader, FormatBaggage(req.Baggage))
+	}
+	return h
+}
+
+// NormalizeTraceState prepares a W3C "tracestate" value for propagation.
+// It returns "" if s is too long to propagate, as the specification
+// allows dropping the value in that case.
+func NormalizeTraceState(s string) string {
+	const maxLen = 512
+	s = strings.TrimSpace(s)
+	if len(s) > maxLen {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	traceID := TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	spanID := SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}
+
+	tests := []struct {
+		in      string
+		ok      bool
+		sampled bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false, false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false, false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", false, false},
+		{"", false, false},
+	}
+	for _, test := range tests {
+		gotTrace, gotSpan, sampled, ok := ParseTraceParent(test.in)
+		if ok != test.ok || sampled != test.sampled {
+			t.Errorf("ParseTraceParent(%q) = sampled %v, ok %v; want %v, %v", test.in, sampled, ok, test.sampled, test.ok)
+		} else if ok && (gotTrace != traceID || gotSpan != spanID) {
+			t.Errorf("ParseTraceParent(%q) = %x, %x", test.in, gotTrace, gotSpan)
+		}
+	}
+
+	if got, want := FormatTraceParent(traceID, spanID, true), tests[0].in; got != want {
+		t.Errorf("FormatTraceParent = %q, want %q", got, want)
+	}
+}
+
+func TestWithTraceContext(t *testing.T) {
+	req := &Request{TraceID: TraceID{1}, SpanID: SpanID{2}, Traced: true, TraceState: "vendor=x"}
+	orig := http.Header{"Accept": {"*/*"}}
+	h := WithTraceContext(orig, req, SpanID{3})
+	if got, want := h.Get(TraceParentHeader), "00-01000000000000000000000000000000-0300000000000000-01"; got != want {
+		t.Errorf("got traceparent %q, want %q", got, want)
+	}
+	if got := h.Get(TraceStateHeader); got != "vendor=x" {
+		t.Errorf("got tracestate %q, want %q", got, "vendor=x")
+	}
+	if orig.Get(TraceParentHeader) != "" {
+		t.Errorf("the original headers were modified")
+	}
+
+	// Existing trace context headers are kept.
+	if h2 := WithTraceContext(h, &Request{TraceID: TraceID{5}}, SpanID{6}); h2.Get(TraceParentHeader) != h.Get(TraceParentHeader) {
+		t.Errorf("existing traceparent was replaced")
+	}
+	if h := WithTraceContext(nil, req, SpanID{3}); h.Get(TraceParentHeader) == "" {
+		t.Errorf("no traceparent set on nil headers")
+	}
+}
@@ -0,0 +1,85 @@
+package platform
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content encodings supported for traces.
+const (
+	encodingZstd   = "zstd"
+	encodingSnappy = "snappy" // the snappy framing format
+)
+
+// zstdEncoder is shared between all traces, as EncodeAll
+// is safe for concurrent use.
+var zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+
+// traceEncodingFor returns the content encoding for the configured
+// trace compression, or "" for no or unknown compression.
+func traceEncodingFor(compression string) string {
+	switch strings.ToLower(compression) {
+	case encodingZstd:
+		return encodingZstd
+	case encodingSnappy:
+		return encodingSnappy
+	default:
+		return ""
+	}
+}
+
+// encodeTrace compresses data with the given content encoding.
+func encodeTrace(data []byte, encoding string) ([]byte, error) {
+	switch encoding {
+	case encodingZstd:
+		return zstdEncoder.EncodeAll(data, make([]byte, 0, len(data)/4)), nil
+	case encodingSnappy:
+		var buf bytes.Buffer
+		w := snappy.NewBufferedWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return data, nil
+	}
+}
+
+// negotiateTraceEncoding picks the encoding to send traces with
+// from the values of the Accept-Encoding header of a trace endpoint,
+// preferring zstd over snappy. It returns "" if neither is accepted.
+func negotiateTraceEncoding(acceptEncoding []string) string {
+	accepted := make(map[string]bool)
+	for _, v := range acceptEncoding {
+		for _, enc := range strings.Split(v, ",") {
+			enc, params, _ := strings.Cut(enc, ";")
+			accepted[strings.ToLower(strings.TrimSpace(enc))] = !isZeroQuality(params)
+		}
+	}
+	for _, enc := range []string{encodingZstd, encodingSnappy} {
+		if accepted[enc] {
+			return enc
+		}
+	}
+	return ""
+}
+
+// isZeroQuality reports whether the parameters of an Accept-Encoding
+// member have a quality value of zero, meaning "not acceptable".
+func isZeroQuality(params string) bool {
+	for _, p := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(p), "=")
+		if strings.EqualFold(key, "q") {
+			q, err := strconv.ParseFloat(value, 64)
+			return err == nil && q == 0
+		}
+	}
+	return false
+}
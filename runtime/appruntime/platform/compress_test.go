@@ -0,0 +1,63 @@
+package platform
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestEncodeTrace(t *testing.T) {
+	data := bytes.Repeat([]byte("trace event "), 1000)
+	decoders := map[string]func([]byte) ([]byte, error){
+		"": func(b []byte) ([]byte, error) { return b, nil },
+		encodingZstd: func(b []byte) ([]byte, error) {
+			d, err := zstd.NewReader(nil)
+			if err != nil {
+				return nil, err
+			}
+			defer d.Close()
+			return d.DecodeAll(b, nil)
+		},
+		encodingSnappy: func(b []byte) ([]byte, error) {
+			return io.ReadAll(snappy.NewReader(bytes.NewReader(b)))
+		},
+	}
+	for encoding, decode := range decoders {
+		enc, err := encodeTrace(data, encoding)
+		if err != nil {
+			t.Fatalf("encode %q: %v", encoding, err)
+		}
+		if encoding != "" && len(enc) >= len(data) {
+			t.Errorf("encode %q: got %d bytes, want fewer than %d", encoding, len(enc), len(data))
+		}
+		got, err := decode(enc)
+		if err != nil {
+			t.Fatalf("decode %q: %v", encoding, err)
+		} else if !bytes.Equal(got, data) {
+			t.Errorf("decode %q: data does not round-trip", encoding)
+		}
+	}
+}
+
+func TestNegotiateTraceEncoding(t *testing.T) {
+	tests := []struct {
+		accept []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"gzip"}, ""},
+		{[]string{"snappy"}, "snappy"},
+		{[]string{"snappy, ZSTD"}, "zstd"},
+		{[]string{"gzip", "snappy;q=0.5"}, "snappy"},
+		{[]string{"zstd;q=0, snappy"}, "snappy"},
+		{[]string{"zstd; q=0.0"}, ""},
+	}
+	for _, test := range tests {
+		if got := negotiateTraceEncoding(test.accept); got != test.want {
+			t.Errorf("negotiateTraceEncoding(%q) = %q, want %q", test.accept, got, test.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@
 package platform
 
 import (
+	"bytes"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
@@ -11,6 +12,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"encore.dev/appruntime/config"
@@ -19,30 +21,68 @@ import (
 )
 
 func NewClient(cfg *config.Config) *Client {
-	return &Client{cfg}
+	c := &Client{cfg: cfg}
+	c.traceEncoding.Store(traceEncodingFor(cfg.Runtime.TraceCompression))
+	return c
 }
 
 type Client struct {
 	cfg *config.Config
+
+	// traceEncoding is the content encoding traces are sent with,
+	// or "" if they are sent uncompressed. It is negotiated with
+	// the trace endpoint, falling back to an encoding the endpoint
+	// accepts if it rejects the configured one.
+	traceEncoding atomic.Value // string
 }
 
 func (c *Client) SendTrace(ctx context.Context, id model.TraceID, data io.Reader) error {
-	req, err := http.NewRequestWithContext(ctx, "POST", c.cfg.Runtime.TraceEndpoint, data)
+	return c.sendTraceChunk(ctx, id, nil, data)
+}
+
+// TraceChunk identifies a chunk of a trace that is sent in several parts,
+// such as the trace of a long-running operation sent while it runs.
+// All chunks of a trace are sent with the same trace id, and the
+// trace endpoint reassembles them in order once it has received the
+// final chunk.
+type TraceChunk struct {
+	// Seq is the sequence number of the chunk, starting at zero.
+	Seq int
+	// Final specifies whether it is the last chunk of the trace.
+	Final bool
+}
+
+// SendTraceChunk is like SendTrace but sends a chunk of the trace with
+// the given id. The chunks are sent with the X-Encore-Trace-Chunk header
+// set to the sequence number of the chunk, suffixed with ";final"
+// for the final chunk.
+func (c *Client) SendTraceChunk(ctx context.Context, id model.TraceID, chunk TraceChunk, data io.Reader) error {
+	return c.sendTraceChunk(ctx, id, &chunk, data)
+}
+
+// sendTraceChunk sends the trace data, which is the chunk of the trace
+// described by chunk, or the whole trace if chunk is nil.
+func (c *Client) sendTraceChunk(ctx context.Context, id model.TraceID, chunk *TraceChunk, data io.Reader) error {
+	raw, err := io.ReadAll(data)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("X-Encore-App-ID", c.cfg.Runtime.AppID)
-	req.Header.Set("X-Encore-Env-ID", c.cfg.Runtime.EnvID)
-	req.Header.Set("X-Encore-Deploy-ID", c.cfg.Runtime.DeployID)
-	req.Header.Set("X-Encore-App-Commit", c.cfg.Static.AppCommit.AsRevisionString())
-	req.Header.Set("X-Encore-Trace-ID", base64.RawStdEncoding.EncodeToString(id[:]))
-	req.Header.Set("X-Encore-Trace-Version", strconv.Itoa(int(trace.CurrentVersion)))
-	c.addAuthKey(req)
-
-	resp, err := http.DefaultClient.Do(req)
+	encoding := c.traceEncoding.Load().(string)
+	resp, err := c.sendTrace(ctx, id, chunk, raw, encoding)
 	if err != nil {
 		return err
 	}
+	if resp.StatusCode == http.StatusUnsupportedMediaType && encoding != "" {
+		// The endpoint does not support the encoding; fall back to one
+		// it accepts, as listed in its Accept-Encoding header (RFC 7694).
+		encoding = negotiateTraceEncoding(resp.Header.Values("Accept-Encoding"))
+		c.traceEncoding.Store(encoding)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp, err = c.sendTrace(ctx, id, chunk, raw, encoding); err != nil {
+			return err
+		}
+	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -51,6 +91,41 @@ func (c *Client) SendTrace(ctx context.Context, id model.TraceID, data io.Reader
 	return nil
 }
 
+// sendTrace sends the trace data, compressed with the given encoding.
+func (c *Client) sendTrace(ctx context.Context, id model.TraceID, chunk *TraceChunk, data []byte, encoding string) (*http.Response, error) {
+	body, err := encodeTrace(data, encoding)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.cfg.Runtime.TraceEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
+	}
+	req.Header.Set("X-Encore-App-ID", c.cfg.Runtime.AppID)
+	req.Header.Set("X-Encore-Env-ID", c.cfg.Runtime.EnvID)
+	req.Header.Set("X-Encore-Deploy-ID", c.cfg.Runtime.DeployID)
+	req.Header.Set("X-Encore-App-Commit", c.cfg.Static.AppCommit.AsRevisionString())
+	req.Header.Set("X-Encore-Trace-ID", base64.RawStdEncoding.EncodeToString(id[:]))
+	req.Header.Set("X-Encore-Trace-Version", strconv.Itoa(int(trace.CurrentVersion)))
+	if chunk != nil {
+		req.Header.Set("X-Encore-Trace-Chunk", chunk.header())
+	}
+	c.addAuthKey(req)
+	return http.DefaultClient.Do(req)
+}
+
+// header returns the value of the X-Encore-Trace-Chunk header for the chunk.
+func (chunk *TraceChunk) header() string {
+	h := strconv.Itoa(chunk.Seq)
+	if chunk.Final {
+		h += ";final"
+	}
+	return h
+}
+
 func (c *Client) addAuthKey(req *http.Request) {
 	k := c.cfg.Runtime.AuthKeys[0]
 	date := time.Now().UTC().Format(http.TimeFormat)
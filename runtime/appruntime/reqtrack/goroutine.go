@@ -0,0 +1,49 @@
+package reqtrack
+
+import (
+	"sync/atomic"
+)
+
+// Go runs fn in a new goroutine that is part of the current operation
+// and request, if any, so that the log messages and trace events it
+// records are attached to the request's trace.
+//
+// Unlike goroutines started with the go statement, the goroutine keeps
+// the operation alive until fn returns: the trace of the operation is
+// not sent until then, even if the request completes before fn does.
+func (t *RequestTracker) Go(fn func()) {
+	src := t.impl.get()
+	if src == nil {
+		go fn()
+		return
+	}
+
+	src.op.incRef()
+	go func() {
+		g := t.impl.get()
+		if g == nil {
+			// Outside of Encore apps, the runtime does not tag new goroutines.
+			g = &encoreG{
+				op:    src.op,
+				req:   src.req,
+				goctr: atomic.AddUint32(&src.op.goidCtr, 1),
+			}
+			t.impl.set(g)
+			if g.req != nil && g.op.trace != nil {
+				g.op.trace.GoStart(g.req.spanID, g.goctr)
+			}
+		}
+		op, req, goctr := g.op, g.req, g.goctr
+
+		defer func() {
+			if req != nil && op.trace != nil {
+				op.trace.GoEnd(req.spanID, goctr)
+			}
+			// Untag the goroutine before it exits, so that the runtime
+			// doesn't record its end after the trace has been sent.
+			t.impl.set(nil)
+			op.decRef()
+		}()
+		fn()
+	}()
+}
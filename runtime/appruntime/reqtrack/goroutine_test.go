@@ -0,0 +1,59 @@
+package reqtrack
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+type captureExporter chan []byte
+
+func (e captureExporter) ExportTrace(ctx context.Context, data []byte) error {
+	e <- data
+	return nil
+}
+
+func TestGo(t *testing.T) {
+	rt := New(zerolog.Nop(), nil, trace.DefaultFactory)
+	exp := make(captureExporter, 1)
+	rt.AddTraceExporter(exp)
+
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	release := make(chan struct{})
+	got := make(chan Current, 1)
+	rt.Go(func() {
+		got <- rt.Current()
+		<-release
+	})
+	curr := <-got
+	rt.FinishRequest()
+
+	if curr.Req != req || curr.Trace == nil || curr.Goctr < 2 {
+		t.Fatalf("goroutine has current %+v, want request %p", curr, req)
+	}
+
+	// The trace is sent once the goroutine completes, not the request.
+	select {
+	case <-exp:
+		t.Fatalf("trace sent before the goroutine completed")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+
+	var types []trace.EventType
+	for data := <-exp; len(data) >= 13; {
+		n := binary.LittleEndian.Uint32(data[9:13])
+		types = append(types, trace.EventType(data[0]))
+		data = data[13+n:]
+	}
+	if len(types) != 2 || types[0] != trace.GoStart || types[1] != trace.GoEnd {
+		t.Errorf("got events %v, want GoStart and GoEnd", types)
+	}
+}
@@ -76,6 +76,10 @@ func (t *RequestTracker) newOp(trace bool) *encoreOp {
 	}
 	if trace && t.trace != nil {
 		op.trace = t.trace.NewLogger()
+		t.addLive(op)
+		if t.streamer != nil {
+			t.streamer.add(op)
+		}
 	}
 	return op
 }
@@ -119,8 +123,15 @@ func (op *encoreOp) incRef() int32 {
 // If it reaches zero and the op is traced, it sends off the trace.
 func (op *encoreOp) decRef() int32 {
 	n := atomic.AddInt32(&op.refs, -1)
-	if n == 0 && op.trace != nil && op.t.platform != nil {
-		op.t.sendTrace(op.trace)
+	if n == 0 && op.trace != nil {
+		op.t.removeLive(op)
+		var chunk *traceChunk
+		if op.t.streamer != nil {
+			chunk = op.t.streamer.remove(op)
+		}
+		if op.t.sendsTraces() {
+			op.t.sendTrace(op.trace, chunk)
+		}
 	}
 	return n
 }
@@ -143,6 +154,10 @@ func (t *RequestTracker) beginReq(data *model.Request, trace bool) {
 		}
 		e.op.incRef()
 		e.req = req
+		if e.op.trace == nil {
+			// The operation is not traced, such as when it was sampled out.
+			data.Traced = false
+		}
 	}
 }
 
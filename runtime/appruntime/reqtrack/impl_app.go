@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"sync/atomic"
 	_ "unsafe" // for go:linkname
+
+	"encore.dev/appruntime/model"
 )
 
 func newImpl() reqTrackImpl {
@@ -67,7 +69,21 @@ func exitEncoreG(e *encoreG) {
 //go:linkname beginHTTPRoundTrip net/http.encoreBeginRoundTrip
 func beginHTTPRoundTrip(req *http.Request) (context.Context, error) {
 	g := getEncoreG()
-	if g == nil || g.req == nil || !g.req.data.Traced {
+	if g == nil || g.req == nil {
+		return req.Context(), nil
+	}
+	ctx, err := beginTracedHTTPRoundTrip(g, req)
+	if rec := g.req.data.Recorder; rec != nil && err == nil {
+		ctx = rec.BeginCall(ctx, req)
+	}
+	return ctx, err
+}
+
+func beginTracedHTTPRoundTrip(g *encoreG, req *http.Request) (context.Context, error) {
+	if !g.req.data.Traced {
+		// Propagate the trace to the callee even if the request is not traced,
+		// with the request as the parent span as the call has no span.
+		req.Header = model.WithTraceContext(req.Header, g.req.data, g.req.data.SpanID)
 		return req.Context(), nil
 	} else if req.URL == nil {
 		return nil, fmt.Errorf("http: nil Request.URL")
@@ -78,7 +94,12 @@ func beginHTTPRoundTrip(req *http.Request) (context.Context, error) {
 
 //go:linkname finishHTTPRoundTrip net/http.encoreFinishRoundTrip
 func finishHTTPRoundTrip(req *http.Request, resp *http.Response, err error) {
-	if g := getEncoreG(); g != nil && g.req != nil && g.op.trace != nil {
-		g.op.trace.HTTPCompleteRoundTrip(req, resp, err)
+	if g := getEncoreG(); g != nil && g.req != nil {
+		if g.op.trace != nil {
+			g.op.trace.HTTPCompleteRoundTrip(req, resp, err)
+		}
+		if rec := g.req.data.Recorder; rec != nil {
+			rec.FinishCall(req, resp, err)
+		}
 	}
 }
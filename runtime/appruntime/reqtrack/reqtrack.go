@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -18,7 +19,9 @@ import (
 //
 // If traceProvider is nil no traces are generated.
 //
-// If platform is nil no traces are sent (but are still generated if traceProvider is non-nil).
+// If platform is nil no traces are sent to the Encore platform
+// (but are still generated if traceProvider is non-nil, and sent to
+// any exporters added with AddTraceExporter).
 func New(rootLogger zerolog.Logger, platform *platform.Client, traceProvider trace.Factory) *RequestTracker {
 	return &RequestTracker{
 		platform:   platform,
@@ -33,12 +36,84 @@ type RequestTracker struct {
 	impl       reqTrackImpl
 	trace      trace.Factory // nil if tracing is not enabled
 	rootLogger zerolog.Logger
+
+	expMu     sync.RWMutex // protects exporters and sampler
+	exporters []trace.Exporter
+	sampler   *trace.Sampler // nil means every operation is traced
+
+	streamer *traceStreamer // nil if traces are not streamed
+
+	liveMu sync.Mutex
+	live   map[*encoreOp]bool // traced operations that are running
+}
+
+// AddTraceExporter adds an exporter that is sent the trace data
+// of each traced operation, in addition to the Encore platform.
+func (t *RequestTracker) AddTraceExporter(exp trace.Exporter) {
+	t.expMu.Lock()
+	defer t.expMu.Unlock()
+	t.exporters = append(t.exporters[:len(t.exporters):len(t.exporters)], exp)
+}
+
+func (t *RequestTracker) traceExporters() []trace.Exporter {
+	t.expMu.RLock()
+	defer t.expMu.RUnlock()
+	return t.exporters
+}
+
+// sendsTraces reports whether trace data is sent anywhere,
+// to the Encore platform or to an exporter.
+func (t *RequestTracker) sendsTraces() bool {
+	return t.platform != nil || len(t.traceExporters()) > 0
+}
+
+// SetTraceSampler sets the sampler deciding which operations
+// begun with BeginSampledOperation are traced.
+func (t *RequestTracker) SetTraceSampler(s *trace.Sampler) {
+	t.expMu.Lock()
+	defer t.expMu.Unlock()
+	t.sampler = s
 }
 
 func (t *RequestTracker) BeginOperation() {
 	t.beginOp(true /* always trace by default */)
 }
 
+// BeginSampledOperation is like BeginOperation, for an operation handling
+// a request to the given endpoint, made to the given HTTP path if it is an
+// HTTP request, whose caller made the sampling decision parent.
+// The operation is only traced if the trace sampler samples it and does not
+// exclude it; otherwise no trace buffer is allocated for it, and requests
+// within it are not traced.
+//
+// If the sampler uses tail-based sampling, operations that are not sampled
+// are traced provisionally instead, and their traces are only sent if one
+// of their requests is kept by the sampler once it completes.
+func (t *RequestTracker) BeginSampledOperation(service, endpoint, path string, parent trace.Parent) {
+	if t.trace == nil {
+		t.beginOp(false)
+		return
+	}
+	t.expMu.RLock()
+	sampler := t.sampler
+	t.expMu.RUnlock()
+
+	switch {
+	case sampler.Exclude(service, endpoint, path):
+		t.beginOp(false)
+	case sampler.Sample(service, endpoint, parent):
+		t.beginOp(true)
+	case sampler.TailEnabled():
+		// Trace the operation provisionally, and decide whether
+		// to keep the trace once its requests have completed.
+		op := t.beginOp(false)
+		op.trace = &tailTrace{Logger: t.trace.NewLogger(), sampler: sampler}
+		t.addLive(op)
+	default:
+		t.beginOp(false)
+	}
+}
+
 func (t *RequestTracker) FinishOperation() {
 	t.finishOp()
 }
@@ -82,12 +157,27 @@ func copyReqInfoFromParent(next, prev *model.Request) {
 	if next.ExtCorrelationID == "" {
 		next.ExtCorrelationID = prev.ExtCorrelationID
 	}
+	if next.TraceState == "" {
+		next.TraceState = prev.TraceState
+	}
+	if next.Baggage == nil {
+		next.Baggage = prev.Baggage
+	}
 	if !next.Traced {
 		next.Traced = prev.Traced
 	}
+	if !next.TraceOnlyLogs.Load() {
+		next.TraceOnlyLogs.Store(prev.TraceOnlyLogs.Load())
+	}
+	if !next.DebugLogs.Load() {
+		next.DebugLogs.Store(prev.DebugLogs.Load())
+	}
 	if next.Test == nil {
 		next.Test = prev.Test
 	}
+	if next.Recorder == nil {
+		next.Recorder = prev.Recorder
+	}
 }
 
 func (t *RequestTracker) FinishRequest() {
@@ -106,6 +196,11 @@ func (t *RequestTracker) Current() Current {
 	return Current{req, tr, goid, svc}
 }
 
+// RootLogger returns the logger that is not associated with any request.
+func (t *RequestTracker) RootLogger() *zerolog.Logger {
+	return &t.rootLogger
+}
+
 func (t *RequestTracker) Logger() *zerolog.Logger {
 	if curr := t.Current(); curr.Req != nil && curr.Req.Logger != nil {
 		return curr.Req.Logger
@@ -113,27 +208,129 @@ func (t *RequestTracker) Logger() *zerolog.Logger {
 	return &t.rootLogger
 }
 
-func (t *RequestTracker) sendTrace(tr trace.Logger) {
+// sendTrace sends the trace recorded by tr, which is the final chunk
+// of a streamed trace if chunk is non-nil.
+func (t *RequestTracker) sendTrace(tr trace.Logger, chunk *traceChunk) {
 	// Do this first so we clear the buffer even if t.platform == nil
 	data := tr.GetAndClear()
 
-	if t.platform == nil {
-		// If we don't have a platform client we can't send traces.
+	if tt, ok := tr.(*tailTrace); ok && !tt.kept() {
+		// The operation was traced provisionally, and none of its
+		// requests turned out to be worth keeping.
+		return
+	}
+
+	if !t.sendsTraces() {
+		// If we don't have a platform client or any exporters we can't send traces.
 		// This is the case if the app is ejected.
 		return
 	}
 
 	go func() {
-		traceID, err := model.GenTraceID()
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "encore: could not generate trace id:", err)
-			return
-		}
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		err = t.platform.SendTrace(ctx, traceID, bytes.NewReader(data))
+		t.sendTraceData(ctx, data, chunk)
 		cancel()
-		if err != nil {
+	}()
+}
+
+// MarkCrashed records in the trace of the current operation, if it is
+// traced, that it crashed for the given reason, such as a panic that
+// unwound through it. See trace.Crashed.
+func (t *RequestTracker) MarkCrashed(reason string) {
+	if curr := t.Current(); curr.Trace != nil {
+		trace.Crashed(curr.Trace, reason)
+	}
+}
+
+// FlushCrashedTraces marks the traces of all running operations as
+// crashed for the given reason and sends the trace data they have
+// recorded so far, waiting for it to be sent or for ctx to be done.
+//
+// It is used when the process is about to terminate, so that the
+// traces of the operations running at the time, which are often
+// the most important ones, are not lost. Provisional traces recorded
+// for tail-based sampling are sent as well.
+func (t *RequestTracker) FlushCrashedTraces(ctx context.Context, reason string) {
+	if !t.sendsTraces() {
+		return
+	}
+	t.liveMu.Lock()
+	ops := make([]*encoreOp, 0, len(t.live))
+	for op := range t.live {
+		ops = append(ops, op)
+	}
+	t.liveMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, op := range ops {
+		trace.Crashed(op.trace, reason)
+		data := op.trace.GetAndClear()
+		var chunk *traceChunk
+		if t.streamer != nil {
+			chunk = t.streamer.remove(op)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.sendTraceData(ctx, data, chunk)
+		}()
+	}
+	wg.Wait()
+}
+
+// addLive adds the traced operation op to the running operations.
+func (t *RequestTracker) addLive(op *encoreOp) {
+	t.liveMu.Lock()
+	defer t.liveMu.Unlock()
+	if t.live == nil {
+		t.live = make(map[*encoreOp]bool)
+	}
+	t.live[op] = true
+}
+
+// removeLive removes op from the running operations.
+func (t *RequestTracker) removeLive(op *encoreOp) {
+	t.liveMu.Lock()
+	defer t.liveMu.Unlock()
+	delete(t.live, op)
+}
+
+// sendTraceData sends the trace data, which is a chunk of a streamed
+// trace if chunk is non-nil, and otherwise a whole trace.
+// The exporters are only sent whole traces.
+func (t *RequestTracker) sendTraceData(ctx context.Context, data []byte, chunk *traceChunk) {
+	if exps := t.traceExporters(); len(exps) > 0 {
+		whole, ok := data, true
+		if chunk != nil {
+			whole, ok = t.streamer.exports.add(*chunk, data)
+		}
+		if ok {
+			for _, exp := range exps {
+				if err := exp.ExportTrace(ctx, whole); err != nil {
+					fmt.Fprintln(os.Stderr, "encore: could not export trace:", err)
+				}
+			}
+		}
+	}
+	if t.platform == nil {
+		return
+	}
+
+	if chunk != nil {
+		c := platform.TraceChunk{Seq: chunk.seq, Final: chunk.final}
+		if err := t.platform.SendTraceChunk(ctx, chunk.id, c, bytes.NewReader(data)); err != nil {
 			fmt.Fprintln(os.Stderr, "encore: could not record trace:", err)
 		}
-	}()
+		return
+	}
+
+	traceID, err := model.GenTraceID()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "encore: could not generate trace id:", err)
+		return
+	}
+	err = t.platform.SendTrace(ctx, traceID, bytes.NewReader(data))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "encore: could not record trace:", err)
+	}
 }
@@ -0,0 +1,60 @@
+package reqtrack
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+type recordingExporter struct {
+	mu     sync.Mutex
+	traces [][]byte
+}
+
+func (e *recordingExporter) ExportTrace(ctx context.Context, data []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.traces = append(e.traces, data)
+	return nil
+}
+
+func TestFlushCrashedTraces(t *testing.T) {
+	rt := New(zerolog.Nop(), nil, trace.DefaultFactory)
+	exp := &recordingExporter{}
+	rt.AddTraceExporter(exp)
+
+	rt.BeginOperation()
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	curr := rt.Current()
+	curr.Trace.BeginRequest(req, curr.Goctr)
+	curr.Trace.DBQueryStart(trace.DBQueryStartParams{Query: "SELECT 1", SpanID: req.SpanID, QueryID: 1})
+
+	rt.FlushCrashedTraces(context.Background(), "out of memory")
+	if len(exp.traces) != 1 {
+		t.Fatalf("got %d traces, want 1", len(exp.traces))
+	}
+	spans, err := trace.DecodeSpans(exp.traces[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want the open request and query", len(spans))
+	}
+	for _, s := range spans {
+		if s.Err != "out of memory" || len(s.Events) != 1 || s.Events[0].Name != "crashed" {
+			t.Errorf("span %s not marked as crashed: %+v", s.Name, s)
+		}
+	}
+
+	rt.FinishRequest()
+	rt.FinishOperation()
+	if len(rt.live) != 0 {
+		t.Errorf("got %d running operations after finishing, want 0", len(rt.live))
+	}
+}
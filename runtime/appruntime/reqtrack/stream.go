@@ -0,0 +1,239 @@
+package reqtrack
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/config"
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+// Defaults for config.TraceStreaming.
+const (
+	defaultChunkSize   = 256 << 10
+	defaultMaxBuffered = 4 << 20
+	defaultMaxInFlight = 8
+	defaultInterval    = time.Second
+)
+
+// traceStreamer sends the traces of long-running operations in chunks
+// while the operations run, so that they don't buffer their whole trace.
+// The chunks of a trace are sent under the same trace id with increasing
+// sequence numbers, the last one marked as final, for the trace endpoint
+// to reassemble them.
+//
+// Chunks are queued for sending in a bounded queue. When the queue is full,
+// traces are left to grow until the queue has room, up to the maximum size
+// of each operation's trace buffer, beyond which events are dropped.
+// This bounds the memory used for traces when they are recorded faster
+// than they can be sent.
+type traceStreamer struct {
+	chunkSize   int
+	maxBuffered int
+
+	mu  sync.Mutex
+	ops map[*encoreOp]*streamedTrace // active ops with streamable traces
+
+	queue chan queuedChunk // chunks waiting to be sent
+
+	exports exportBuffer // chunks buffered for the trace exporters
+}
+
+// streamedTrace is the state of the trace of an operation being streamed.
+type streamedTrace struct {
+	log *trace.Log
+	id  model.TraceID // the id the chunks are sent under
+	seq int           // the sequence number of the next chunk
+}
+
+// traceChunk identifies a chunk of a streamed trace.
+type traceChunk struct {
+	id    model.TraceID
+	seq   int
+	final bool
+}
+
+type queuedChunk struct {
+	chunk traceChunk
+	data  []byte
+}
+
+// StreamTraces enables streaming the traces of long-running operations
+// in chunks according to cfg. If cfg is nil or disables streaming,
+// traces are sent in full once their operations complete.
+// It must be called before any operations begin, and starts background
+// goroutines that run for the lifetime of the process.
+//
+// While streaming is enabled, the trace buffered by each operation is
+// bounded by cfg.MaxBuffered (4 MiB by default), and events beyond it
+// are dropped, which is recorded in the trace.
+//
+// Only traces of operations that are traced in full are streamed;
+// provisional traces recorded for tail-based sampling are not,
+// since whether to send them is only decided once they complete.
+// Trace exporters are only sent whole traces: the chunks of a streamed
+// trace are buffered for them until its final chunk has been sent.
+func (t *RequestTracker) StreamTraces(cfg *config.TraceStreaming) {
+	if cfg == nil || cfg.Disabled || t.trace == nil {
+		return
+	}
+
+	s := &traceStreamer{
+		chunkSize:   orDefault(cfg.ChunkSize, defaultChunkSize),
+		maxBuffered: orDefault(cfg.MaxBuffered, defaultMaxBuffered),
+		ops:         make(map[*encoreOp]*streamedTrace),
+		queue:       make(chan queuedChunk, orDefault(cfg.MaxInFlight, defaultMaxInFlight)),
+		exports:     exportBuffer{pending: make(map[model.TraceID]*pendingExport)},
+	}
+	if s.maxBuffered < s.chunkSize {
+		s.maxBuffered = s.chunkSize
+	}
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	t.streamer = s
+
+	go s.run(interval)
+	go func() {
+		for c := range s.queue {
+			c := c
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			t.sendTraceData(ctx, c.data, &c.chunk)
+			cancel()
+		}
+	}()
+}
+
+func orDefault(n, def int) int {
+	if n > 0 {
+		return n
+	}
+	return def
+}
+
+// add starts streaming the trace of op, if it can be streamed.
+func (s *traceStreamer) add(op *encoreOp) {
+	log, ok := op.trace.(*trace.Log)
+	if !ok {
+		return
+	}
+	id, err := model.GenTraceID()
+	if err != nil {
+		return
+	}
+	if max := log.MaxSize(); max == 0 || max > s.maxBuffered {
+		log.SetMaxSize(s.maxBuffered)
+	}
+	s.mu.Lock()
+	s.ops[op] = &streamedTrace{log: log, id: id}
+	s.mu.Unlock()
+}
+
+// remove stops streaming the trace of op, leaving the remaining data to
+// be sent by the caller. It returns the final chunk the remaining data
+// is to be sent as, or nil if no chunks have been sent, in which case
+// the data is the whole trace.
+func (s *traceStreamer) remove(op *encoreOp) *traceChunk {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.ops[op]
+	if !ok {
+		return nil
+	}
+	delete(s.ops, op)
+	if st.seq == 0 {
+		return nil
+	}
+	return &traceChunk{id: st.id, seq: st.seq, final: true}
+}
+
+// run periodically queues the buffered trace data of each operation
+// that has buffered at least a chunk, as long as the queue has room.
+func (s *traceStreamer) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.flush()
+	}
+}
+
+func (s *traceStreamer) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, st := range s.ops {
+		if len(s.queue) == cap(s.queue) {
+			// Apply backpressure by leaving the data buffered.
+			return
+		}
+		if st.log.Len() >= s.chunkSize {
+			// This is the only sender, so the send never blocks.
+			s.queue <- queuedChunk{
+				chunk: traceChunk{id: st.id, seq: st.seq},
+				data:  st.log.GetAndClear(),
+			}
+			st.seq++
+		}
+	}
+}
+
+// maxExportBuffered is the maximum size of a streamed trace buffered
+// for the trace exporters. Larger traces are not exported.
+const maxExportBuffered = 64 << 20
+
+// exportBuffer reassembles streamed traces for the trace exporters,
+// which cannot export spans that cross chunk boundaries.
+type exportBuffer struct {
+	mu      sync.Mutex
+	pending map[model.TraceID]*pendingExport
+}
+
+type pendingExport struct {
+	chunks   map[int][]byte // nil if the trace is too large to export
+	size     int            // total size of chunks
+	received int            // number of chunks received
+	final    int            // sequence number of the final chunk, or -1 if not received
+}
+
+// add adds the chunk c with the given data. Once all chunks of its trace
+// have been added, it returns the whole trace data and true.
+// Chunks may be added in any order.
+func (b *exportBuffer) add(c traceChunk, data []byte) ([]byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p, ok := b.pending[c.id]
+	if !ok {
+		p = &pendingExport{chunks: make(map[int][]byte), final: -1}
+		b.pending[c.id] = p
+	}
+	p.received++
+	if c.final {
+		p.final = c.seq
+	}
+	if p.chunks != nil {
+		p.chunks[c.seq] = data
+		p.size += len(data)
+		if p.size > maxExportBuffered {
+			fmt.Fprintf(os.Stderr, "encore: not exporting trace %s: larger than %d bytes\n", c.id, maxExportBuffered)
+			p.chunks, p.size = nil, 0
+		}
+	}
+	if p.final < 0 || p.received < p.final+1 {
+		return nil, false
+	}
+
+	delete(b.pending, c.id)
+	if p.chunks == nil {
+		return nil, false
+	}
+	whole := make([]byte, 0, p.size)
+	for i := 0; i <= p.final; i++ {
+		whole = append(whole, p.chunks[i]...)
+	}
+	return whole, true
+}
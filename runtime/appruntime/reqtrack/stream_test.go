@@ -0,0 +1,164 @@
+package reqtrack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/config"
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+type chanExporter struct {
+	traces chan []byte
+}
+
+func (e *chanExporter) ExportTrace(ctx context.Context, data []byte) error {
+	e.traces <- data
+	return nil
+}
+
+func TestStreamTraces(t *testing.T) {
+	rt := New(zerolog.Nop(), nil, trace.DefaultFactory)
+	exp := &chanExporter{traces: make(chan []byte, 10)}
+	rt.AddTraceExporter(exp)
+	rt.StreamTraces(&config.TraceStreaming{
+		ChunkSize:   100,
+		MaxBuffered: 1000,
+		MaxInFlight: 10,
+		Interval:    time.Hour, // flushed manually
+	})
+
+	rt.BeginOperation()
+	tr := rt.Current().Trace
+	for i := 0; i < 3; i++ {
+		tr.Add(trace.LogMessage, make([]byte, 50))
+		tr.Add(trace.LogMessage, make([]byte, 50))
+		rt.streamer.flush()
+	}
+	tr.Add(trace.LogMessage, make([]byte, 50))
+	rt.FinishOperation()
+
+	// The exporter is sent the whole trace once, after its final chunk.
+	const want = 7 * (13 + 50)
+	select {
+	case data := <-exp.traces:
+		if len(data) != want {
+			t.Errorf("exported trace of %d bytes, want %d", len(data), want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("trace not exported")
+	}
+	select {
+	case data := <-exp.traces:
+		t.Errorf("exported %d more bytes", len(data))
+	case <-time.After(100 * time.Millisecond):
+	}
+	if len(rt.streamer.ops) != 0 {
+		t.Errorf("operation still streamed after finishing")
+	}
+	if len(rt.streamer.exports.pending) != 0 {
+		t.Errorf("exported trace still buffered")
+	}
+}
+
+func TestStreamTraces_Backpressure(t *testing.T) {
+	// Nothing sends the queued chunks.
+	s := &traceStreamer{
+		chunkSize:   100,
+		maxBuffered: 200,
+		ops:         make(map[*encoreOp]*streamedTrace),
+		queue:       make(chan queuedChunk, 1),
+	}
+	op := &encoreOp{trace: trace.DefaultFactory.NewLogger()}
+	s.add(op)
+
+	tr := op.trace
+	tr.Add(trace.LogMessage, make([]byte, 50))
+	s.flush()
+	if len(s.queue) != 0 {
+		t.Fatalf("queued chunk before reaching the chunk size")
+	}
+
+	// Once the queue is full, data stays buffered up to the maximum.
+	tr.Add(trace.LogMessage, make([]byte, 50))
+	s.flush() // queued
+	tr.Add(trace.LogMessage, make([]byte, 100))
+	s.flush()                                   // queue full
+	tr.Add(trace.LogMessage, make([]byte, 100)) // dropped
+	if got := tr.(*trace.Log).Len(); got != 13+100 {
+		t.Errorf("got %d bytes buffered, want %d", got, 13+100)
+	}
+	if c := <-s.queue; len(c.data) != 2*(13+50) {
+		t.Errorf("got chunk of %d bytes, want %d", len(c.data), 2*(13+50))
+	}
+}
+
+func TestExportBuffer(t *testing.T) {
+	b := exportBuffer{pending: make(map[model.TraceID]*pendingExport)}
+	id := model.TraceID{1}
+
+	// The final chunk may be sent before earlier chunks.
+	if _, ok := b.add(traceChunk{id: id, seq: 2, final: true}, []byte("c")); ok {
+		t.Fatal("trace complete without earlier chunks")
+	}
+	if _, ok := b.add(traceChunk{id: id, seq: 0}, []byte("a")); ok {
+		t.Fatal("trace complete without chunk 1")
+	}
+	data, ok := b.add(traceChunk{id: id, seq: 1}, []byte("b"))
+	if !ok || string(data) != "abc" {
+		t.Fatalf("got trace %q, %v, want %q", data, ok, "abc")
+	}
+	if len(b.pending) != 0 {
+		t.Error("exported trace still buffered")
+	}
+
+	// Traces that grow too large are dropped.
+	id = model.TraceID{2}
+	b.add(traceChunk{id: id, seq: 0}, make([]byte, maxExportBuffered))
+	b.add(traceChunk{id: id, seq: 1}, []byte("x"))
+	if _, ok := b.add(traceChunk{id: id, seq: 2, final: true}, []byte("y")); ok {
+		t.Error("exported trace larger than the maximum")
+	}
+	if len(b.pending) != 0 {
+		t.Error("dropped trace still buffered")
+	}
+}
+
+func TestStreamTraces_Chunks(t *testing.T) {
+	s := &traceStreamer{
+		chunkSize:   10,
+		maxBuffered: 100,
+		ops:         make(map[*encoreOp]*streamedTrace),
+		queue:       make(chan queuedChunk, 10),
+	}
+
+	// Operations that finish before sending a chunk are sent whole.
+	op := &encoreOp{trace: trace.DefaultFactory.NewLogger()}
+	s.add(op)
+	if chunk := s.remove(op); chunk != nil {
+		t.Errorf("got final chunk %+v for unstreamed trace", chunk)
+	}
+
+	op = &encoreOp{trace: trace.DefaultFactory.NewLogger()}
+	s.add(op)
+	for i := 0; i < 2; i++ {
+		op.trace.Add(trace.LogMessage, make([]byte, 10))
+		s.flush()
+	}
+	final := s.remove(op)
+
+	first, second := <-s.queue, <-s.queue
+	if first.chunk.seq != 0 || second.chunk.seq != 1 || first.chunk.final || second.chunk.final {
+		t.Errorf("got chunks %+v and %+v, want sequence numbers 0 and 1", first.chunk, second.chunk)
+	}
+	if final == nil || final.seq != 2 || !final.final {
+		t.Fatalf("got final chunk %+v, want final chunk 2", final)
+	}
+	if first.chunk.id != final.id || second.chunk.id != final.id || final.id == (model.TraceID{}) {
+		t.Errorf("chunks sent under different trace ids")
+	}
+}
@@ -0,0 +1,30 @@
+package reqtrack
+
+import (
+	"sync/atomic"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+// tailTrace is the trace of an operation that was not sampled,
+// recorded provisionally for tail-based sampling.
+// The trace is only sent if it is kept by the sampler
+// when one of the operation's requests completes.
+type tailTrace struct {
+	trace.Logger
+	sampler *trace.Sampler
+	keep    uint32 // non-zero if the trace is kept; accessed atomically
+}
+
+func (tt *tailTrace) FinishRequest(req *model.Request, resp *model.Response) {
+	tt.Logger.FinishRequest(req, resp)
+	if tt.sampler.KeepTail(req, resp) {
+		atomic.StoreUint32(&tt.keep, 1)
+	}
+}
+
+// kept reports whether the trace is kept.
+func (tt *tailTrace) kept() bool {
+	return atomic.LoadUint32(&tt.keep) != 0
+}
@@ -0,0 +1,14 @@
+package trace
+
+// Crashed records in l that the process crashed, or is about to
+// terminate, while the operation l traces is running, for the given
+// reason, such as the message of a fatal error or the value of a panic.
+//
+// Spans still open when the crash is recorded will never end, so they
+// are decoded as ending at the time of the crash, with the reason as
+// their error and the "encore.crashed" attribute set.
+func Crashed(l Logger, reason string) {
+	tb := NewBuffer(len(reason) + 4)
+	tb.String(reason)
+	l.Add(TraceCrashed, tb.Buf())
+}
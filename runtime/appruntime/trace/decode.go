@@ -0,0 +1,636 @@
+package trace
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"encore.dev/appruntime/model"
+)
+
+// SpanKind describes the relationship of a span to its parent and children,
+// with the same meaning as in OpenTelemetry.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = iota
+	SpanKindServer
+	SpanKindClient
+	SpanKindProducer
+	SpanKindConsumer
+)
+
+// OperationAttr is the attribute recording the kind of operation an
+// application-defined span represents, for spans started as typed
+// operations such as cache lookups and outgoing HTTP calls.
+// Such spans are decoded with the span kind of the operation,
+// and with the "error" attribute recorded when they end as their error.
+const OperationAttr = "encore.operation"
+
+// The kinds of operations application-defined spans can represent.
+const (
+	OperationCache   = "cache"
+	OperationHTTP    = "http"
+	OperationPublish = "publish"
+	OperationConsume = "consume"
+)
+
+// operationSpanKind returns the span kind of an operation kind.
+func operationSpanKind(op string) SpanKind {
+	switch op {
+	case OperationCache, OperationHTTP:
+		return SpanKindClient
+	case OperationPublish:
+		return SpanKindProducer
+	case OperationConsume:
+		return SpanKindConsumer
+	default:
+		return SpanKindInternal
+	}
+}
+
+// Span is a completed operation decoded from trace data,
+// for exporting traces to other tracing systems.
+type Span struct {
+	TraceID  model.TraceID
+	SpanID   model.SpanID
+	ParentID model.SpanID // zero for root spans
+	Name     string
+	Kind     SpanKind
+	Start    time.Time
+	End      time.Time
+	Attrs    []Attr
+	Err      string // the error message, or "" if the operation succeeded
+	Events   []SpanEvent
+	Links    []SpanLink
+}
+
+// SpanEvent is an event that happened during a span, such as a log message.
+type SpanEvent struct {
+	Time  time.Time
+	Name  string
+	Attrs []Attr
+}
+
+// SpanLink is a link from a span to a span of another trace,
+// such as the trace of the message that caused the work it does.
+type SpanLink struct {
+	TraceID model.TraceID
+	SpanID  model.SpanID
+	Attrs   []Attr
+}
+
+// Attr is an attribute of a span or span event.
+// Value is a string, bool, int64 or float64.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// DecodeSpans decodes the spans recorded in data, as returned by Log.GetAndClear.
+// Requests, database queries, Pub/Sub publishes, outgoing HTTP calls,
+// cache operations and application-defined spans are decoded as spans,
+// and log messages and application-defined events as events of the span
+// they were recorded in. If events were dropped from data because the
+// trace reached its maximum size, the request spans are marked as
+// truncated with the "encore.trace.truncated" attribute and an event.
+//
+// Only spans that both start and end within data are returned, in the
+// order they started. Since event times are recorded using the monotonic
+// clock, DecodeSpans must be called by the process that recorded data.
+// Other processes can use DecodeSpansWithOffset.
+func DecodeSpans(data []byte) ([]Span, error) {
+	return DecodeSpansWithOffset(data, ClockOffset())
+}
+
+// ClockOffset returns the offset from the monotonic clock that trace event
+// times are recorded with to Unix time in nanoseconds, in this process.
+// Storing it alongside trace data allows decoding the data in another
+// process with DecodeSpansWithOffset.
+func ClockOffset() int64 {
+	return time.Now().UnixNano() - nanotime()
+}
+
+// DecodeSpansWithOffset is like DecodeSpans, for data recorded
+// by a process with the given ClockOffset.
+func DecodeSpansWithOffset(data []byte, offset int64) ([]Span, error) {
+	d := &spanDecoder{
+		offset:   offset,
+		traces:   make(map[model.SpanID]model.TraceID),
+		open:     make(map[model.SpanID]*Span),
+		pending:  make(map[pendingKey]*Span),
+		requests: make(map[model.SpanID]bool),
+	}
+	for len(data) > 0 {
+		if len(data) < 13 {
+			return nil, errors.New("trace: truncated event header")
+		}
+		typ := EventType(data[0])
+		ts := int64(binary.LittleEndian.Uint64(data[1:9]))
+		n := binary.LittleEndian.Uint32(data[9:13])
+		if uint64(len(data)-13) < uint64(n) {
+			return nil, fmt.Errorf("trace: truncated %v event", typ)
+		}
+		r := &eventReader{buf: data[13 : 13+n]}
+		data = data[13+n:]
+
+		d.event(typ, d.time(ts), r)
+		if r.err != nil {
+			return nil, fmt.Errorf("trace: decode %v event: %v", typ, r.err)
+		}
+	}
+	d.markTruncated()
+	return d.done, nil
+}
+
+// pendingKey identifies a span that is ended by an event
+// referring to it by an id other than its span id.
+type pendingKey struct {
+	typ EventType // the type of the start event
+	id  uint64
+}
+
+type spanDecoder struct {
+	offset  int64                          // offset from nanotime to unix nanoseconds
+	traces  map[model.SpanID]model.TraceID // trace id of requests and user spans, including ended ones
+	open    map[model.SpanID]*Span         // spans that have started but not ended
+	pending map[pendingKey]*Span           // open spans by their start event id
+	started []*Span                        // open spans in the order they started
+	done    []Span
+
+	requests    map[model.SpanID]bool // span ids of requests
+	truncations []SpanEvent           // the TraceTruncated events
+}
+
+func (d *spanDecoder) time(nanotime int64) time.Time {
+	return time.Unix(0, nanotime+d.offset)
+}
+
+func (d *spanDecoder) event(typ EventType, ts time.Time, r *eventReader) {
+	switch typ {
+	case RequestStart:
+		d.requestStart(ts, r)
+	case RequestEnd:
+		r.byte() // request type
+		spanID := r.spanID()
+		d.end(d.open[spanID], ts, r.string())
+
+	case QueryStart:
+		id := r.uvarint()
+		parent := r.spanID()
+		r.uvarint() // tx id
+		r.uvarint() // goid
+		query := r.string()
+		d.start(pendingKey{typ, id}, parent, "db.query", SpanKindClient, ts,
+			Attr{"db.system", "postgresql"}, Attr{"db.statement", query})
+	case QueryEnd:
+		id := r.uvarint()
+		d.endPending(pendingKey{QueryStart, id}, ts, r.string())
+
+	case PublishStart:
+		id := r.uvarint()
+		parent := r.spanID()
+		r.uvarint() // goid
+		topic := r.string()
+		d.start(pendingKey{typ, id}, parent, topic+" publish", SpanKindProducer, ts,
+			Attr{"messaging.system", "encore"}, Attr{"messaging.destination.name", topic})
+	case PublishEnd:
+		id := r.uvarint()
+		msgID := r.string()
+		if s := d.pending[pendingKey{PublishStart, id}]; s != nil && msgID != "" {
+			s.Attrs = append(s.Attrs, Attr{"messaging.message.id", msgID})
+		}
+		d.endPending(pendingKey{PublishStart, id}, ts, r.string())
+
+	case HTTPCallStart:
+		id := r.uvarint()
+		parent := r.spanID()
+		spanID := r.spanID()
+		r.uvarint() // goid
+		method := r.string()
+		url := r.string()
+		if s := d.start(pendingKey{typ, id}, parent, "HTTP "+method, SpanKindClient, ts,
+			Attr{"http.method", method}, Attr{"http.url", url}); s != nil {
+			s.SpanID = spanID
+		}
+	case HTTPCallEnd:
+		id := r.uvarint()
+		errMsg := r.string()
+		if status := r.uvarint(); status != 0 {
+			if s := d.pending[pendingKey{HTTPCallStart, id}]; s != nil {
+				s.Attrs = append(s.Attrs, Attr{"http.status_code", int64(status)})
+			}
+		}
+		d.endPending(pendingKey{HTTPCallStart, id}, ts, errMsg)
+
+	case CacheOpStart:
+		id := r.uvarint()
+		parent := r.spanID()
+		r.uvarint() // goid
+		r.uvarint() // def loc
+		op := r.string()
+		r.bool() // is write
+		r.stack()
+		attrs := []Attr{{"db.system", "redis"}, {"db.operation", op}}
+		if n := r.uvarint(); n > 0 {
+			attrs = append(attrs, Attr{"db.redis.key", r.string()})
+		}
+		d.start(pendingKey{typ, id}, parent, "cache "+op, SpanKindClient, ts, attrs...)
+	case CacheOpEnd:
+		id := r.uvarint()
+		var errMsg string
+		if res := CacheOpResult(r.byte()); res == CacheErr {
+			errMsg = r.string()
+		}
+		d.endPending(pendingKey{CacheOpStart, id}, ts, errMsg)
+
+	case UserSpanStart:
+		spanID := r.spanID()
+		parent := r.spanID()
+		r.uvarint() // goctr
+		name := r.string()
+		attrs := r.fields()
+		kind := SpanKindInternal
+		if op, ok := attrValue(attrs, OperationAttr).(string); ok {
+			kind = operationSpanKind(op)
+		}
+		if s := d.start(pendingKey{}, parent, name, kind, ts, attrs...); s != nil {
+			s.SpanID = spanID
+			d.open[spanID] = s
+			d.traces[spanID] = s.TraceID // for nested spans
+		}
+	case UserSpanEnd:
+		spanID := r.spanID()
+		r.int64() // duration
+		s := d.open[spanID]
+		var errMsg string
+		if s != nil {
+			attrs := r.fields()
+			s.Attrs = append(s.Attrs, attrs...)
+			if _, isOp := attrValue(s.Attrs, OperationAttr).(string); isOp {
+				errMsg, _ = attrValue(attrs, "error").(string)
+			}
+		}
+		d.end(s, ts, errMsg)
+
+	case UserSpanAttrs:
+		spanID := r.spanID()
+		r.uvarint() // goctr
+		attrs := r.fields()
+		if s := d.open[spanID]; s != nil {
+			s.Attrs = setAttrs(s.Attrs, attrs)
+		}
+
+	case UserSpanEvent:
+		spanID := r.spanID()
+		r.uvarint() // goctr
+		name := r.string()
+		attrs := r.fields()
+		if s := d.open[spanID]; s != nil {
+			s.Events = append(s.Events, SpanEvent{Time: ts, Name: name, Attrs: attrs})
+		}
+
+	case UserSpanLink:
+		spanID := r.spanID()
+		r.uvarint() // goctr
+		traceID := r.traceID()
+		linked := r.spanID()
+		attrs := r.fields()
+		if s := d.open[spanID]; s != nil {
+			s.Links = append(s.Links, SpanLink{TraceID: traceID, SpanID: linked, Attrs: attrs})
+		}
+
+	case TraceTruncated:
+		policy := TruncationPolicy(r.byte())
+		events, size := r.uvarint(), r.uvarint()
+		d.truncations = append(d.truncations, SpanEvent{Time: ts, Name: "trace truncated", Attrs: []Attr{
+			{"encore.trace.truncation_policy", policy.String()},
+			{"encore.trace.dropped_events", int64(events)},
+			{"encore.trace.dropped_bytes", int64(size)},
+		}})
+
+	case TraceCrashed:
+		d.crash(ts, r.string())
+
+	case LogMessage, ExternalLogMessage:
+		var reqSpanID model.SpanID
+		if typ == ExternalLogMessage {
+			reqSpanID = r.spanID()
+		}
+		spanID := r.spanID()
+		r.uvarint() // goctr
+		level := r.byte()
+		msg := r.string()
+		attrs := append([]Attr{{"level", levelName(level)}, {"message", msg}}, r.fields()...)
+		s := d.open[spanID]
+		if s == nil && typ == ExternalLogMessage {
+			// The explicit span is not part of the trace,
+			// so record the log under the request that logged it.
+			s = d.open[reqSpanID]
+			attrs = append(attrs, Attr{"encore.log.span_id", spanID.String()})
+		}
+		if s != nil {
+			s.Events = append(s.Events, SpanEvent{Time: ts, Name: "log", Attrs: attrs})
+		}
+	}
+}
+
+// crash ends the spans that are open at the time ts the process crashed
+// for the given reason, marking them as crashed, as they will never end.
+func (d *spanDecoder) crash(ts time.Time, reason string) {
+	for _, s := range append([]*Span(nil), d.started...) {
+		if !s.End.IsZero() {
+			continue
+		}
+		s.Attrs = append(s.Attrs, Attr{"encore.crashed", true})
+		s.Events = append(s.Events, SpanEvent{Time: ts, Name: "crashed", Attrs: []Attr{{"encore.crash.reason", reason}}})
+		d.end(s, ts, reason)
+	}
+	d.pending = make(map[pendingKey]*Span)
+}
+
+// markTruncated marks the decoded request spans as incomplete if events
+// were dropped from the trace data, as the dropped events are not
+// attributed to particular spans.
+func (d *spanDecoder) markTruncated() {
+	if len(d.truncations) == 0 {
+		return
+	}
+	for i := range d.done {
+		if s := &d.done[i]; d.requests[s.SpanID] {
+			s.Attrs = append(s.Attrs, Attr{"encore.trace.truncated", true})
+			s.Events = append(s.Events, d.truncations...)
+		}
+	}
+}
+
+// attrValue returns the value of the attribute with the given key,
+// or nil if there is none.
+func attrValue(attrs []Attr, key string) any {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+// setAttrs sets the attributes in attrs, replacing
+// any existing attributes with the same key.
+func setAttrs(existing, attrs []Attr) []Attr {
+outer:
+	for _, a := range attrs {
+		for i := range existing {
+			if existing[i].Key == a.Key {
+				existing[i] = a
+				continue outer
+			}
+		}
+		existing = append(existing, a)
+	}
+	return existing
+}
+
+func (d *spanDecoder) requestStart(ts time.Time, r *eventReader) {
+	s := &Span{Start: ts, Kind: SpanKindServer}
+	typ := model.RequestType(r.byte())
+	r.time() // wall clock time; the event time is used instead
+	copy(s.TraceID[:], r.bytes(len(s.TraceID)))
+	r.bytes(len(model.TraceID{})) // parent trace id
+	s.SpanID = r.spanID()
+	s.ParentID = r.spanID()
+	r.uvarint() // goid
+	r.uvarint() // def loc
+
+	switch typ {
+	case model.RPCCall:
+		r.bool() // raw
+		svc, ep := r.string(), r.string()
+		method, path := r.string(), r.string()
+		s.Name = svc + "." + ep
+		s.Attrs = []Attr{
+			{"encore.service", svc},
+			{"encore.endpoint", ep},
+			{"http.method", method},
+			{"http.target", path},
+		}
+	case model.AuthHandler:
+		svc, ep := r.string(), r.string()
+		s.Name = svc + "." + ep
+		s.Kind = SpanKindInternal
+		s.Attrs = []Attr{{"encore.service", svc}, {"encore.endpoint", ep}}
+	case model.PubSubMessage:
+		svc, topic, sub, msgID := r.string(), r.string(), r.string(), r.string()
+		attempt := r.uint32()
+		s.Name = topic + " process"
+		s.Kind = SpanKindConsumer
+		s.Attrs = []Attr{
+			{"encore.service", svc},
+			{"messaging.system", "encore"},
+			{"messaging.destination.name", topic},
+			{"messaging.subscription", sub},
+			{"messaging.message.id", msgID},
+			{"messaging.delivery_attempt", int64(attempt)},
+		}
+	case model.Test:
+		s.Name = "test"
+		s.Kind = SpanKindInternal
+	default:
+		s.Name = "request"
+	}
+
+	d.traces[s.SpanID] = s.TraceID
+	d.requests[s.SpanID] = true
+	d.open[s.SpanID] = s
+	d.started = append(d.started, s)
+}
+
+// start starts a span that is a child of the request or span parent.
+// It returns nil if the trace parent belongs to is unknown.
+func (d *spanDecoder) start(key pendingKey, parent model.SpanID, name string, kind SpanKind, ts time.Time, attrs ...Attr) *Span {
+	traceID, ok := d.traces[parent]
+	if !ok {
+		return nil
+	}
+	spanID, _ := model.GenSpanID()
+	s := &Span{
+		TraceID:  traceID,
+		SpanID:   spanID,
+		ParentID: parent,
+		Name:     name,
+		Kind:     kind,
+		Start:    ts,
+		Attrs:    attrs,
+	}
+	if key != (pendingKey{}) {
+		d.pending[key] = s
+	}
+	d.started = append(d.started, s)
+	return s
+}
+
+func (d *spanDecoder) endPending(key pendingKey, ts time.Time, errMsg string) {
+	d.end(d.pending[key], ts, errMsg)
+	delete(d.pending, key)
+}
+
+// end ends the span s, if non-nil, and adds all spans that have ended
+// before the first span that is still open to the decoded spans.
+func (d *spanDecoder) end(s *Span, ts time.Time, errMsg string) {
+	if s == nil {
+		return
+	}
+	s.End = ts
+	s.Err = errMsg
+	delete(d.open, s.SpanID)
+
+	for len(d.started) > 0 && !d.started[0].End.IsZero() {
+		d.done = append(d.done, *d.started[0])
+		d.started = d.started[1:]
+	}
+}
+
+// levelName returns the name of an rlog level.
+func levelName(level byte) string {
+	switch level {
+	case 0:
+		return "trace"
+	case 1:
+		return "debug"
+	case 2:
+		return "info"
+	case 3:
+		return "warn"
+	case 4:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// eventReader reads the fields of a trace event.
+// The first error is recorded in err, after which
+// all reads return zero values.
+type eventReader struct {
+	buf []byte
+	err error
+}
+
+func (r *eventReader) bytes(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	} else if len(r.buf) < n {
+		r.err = errors.New("unexpected end of event")
+		r.buf = nil
+		return make([]byte, n)
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *eventReader) byte() byte     { return r.bytes(1)[0] }
+func (r *eventReader) bool() bool     { return r.byte() != 0 }
+func (r *eventReader) uint32() uint32 { return binary.LittleEndian.Uint32(r.bytes(4)) }
+func (r *eventReader) uint64() uint64 { return binary.LittleEndian.Uint64(r.bytes(8)) }
+func (r *eventReader) int64() int64   { return unzigzag(r.uint64()) }
+func (r *eventReader) varint() int64  { return unzigzag(r.uvarint()) }
+
+func (r *eventReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	x, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errors.New("invalid varint")
+		r.buf = nil
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return x
+}
+
+func (r *eventReader) string() string {
+	n := r.uvarint()
+	if uint64(len(r.buf)) < n {
+		r.bytes(len(r.buf) + 1) // records the error
+		return ""
+	}
+	return string(r.bytes(int(n)))
+}
+
+func (r *eventReader) traceID() (id model.TraceID) {
+	copy(id[:], r.bytes(len(id)))
+	return id
+}
+
+func (r *eventReader) spanID() (id model.SpanID) {
+	copy(id[:], r.bytes(len(id)))
+	return id
+}
+
+func (r *eventReader) time() time.Time {
+	sec := r.int64()
+	nsec := int32(unzigzag(uint64(r.uint32())))
+	return time.Unix(sec, int64(nsec))
+}
+
+func (r *eventReader) stack() {
+	n := int(r.byte())
+	for i := 0; i < n; i++ {
+		r.uvarint()
+	}
+}
+
+// fields reads a list of log fields, as recorded by rlog.
+func (r *eventReader) fields() []Attr {
+	n := int(r.uvarint())
+	attrs := make([]Attr, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		typ := r.byte()
+		a := Attr{Key: r.string()}
+		switch typ {
+		case 1: // error
+			a.Value = r.string()
+			r.stack()
+		case 2: // string
+			a.Value = r.string()
+		case 3: // bool
+			a.Value = r.bool()
+		case 4: // time
+			a.Value = r.time().Format(time.RFC3339Nano)
+		case 5: // duration
+			a.Value = time.Duration(r.int64()).String()
+		case 6: // uuid
+			a.Value = fmt.Sprintf("%x-%x-%x-%x-%x", r.bytes(4), r.bytes(2), r.bytes(2), r.bytes(2), r.bytes(6))
+		case 7: // json
+			a.Value = r.string()
+			if errMsg := r.string(); errMsg != "" {
+				a.Value = errMsg
+			}
+		case 8: // int
+			a.Value = r.varint()
+		case 9: // uint
+			a.Value = int64(r.uvarint())
+		case 10: // float32
+			a.Value = float64(math.Float32frombits(r.uint32()))
+		case 11: // float64
+			a.Value = math.Float64frombits(r.uint64())
+		default:
+			r.err = fmt.Errorf("unknown field type %d", typ)
+			return attrs
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs
+}
+
+func unzigzag(u uint64) int64 {
+	x := int64(u >> 1)
+	if u&1 != 0 {
+		x = ^x
+	}
+	return x
+}
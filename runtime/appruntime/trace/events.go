@@ -37,6 +37,19 @@ const (
 	CacheOpStart       EventType = 0x16
 	CacheOpEnd         EventType = 0x17
 	BodyStream         EventType = 0x18
+	UserSpanStart      EventType = 0x19
+	UserSpanEnd        EventType = 0x1A
+	UserSpanAttrs      EventType = 0x1B
+	UserSpanEvent      EventType = 0x1C
+	UserSpanLink       EventType = 0x1D
+	TraceTruncated     EventType = 0x1E
+	TraceCrashed       EventType = 0x1F
+
+	// ExternalLogMessage is a LogMessage recorded under an explicit span
+	// rather than the span of the request that logged it, such as a log
+	// imported from another system. Its data is the span id of the request
+	// that logged it, followed by the data of a LogMessage event.
+	ExternalLogMessage EventType = 0x20
 )
 
 func (te EventType) String() string {
@@ -89,13 +102,30 @@ func (te EventType) String() string {
 		return "CacheOpEnd"
 	case BodyStream:
 		return "BodyStream"
+	case UserSpanStart:
+		return "UserSpanStart"
+	case UserSpanEnd:
+		return "UserSpanEnd"
+	case UserSpanAttrs:
+		return "UserSpanAttrs"
+	case UserSpanEvent:
+		return "UserSpanEvent"
+	case UserSpanLink:
+		return "UserSpanLink"
+	case TraceTruncated:
+		return "TraceTruncated"
+	case TraceCrashed:
+		return "TraceCrashed"
+	case ExternalLogMessage:
+		return "ExternalLogMessage"
 	default:
 		return fmt.Sprintf("Unknown(%x)", byte(te))
 	}
 }
 
 func (l *Log) BeginRequest(req *model.Request, goid uint32) {
-	tb := NewBuffer(1 + 8 + 8 + 8 + 8 + 8 + 8 + 64)
+	tb := GetBuffer(1 + 8 + 8 + 8 + 8 + 8 + 8 + 64)
+	defer PutBuffer(tb)
 	tb.Byte(byte(req.Type))
 	tb.Now()
 	tb.Bytes(req.TraceID[:])
@@ -124,9 +154,9 @@ func (l *Log) BeginRequest(req *model.Request, goid uint32) {
 		tb.String(req.ExtCorrelationID)
 
 		if desc.Raw {
-			l.logHeaders(&tb, data.RequestHeaders)
+			l.logHeaders(tb, data.RequestHeaders)
 		} else {
-			tb.ByteString(data.NonRawPayload)
+			tb.ByteString(l.redactor.Redact(data.NonRawPayload, data.TypedPayload))
 		}
 
 	case model.AuthHandler:
@@ -134,7 +164,7 @@ func (l *Log) BeginRequest(req *model.Request, goid uint32) {
 		desc := data.Desc
 		tb.String(desc.Service)
 		tb.String(desc.Endpoint)
-		tb.ByteString(data.NonRawPayload)
+		tb.ByteString(l.redactor.Redact(data.NonRawPayload, data.TypedPayload))
 
 	case model.PubSubMessage:
 		data := req.MsgData
@@ -144,14 +174,15 @@ func (l *Log) BeginRequest(req *model.Request, goid uint32) {
 		tb.String(data.MessageID)
 		tb.Uint32(uint32(data.Attempt))
 		tb.Time(data.Published)
-		tb.ByteString(data.Payload)
+		tb.ByteString(l.redactor.Redact(data.Payload, data.DecodedPayload))
 	}
 
 	l.Add(RequestStart, tb.Buf())
 }
 
 func (l *Log) FinishRequest(req *model.Request, resp *model.Response) {
-	tb := NewBuffer(64)
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.Byte(byte(req.Type))
 	tb.Bytes(req.SpanID[:])
 
@@ -165,22 +196,23 @@ func (l *Log) FinishRequest(req *model.Request, resp *model.Response) {
 		isRaw := req.RPCData.Desc.Raw
 		tb.Bool(isRaw)
 		if isRaw {
-			l.logHeaders(&tb, resp.RawResponseHeaders)
+			l.logHeaders(tb, resp.RawResponseHeaders)
 		} else {
-			tb.ByteString(resp.Payload)
+			tb.ByteString(l.redactor.Redact(resp.Payload, resp.TypedPayload))
 		}
 	case model.AuthHandler:
 		tb.String(string(resp.AuthUID))
-		tb.ByteString(resp.Payload)
+		tb.ByteString(l.redactor.Redact(resp.Payload, resp.TypedPayload))
 	case model.PubSubMessage:
-		tb.ByteString(resp.Payload)
+		tb.ByteString(l.redactor.Redact(resp.Payload, resp.TypedPayload))
 	}
 
 	l.Add(RequestEnd, tb.Buf())
 }
 
 func (l *Log) BeginCall(call *model.APICall, goid uint32) {
-	tb := NewBuffer(8 + 4 + 4 + 4)
+	tb := GetBuffer(8 + 4 + 4 + 4)
+	defer PutBuffer(tb)
 	tb.UVarint(call.ID)
 	tb.Bytes(call.Source.SpanID[:])
 	tb.Bytes(call.SpanID[:])
@@ -192,7 +224,8 @@ func (l *Log) BeginCall(call *model.APICall, goid uint32) {
 }
 
 func (l *Log) FinishCall(call *model.APICall, err error) {
-	tb := NewBuffer(8 + 4 + 4 + 4)
+	tb := GetBuffer(8 + 4 + 4 + 4)
+	defer PutBuffer(tb)
 	tb.UVarint(call.ID)
 	if err != nil {
 		msg := err.Error()
@@ -207,7 +240,8 @@ func (l *Log) FinishCall(call *model.APICall, err error) {
 }
 
 func (l *Log) BeginAuth(call *model.AuthCall, goid uint32) {
-	tb := NewBuffer(8 + 4 + 4 + 4)
+	tb := GetBuffer(8 + 4 + 4 + 4)
+	defer PutBuffer(tb)
 	tb.UVarint(call.ID)
 	tb.Bytes(call.SpanID[:])
 	tb.UVarint(uint64(goid))
@@ -216,7 +250,8 @@ func (l *Log) BeginAuth(call *model.AuthCall, goid uint32) {
 }
 
 func (l *Log) FinishAuth(call *model.AuthCall, uid model.UID, err error) {
-	tb := NewBuffer(64)
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(call.ID)
 	tb.String(string(uid))
 	if err != nil {
@@ -243,7 +278,8 @@ type DBQueryStartParams struct {
 }
 
 func (l *Log) DBQueryStart(p DBQueryStartParams) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(p.QueryID)
 	tb.Bytes(p.SpanID[:])
 	tb.UVarint(p.TxID)
@@ -254,7 +290,8 @@ func (l *Log) DBQueryStart(p DBQueryStartParams) {
 }
 
 func (l *Log) DBQueryEnd(queryID uint64, err error) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(queryID)
 	if err != nil {
 		tb.String(err.Error())
@@ -272,7 +309,8 @@ type DBTxStartParams struct {
 }
 
 func (l *Log) DBTxStart(p DBTxStartParams) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(p.TxID)
 	tb.Bytes(p.SpanID[:])
 	tb.UVarint(uint64(p.Goid))
@@ -290,7 +328,8 @@ type DBTxEndParams struct {
 }
 
 func (l *Log) DBTxEnd(p DBTxEndParams) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(p.TxID)
 	tb.Bytes(p.SpanID[:])
 	tb.UVarint(uint64(p.Goid))
@@ -309,18 +348,20 @@ func (l *Log) DBTxEnd(p DBTxEndParams) {
 }
 
 func (l *Log) PublishStart(topic string, msg []byte, spanID model.SpanID, goid uint32, publishID uint64, skipFrames int) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(publishID)
 	tb.Bytes(spanID[:])
 	tb.UVarint(uint64(goid))
 	tb.String(topic)
-	tb.ByteString(msg)
+	tb.ByteString(l.redactor.Redact(msg, nil))
 	tb.Stack(stack.Build(skipFrames))
 	l.Add(PublishStart, tb.Buf())
 }
 
 func (l *Log) PublishEnd(publishID uint64, messageID string, err error) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(publishID)
 	tb.String(messageID)
 	tb.Err(err)
@@ -378,6 +419,19 @@ func (l *Log) GoEnd(spanID model.SpanID, goctr uint32) {
 	})
 }
 
+// SpanLink records a link without attributes from the span spanID
+// to the span linkedSpanID of the trace traceID.
+func (l *Log) SpanLink(spanID model.SpanID, goctr uint32, traceID model.TraceID, linkedSpanID model.SpanID) {
+	tb := GetBuffer(8 + 4 + 16 + 8 + 1)
+	defer PutBuffer(tb)
+	tb.Bytes(spanID[:])
+	tb.UVarint(uint64(goctr))
+	tb.Bytes(traceID[:])
+	tb.Bytes(linkedSpanID[:])
+	tb.UVarint(0) // attributes
+	l.Add(UserSpanLink, tb.Buf())
+}
+
 type ServiceInitStartParams struct {
 	InitCtr uint64
 	SpanID  model.SpanID
@@ -387,7 +441,8 @@ type ServiceInitStartParams struct {
 }
 
 func (l *Log) ServiceInitStart(p ServiceInitStartParams) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.Bytes(p.SpanID[:])
 	tb.UVarint(p.InitCtr)
 	tb.UVarint(uint64(p.Goctr))
@@ -397,7 +452,8 @@ func (l *Log) ServiceInitStart(p ServiceInitStartParams) {
 }
 
 func (l *Log) ServiceInitEnd(initCtr uint64, err error) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(initCtr)
 	tb.Err(err)
 	if err != nil {
@@ -419,7 +475,8 @@ type CacheOpStartParams struct {
 }
 
 func (l *Log) CacheOpStart(p CacheOpStartParams) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(p.OpID)
 	tb.Bytes(p.SpanID[:])
 	tb.UVarint(uint64(p.Goid))
@@ -451,7 +508,8 @@ type CacheOpEndParams struct {
 }
 
 func (l *Log) CacheOpEnd(p CacheOpEndParams) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.UVarint(p.OpID)
 	tb.Byte(byte(p.Res))
 	if p.Res == CacheErr {
@@ -495,7 +553,8 @@ type BodyStreamParams struct {
 }
 
 func (l *Log) BodyStream(p BodyStreamParams) {
-	var tb Buffer
+	tb := GetBuffer(64)
+	defer PutBuffer(tb)
 	tb.Bytes(p.SpanID[:])
 
 	var flags byte = 0
@@ -0,0 +1,245 @@
+// Package filetrace exports traces to local files, so that they
+// can be inspected or diffed without a tracing backend.
+//
+// Each exported trace is written to its own file, either as the
+// decoded spans in JSON or in the native binary trace format:
+//
+//	exp, err := filetrace.New(filetrace.Config{
+//		Dir:    ".encore/traces",
+//		Format: filetrace.FormatBinary,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	rt.AddTraceExporter(exp)
+//
+// Files in the binary format can be decoded with ReadFile.
+package filetrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"encore.dev/appruntime/trace"
+)
+
+// The formats traces can be written in.
+const (
+	FormatJSON   = "json"
+	FormatBinary = "binary"
+)
+
+// magic begins every trace file in the binary format.
+var magic = [8]byte{'E', 'N', 'C', 'T', 'R', 'A', 'C', 'E'}
+
+// Config configures an Exporter.
+type Config struct {
+	// Dir is the directory to write trace files to.
+	// It is created if it does not exist.
+	Dir string
+
+	// Format is the format to write traces in, FormatJSON
+	// or FormatBinary. It defaults to FormatJSON.
+	Format string
+}
+
+// Exporter writes traces to files in a directory.
+// It implements trace.Exporter.
+type Exporter struct {
+	cfg Config
+	seq uint64 // accessed atomically
+}
+
+var _ trace.Exporter = (*Exporter)(nil)
+
+// New creates an Exporter that writes traces as configured by cfg.
+func New(cfg Config) (*Exporter, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("filetrace: no directory configured")
+	}
+	switch cfg.Format {
+	case "":
+		cfg.Format = FormatJSON
+	case FormatJSON, FormatBinary:
+	default:
+		return nil, fmt.Errorf("filetrace: unknown format %q", cfg.Format)
+	}
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("filetrace: %v", err)
+	}
+	return &Exporter{cfg: cfg}, nil
+}
+
+// ExportTrace writes the spans in data to a new file in the directory.
+// The file is named after the start time and trace id of its first span,
+// so that listing the directory lists the traces in the order they began.
+// Data without any completed spans is not written.
+func (e *Exporter) ExportTrace(ctx context.Context, data []byte) error {
+	spans, err := trace.DecodeSpans(data)
+	if err != nil {
+		return err
+	} else if len(spans) == 0 {
+		return nil
+	}
+
+	var contents []byte
+	ext := ".json"
+	if e.cfg.Format == FormatBinary {
+		contents = encodeBinary(data, trace.ClockOffset())
+		ext = ".trace"
+	} else {
+		contents, err = json.MarshalIndent(convertSpans(spans), "", "  ")
+		if err != nil {
+			return err
+		}
+	}
+
+	first := spans[0]
+	name := fmt.Sprintf("%s-%s-%d%s",
+		first.Start.UTC().Format("20060102T150405.000000000Z"),
+		hex.EncodeToString(first.TraceID[:]),
+		atomic.AddUint64(&e.seq, 1),
+		ext)
+	return os.WriteFile(filepath.Join(e.cfg.Dir, name), contents, 0644)
+}
+
+// Shutdown does nothing, as traces are written synchronously.
+// It exists for symmetry with the other exporters.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// encodeBinary encodes trace data in the binary file format:
+// the magic bytes, the trace protocol version as a uvarint,
+// the clock offset of the recording process, and then the data.
+func encodeBinary(data []byte, clockOffset int64) []byte {
+	buf := make([]byte, 0, len(magic)+2*binary.MaxVarintLen64+len(data))
+	buf = append(buf, magic[:]...)
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], uint64(trace.CurrentVersion))
+	buf = append(buf, tmp[:n]...)
+	n = binary.PutVarint(tmp[:], clockOffset)
+	buf = append(buf, tmp[:n]...)
+	return append(buf, data...)
+}
+
+// ReadFile decodes the spans of a trace file written in the binary format.
+func ReadFile(path string) ([]trace.Span, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(contents)
+}
+
+// Decode decodes the spans of the contents of a trace file
+// written in the binary format.
+func Decode(contents []byte) ([]trace.Span, error) {
+	if !bytes.HasPrefix(contents, magic[:]) {
+		return nil, errors.New("filetrace: not a binary trace file")
+	}
+	r := bytes.NewReader(contents[len(magic):])
+	version, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, errors.New("filetrace: truncated trace file")
+	} else if trace.Version(version) != trace.CurrentVersion {
+		return nil, fmt.Errorf("filetrace: unsupported trace version %d", version)
+	}
+	offset, err := binary.ReadVarint(r)
+	if err != nil {
+		return nil, errors.New("filetrace: truncated trace file")
+	}
+	data := contents[len(contents)-r.Len():]
+	return trace.DecodeSpansWithOffset(data, offset)
+}
+
+// span is a span in the JSON format.
+type span struct {
+	TraceID  string         `json:"trace_id"`
+	SpanID   string         `json:"span_id"`
+	ParentID string         `json:"parent_id,omitempty"`
+	Name     string         `json:"name"`
+	Kind     string         `json:"kind"`
+	Start    time.Time      `json:"start"`
+	End      time.Time      `json:"end"`
+	Duration time.Duration  `json:"duration_ns"`
+	Attrs    map[string]any `json:"attrs,omitempty"`
+	Error    string         `json:"error,omitempty"`
+	Events   []event        `json:"events,omitempty"`
+	Links    []link         `json:"links,omitempty"`
+}
+
+type event struct {
+	Time  time.Time      `json:"time"`
+	Name  string         `json:"name"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+type link struct {
+	TraceID string         `json:"trace_id"`
+	SpanID  string         `json:"span_id"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+var kindNames = map[trace.SpanKind]string{
+	trace.SpanKindInternal: "internal",
+	trace.SpanKindServer:   "server",
+	trace.SpanKindClient:   "client",
+	trace.SpanKindProducer: "producer",
+	trace.SpanKindConsumer: "consumer",
+}
+
+func convertSpans(spans []trace.Span) []span {
+	out := make([]span, len(spans))
+	for i, s := range spans {
+		out[i] = span{
+			TraceID:  hex.EncodeToString(s.TraceID[:]),
+			SpanID:   hex.EncodeToString(s.SpanID[:]),
+			Name:     s.Name,
+			Kind:     kindNames[s.Kind],
+			Start:    s.Start,
+			End:      s.End,
+			Duration: s.End.Sub(s.Start),
+			Attrs:    convertAttrs(s.Attrs),
+			Error:    s.Err,
+		}
+		if !s.ParentID.IsZero() {
+			out[i].ParentID = hex.EncodeToString(s.ParentID[:])
+		}
+		for _, ev := range s.Events {
+			out[i].Events = append(out[i].Events, event{
+				Time:  ev.Time,
+				Name:  ev.Name,
+				Attrs: convertAttrs(ev.Attrs),
+			})
+		}
+		for _, l := range s.Links {
+			out[i].Links = append(out[i].Links, link{
+				TraceID: hex.EncodeToString(l.TraceID[:]),
+				SpanID:  hex.EncodeToString(l.SpanID[:]),
+				Attrs:   convertAttrs(l.Attrs),
+			})
+		}
+	}
+	return out
+}
+
+func convertAttrs(attrs []trace.Attr) map[string]any {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(attrs))
+	for _, a := range attrs {
+		m[a.Key] = a.Value
+	}
+	return m
+}
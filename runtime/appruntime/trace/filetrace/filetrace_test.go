@@ -0,0 +1,109 @@
+package filetrace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+func testTrace() []byte {
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1, 2, 3},
+		SpanID:  model.SpanID{4, 5, 6},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Hello"}},
+	}
+	log := &trace.Log{}
+	log.BeginRequest(req, 1)
+	log.DBQueryStart(trace.DBQueryStartParams{Query: "SELECT 1", SpanID: req.SpanID, QueryID: 1})
+	log.DBQueryEnd(1, errors.New("boom"))
+	log.FinishRequest(req, &model.Response{})
+	return log.GetAndClear()
+}
+
+func export(t *testing.T, format string, data []byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	exp, err := New(Config{Dir: dir, Format: format})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exp.ExportTrace(context.Background(), data); err != nil {
+		t.Fatalf("ExportTrace: %v", err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(files) != 1 {
+		t.Fatalf("got files %v, want one file", files)
+	}
+	return files[0]
+}
+
+func TestExport_JSON(t *testing.T) {
+	file := export(t, "", testTrace())
+	if !strings.HasSuffix(file, ".json") || !strings.Contains(file, "01020300000000000000000000000000") {
+		t.Errorf("got file name %q", file)
+	}
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spans []span
+	if err := json.Unmarshal(contents, &spans); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	reqSpan, query := spans[0], spans[1]
+	if reqSpan.TraceID != "01020300000000000000000000000000" || reqSpan.SpanID != "0405060000000000" ||
+		reqSpan.ParentID != "" || reqSpan.Name != "svc.Hello" || reqSpan.Kind != "server" {
+		t.Errorf("got request span %+v", reqSpan)
+	}
+	if query.ParentID != reqSpan.SpanID || query.Kind != "client" || query.Error != "boom" ||
+		query.Attrs["db.statement"] != "SELECT 1" {
+		t.Errorf("got query span %+v", query)
+	}
+}
+
+func TestExport_Binary(t *testing.T) {
+	data := testTrace()
+	file := export(t, FormatBinary, data)
+	if !strings.HasSuffix(file, ".trace") {
+		t.Errorf("got file name %q", file)
+	}
+	spans, err := ReadFile(file)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	want, err := trace.DecodeSpans(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("got %d spans, want %d", len(spans), len(want))
+	}
+	for i := range spans {
+		// Span ids of queries are generated when decoding, and the clock
+		// offset is measured anew for each decode, so compare the rest.
+		got, want := spans[i], want[i]
+		if d := got.Start.Sub(want.Start); d < -time.Millisecond || d > time.Millisecond {
+			t.Errorf("span %d: got start %v, want %v", i, got.Start, want.Start)
+		}
+		if got.ParentID != want.ParentID || got.Name != want.Name || got.Err != want.Err {
+			t.Errorf("span %d: got %+v, want %+v", i, got, want)
+		}
+	}
+	if _, err := Decode([]byte("not a trace")); err == nil {
+		t.Errorf("decoded invalid trace file")
+	}
+}
@@ -0,0 +1,217 @@
+// Package gcptrace exports traces to Google Cloud Trace,
+// using the Cloud Trace API.
+//
+// It authenticates with the application default credentials,
+// such as those of the service account an application runs as:
+//
+//	exp, err := gcptrace.New(ctx, gcptrace.Config{
+//		ProjectID:   "my-project",
+//		ServiceName: "my-app",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	rt.AddTraceExporter(exp)
+package gcptrace
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	cloudtrace "google.golang.org/api/cloudtrace/v2"
+	"google.golang.org/api/option"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+// Limits on the sizes of values imposed by Cloud Trace.
+const (
+	maxNameLen      = 128
+	maxAttrKeyLen   = 128
+	maxAttrValueLen = 256
+	maxAttrs        = 32
+)
+
+// Config configures an Exporter.
+type Config struct {
+	// ProjectID is the id of the Google Cloud project to write traces to.
+	ProjectID string
+
+	// ServiceName is reported as the "service.name" attribute of each span.
+	ServiceName string
+
+	// ResourceAttributes are reported as attributes of each span,
+	// such as "deployment.environment".
+	ResourceAttributes map[string]string
+
+	// ClientOptions are additional options for the Cloud Trace API client,
+	// such as the credentials or endpoint to use.
+	ClientOptions []option.ClientOption
+}
+
+// Exporter exports traces to Cloud Trace.
+// It implements trace.Exporter.
+type Exporter struct {
+	cfg Config
+	svc *cloudtrace.Service
+}
+
+var _ trace.Exporter = (*Exporter)(nil)
+
+// New creates an Exporter that exports to the project configured by cfg.
+func New(ctx context.Context, cfg Config) (*Exporter, error) {
+	if cfg.ProjectID == "" {
+		return nil, errors.New("gcptrace: no project configured")
+	}
+	opts := append([]option.ClientOption{option.WithScopes(cloudtrace.TraceAppendScope)}, cfg.ClientOptions...)
+	svc, err := cloudtrace.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("gcptrace: %v", err)
+	}
+	return &Exporter{cfg: cfg, svc: svc}, nil
+}
+
+// ExportTrace decodes the spans in data and exports them,
+// waiting until they have been exported or ctx is done.
+func (e *Exporter) ExportTrace(ctx context.Context, data []byte) error {
+	decoded, err := trace.DecodeSpans(data)
+	if err != nil {
+		return err
+	} else if len(decoded) == 0 {
+		return nil
+	}
+
+	req := &cloudtrace.BatchWriteSpansRequest{Spans: make([]*cloudtrace.Span, len(decoded))}
+	for i, s := range decoded {
+		req.Spans[i] = e.convertSpan(s)
+	}
+	_, err = e.svc.Projects.Traces.BatchWrite("projects/"+e.cfg.ProjectID, req).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("gcptrace: %v", err)
+	}
+	return nil
+}
+
+// Shutdown does nothing, as the exporter holds no resources
+// beyond idle connections. It exists for symmetry with the other exporters.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (e *Exporter) convertSpan(s trace.Span) *cloudtrace.Span {
+	spanID := hex.EncodeToString(s.SpanID[:])
+	cs := &cloudtrace.Span{
+		Name:        fmt.Sprintf("projects/%s/traces/%s/spans/%s", e.cfg.ProjectID, FormatTraceID(s.TraceID), spanID),
+		SpanId:      spanID,
+		DisplayName: truncatable(s.Name, maxNameLen),
+		StartTime:   s.Start.UTC().Format(time.RFC3339Nano),
+		EndTime:     s.End.UTC().Format(time.RFC3339Nano),
+		SpanKind:    spanKind(s.Kind),
+	}
+	if !s.ParentID.IsZero() {
+		cs.ParentSpanId = hex.EncodeToString(s.ParentID[:])
+	}
+
+	attrs := make([]trace.Attr, 0, len(s.Attrs)+len(e.cfg.ResourceAttributes)+1)
+	if e.cfg.ServiceName != "" {
+		attrs = append(attrs, trace.Attr{Key: "service.name", Value: e.cfg.ServiceName})
+	}
+	for k, v := range e.cfg.ResourceAttributes {
+		attrs = append(attrs, trace.Attr{Key: k, Value: v})
+	}
+	cs.Attributes = convertAttrs(append(attrs, s.Attrs...))
+
+	if s.Err != "" {
+		// The code is that of google.rpc.Code.UNKNOWN, as the
+		// decoded spans do not record the kind of error.
+		cs.Status = &cloudtrace.Status{Code: 2, Message: s.Err}
+	}
+	if len(s.Events) > 0 {
+		cs.TimeEvents = &cloudtrace.TimeEvents{}
+		for _, ev := range s.Events {
+			cs.TimeEvents.TimeEvent = append(cs.TimeEvents.TimeEvent, &cloudtrace.TimeEvent{
+				Time: ev.Time.UTC().Format(time.RFC3339Nano),
+				Annotation: &cloudtrace.Annotation{
+					Description: truncatable(ev.Name, maxAttrValueLen),
+					Attributes:  convertAttrs(ev.Attrs),
+				},
+			})
+		}
+	}
+	if len(s.Links) > 0 {
+		cs.Links = &cloudtrace.Links{}
+		for _, l := range s.Links {
+			cs.Links.Link = append(cs.Links.Link, &cloudtrace.Link{
+				TraceId:    FormatTraceID(l.TraceID),
+				SpanId:     hex.EncodeToString(l.SpanID[:]),
+				Attributes: convertAttrs(l.Attrs),
+			})
+		}
+	}
+	return cs
+}
+
+// convertAttrs converts attrs to Cloud Trace attributes, dropping those
+// beyond the maximum number of attributes Cloud Trace accepts.
+func convertAttrs(attrs []trace.Attr) *cloudtrace.Attributes {
+	if len(attrs) == 0 {
+		return nil
+	}
+	ca := &cloudtrace.Attributes{AttributeMap: make(map[string]cloudtrace.AttributeValue, len(attrs))}
+	for _, a := range attrs {
+		if len(ca.AttributeMap) >= maxAttrs {
+			ca.DroppedAttributesCount++
+			continue
+		}
+		key := a.Key
+		if len(key) > maxAttrKeyLen {
+			key = key[:maxAttrKeyLen]
+		}
+		var v cloudtrace.AttributeValue
+		switch val := a.Value.(type) {
+		case bool:
+			v.BoolValue = val
+		case int64:
+			v.IntValue = val
+		default:
+			v.StringValue = truncatable(fmt.Sprint(val), maxAttrValueLen)
+		}
+		ca.AttributeMap[key] = v
+	}
+	return ca
+}
+
+// truncatable returns s as a truncatable string,
+// truncated to at most maxLen bytes.
+func truncatable(s string, maxLen int) *cloudtrace.TruncatableString {
+	if len(s) <= maxLen {
+		return &cloudtrace.TruncatableString{Value: s}
+	}
+	return &cloudtrace.TruncatableString{Value: s[:maxLen], TruncatedByteCount: int64(len(s) - maxLen)}
+}
+
+// spanKind returns the Cloud Trace span kind of kind.
+func spanKind(kind trace.SpanKind) string {
+	switch kind {
+	case trace.SpanKindServer:
+		return "SERVER"
+	case trace.SpanKindClient:
+		return "CLIENT"
+	case trace.SpanKindProducer:
+		return "PRODUCER"
+	case trace.SpanKindConsumer:
+		return "CONSUMER"
+	default:
+		return "INTERNAL"
+	}
+}
+
+// FormatTraceID formats id in the Cloud Trace trace id format,
+// 32 lowercase hexadecimal characters.
+func FormatTraceID(id model.TraceID) string {
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,70 @@
+package gcptrace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cloudtrace "google.golang.org/api/cloudtrace/v2"
+	"google.golang.org/api/option"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+func TestExport(t *testing.T) {
+	var body cloudtrace.BatchWriteSpansRequest
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1, 2, 3},
+		SpanID:  model.SpanID{4, 5, 6},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Hello"}},
+	}
+	log := &trace.Log{}
+	log.BeginRequest(req, 1)
+	log.DBQueryStart(trace.DBQueryStartParams{Query: "SELECT 1", SpanID: req.SpanID, QueryID: 1})
+	log.DBQueryEnd(1, errors.New("boom"))
+	log.FinishRequest(req, &model.Response{})
+
+	exp, err := New(context.Background(), Config{
+		ProjectID:     "my-project",
+		ServiceName:   "my-app",
+		ClientOptions: []option.ClientOption{option.WithEndpoint(srv.URL + "/"), option.WithoutAuthentication()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exp.ExportTrace(context.Background(), log.GetAndClear()); err != nil {
+		t.Fatalf("ExportTrace: %v", err)
+	}
+
+	if path != "/v2/projects/my-project/traces:batchWrite" {
+		t.Errorf("got request to %q", path)
+	}
+	if len(body.Spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(body.Spans))
+	}
+	reqSpan, query := body.Spans[0], body.Spans[1]
+	if reqSpan.Name != "projects/my-project/traces/01020300000000000000000000000000/spans/0405060000000000" ||
+		reqSpan.ParentSpanId != "" || reqSpan.DisplayName.Value != "svc.Hello" || reqSpan.SpanKind != "SERVER" ||
+		reqSpan.Attributes.AttributeMap["service.name"].StringValue.Value != "my-app" {
+		t.Errorf("got request span %+v", reqSpan)
+	}
+	if query.ParentSpanId != reqSpan.SpanId || query.SpanKind != "CLIENT" || query.Status == nil ||
+		query.Status.Message != "boom" || query.Attributes.AttributeMap["db.statement"].StringValue.Value != "SELECT 1" {
+		t.Errorf("got query span %+v", query)
+	}
+}
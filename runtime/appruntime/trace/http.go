@@ -26,7 +26,11 @@ func (l *Log) HTTPBeginRoundTrip(httpReq *http.Request, req *model.Request, goid
 
 	reqID := atomic.AddUint64(&httpReqIDCtr, 1)
 
-	tb := NewBuffer(8 + 4 + 4 + 4 + len(httpReq.Method) + 128)
+	// Propagate the trace to the callee, with the call as the parent span.
+	httpReq.Header = model.WithTraceContext(httpReq.Header, req, spanID)
+
+	tb := GetBuffer(8 + 4 + 4 + 4 + len(httpReq.Method) + 128)
+	defer PutBuffer(tb)
 	tb.UVarint(reqID)
 	tb.Bytes(req.SpanID[:])
 	tb.Bytes(spanID[:])
@@ -66,7 +70,8 @@ func (l *Log) HTTPCompleteRoundTrip(req *http.Request, resp *http.Response, err
 		return
 	}
 
-	tb := NewBuffer(8 + 4 + 4 + 4)
+	tb := GetBuffer(8 + 4 + 4 + 4)
+	defer PutBuffer(tb)
 	tb.UVarint(rt.ReqID)
 	if err != nil {
 		msg := err.Error()
@@ -79,7 +84,7 @@ func (l *Log) HTTPCompleteRoundTrip(req *http.Request, resp *http.Response, err
 		tb.String("")
 		tb.UVarint(uint64(resp.StatusCode))
 	}
-	rt.encodeEvents(&tb)
+	rt.encodeEvents(tb)
 	rt.log.Add(HTTPCallEnd, tb.Buf())
 
 	if req.Method != "HEAD" && resp != nil {
@@ -181,7 +186,8 @@ func (rt *httpRoundTrip) encodeEvents(tb *Buffer) {
 }
 
 func (rt *httpRoundTrip) ClosedBody(err error) {
-	tb := NewBuffer(8 + 4)
+	tb := GetBuffer(8 + 4)
+	defer PutBuffer(tb)
 	tb.UVarint(rt.ReqID)
 	if err != nil {
 		msg := err.Error()
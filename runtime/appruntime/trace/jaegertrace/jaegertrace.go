@@ -0,0 +1,268 @@
+// Package jaegertrace exports traces to a Jaeger collector,
+// using the Jaeger Thrift format over HTTP.
+//
+// It is an alternative to the otlptrace package for collectors
+// that do not support OTLP:
+//
+//	exp, err := jaegertrace.New(jaegertrace.Config{
+//		Endpoint:    "http://jaeger-collector:14268/api/traces",
+//		ServiceName: "my-app",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	rt.AddTraceExporter(exp)
+package jaegertrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"encore.dev/appruntime/trace"
+)
+
+// Config configures an Exporter.
+type Config struct {
+	// Endpoint is the URL of the collector's Thrift HTTP endpoint,
+	// such as "http://localhost:14268/api/traces".
+	Endpoint string
+
+	// Headers are additional HTTP headers sent with each export,
+	// typically used for authentication.
+	Headers map[string]string
+
+	// ServiceName is reported as the name of the process
+	// the spans belong to.
+	ServiceName string
+
+	// ResourceAttributes are reported as tags of the process,
+	// such as "deployment.environment".
+	ResourceAttributes map[string]string
+
+	// HTTPClient is the client used to export traces.
+	// It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Exporter exports traces to a Jaeger collector.
+// It implements trace.Exporter.
+type Exporter struct {
+	cfg Config
+}
+
+var _ trace.Exporter = (*Exporter)(nil)
+
+// New creates an Exporter that exports to the collector configured by cfg.
+func New(cfg Config) (*Exporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("jaegertrace: no endpoint configured")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &Exporter{cfg: cfg}, nil
+}
+
+// ExportTrace decodes the spans in data and exports them,
+// waiting until they have been exported or ctx is done.
+func (e *Exporter) ExportTrace(ctx context.Context, data []byte) error {
+	spans, err := trace.DecodeSpans(data)
+	if err != nil {
+		return err
+	} else if len(spans) == 0 {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.cfg.Endpoint, bytes.NewReader(e.encodeBatch(spans)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-thrift")
+	for k, v := range e.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("jaegertrace: collector responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Shutdown does nothing, as the exporter holds no resources.
+// It exists for symmetry with the other exporters.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// encodeBatch encodes spans as a Jaeger Batch.
+func (e *Exporter) encodeBatch(spans []trace.Span) []byte {
+	w := &thriftWriter{}
+
+	// Batch.process
+	w.field(thriftStruct, 1)
+	w.field(thriftString, 1) // Process.serviceName
+	w.string(e.cfg.ServiceName)
+	if len(e.cfg.ResourceAttributes) > 0 {
+		keys := make([]string, 0, len(e.cfg.ResourceAttributes))
+		for k := range e.cfg.ResourceAttributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.field(thriftList, 2) // Process.tags
+		w.list(thriftStruct, len(keys))
+		for _, k := range keys {
+			writeTag(w, trace.Attr{Key: k, Value: e.cfg.ResourceAttributes[k]})
+		}
+	}
+	w.stop()
+
+	// Batch.spans
+	w.field(thriftList, 2)
+	w.list(thriftStruct, len(spans))
+	for _, s := range spans {
+		writeSpan(w, s)
+	}
+
+	w.stop()
+	return w.buf
+}
+
+func writeSpan(w *thriftWriter, s trace.Span) {
+	traceHigh := int64(binary.BigEndian.Uint64(s.TraceID[:8]))
+	traceLow := int64(binary.BigEndian.Uint64(s.TraceID[8:]))
+	spanID := int64(binary.BigEndian.Uint64(s.SpanID[:]))
+	parentID := int64(binary.BigEndian.Uint64(s.ParentID[:]))
+
+	w.field(thriftI64, 1)
+	w.i64(traceLow)
+	w.field(thriftI64, 2)
+	w.i64(traceHigh)
+	w.field(thriftI64, 3)
+	w.i64(spanID)
+	w.field(thriftI64, 4)
+	w.i64(parentID)
+	w.field(thriftString, 5)
+	w.string(s.Name)
+	if len(s.Links) > 0 {
+		// Jaeger has no link attributes, so only the linked spans are exported.
+		w.field(thriftList, 6) // references
+		w.list(thriftStruct, len(s.Links))
+		for _, l := range s.Links {
+			w.field(thriftI32, 1)
+			w.i32(refFollowsFrom)
+			w.field(thriftI64, 2)
+			w.i64(int64(binary.BigEndian.Uint64(l.TraceID[8:])))
+			w.field(thriftI64, 3)
+			w.i64(int64(binary.BigEndian.Uint64(l.TraceID[:8])))
+			w.field(thriftI64, 4)
+			w.i64(int64(binary.BigEndian.Uint64(l.SpanID[:])))
+			w.stop()
+		}
+	}
+	w.field(thriftI32, 7) // flags
+	w.i32(1)              // sampled
+	w.field(thriftI64, 8)
+	w.i64(s.Start.UnixNano() / 1000)
+	w.field(thriftI64, 9)
+	w.i64(s.End.Sub(s.Start).Microseconds())
+
+	tags := s.Attrs
+	if kind := spanKind(s.Kind); kind != "" {
+		tags = append(tags[:len(tags):len(tags)], trace.Attr{Key: "span.kind", Value: kind})
+	}
+	if s.Err != "" {
+		tags = append(tags[:len(tags):len(tags)],
+			trace.Attr{Key: "error", Value: true},
+			trace.Attr{Key: "otel.status_description", Value: s.Err})
+	}
+	w.field(thriftList, 10)
+	w.list(thriftStruct, len(tags))
+	for _, a := range tags {
+		writeTag(w, a)
+	}
+
+	if len(s.Events) > 0 {
+		w.field(thriftList, 11)
+		w.list(thriftStruct, len(s.Events))
+		for _, ev := range s.Events {
+			w.field(thriftI64, 1) // Log.timestamp
+			w.i64(ev.Time.UnixNano() / 1000)
+			w.field(thriftList, 2) // Log.fields
+			w.list(thriftStruct, len(ev.Attrs)+1)
+			writeTag(w, trace.Attr{Key: "event", Value: ev.Name})
+			for _, a := range ev.Attrs {
+				writeTag(w, a)
+			}
+			w.stop()
+		}
+	}
+	w.stop()
+}
+
+// refFollowsFrom is the Jaeger span reference type used for links.
+const refFollowsFrom int32 = 1
+
+// Jaeger tag types.
+const (
+	tagString int32 = 0
+	tagDouble int32 = 1
+	tagBool   int32 = 2
+	tagLong   int32 = 3
+)
+
+func writeTag(w *thriftWriter, a trace.Attr) {
+	w.field(thriftString, 1)
+	w.string(a.Key)
+	switch v := a.Value.(type) {
+	case bool:
+		w.field(thriftI32, 2)
+		w.i32(tagBool)
+		w.field(thriftBool, 5)
+		w.bool(v)
+	case int64:
+		w.field(thriftI32, 2)
+		w.i32(tagLong)
+		w.field(thriftI64, 6)
+		w.i64(v)
+	case float64:
+		w.field(thriftI32, 2)
+		w.i32(tagDouble)
+		w.field(thriftDouble, 4)
+		w.double(v)
+	default:
+		str, _ := v.(string)
+		w.field(thriftI32, 2)
+		w.i32(tagString)
+		w.field(thriftString, 3)
+		w.string(str)
+	}
+	w.stop()
+}
+
+// spanKind returns the OpenTracing span kind of kind,
+// or "" for internal spans.
+func spanKind(kind trace.SpanKind) string {
+	switch kind {
+	case trace.SpanKindServer:
+		return "server"
+	case trace.SpanKindClient:
+		return "client"
+	case trace.SpanKindProducer:
+		return "producer"
+	case trace.SpanKindConsumer:
+		return "consumer"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,72 @@
+package jaegertrace
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+func TestWriteTag(t *testing.T) {
+	w := &thriftWriter{}
+	writeTag(w, trace.Attr{Key: "k", Value: int64(5)})
+	want := []byte{
+		thriftString, 0, 1, 0, 0, 0, 1, 'k', // key
+		thriftI32, 0, 2, 0, 0, 0, 3, // vType: LONG
+		thriftI64, 0, 6, 0, 0, 0, 0, 0, 0, 0, 5, // vLong
+		0, // stop
+	}
+	if !bytes.Equal(w.buf, want) {
+		t.Errorf("got %v, want %v", w.buf, want)
+	}
+}
+
+func TestExport(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-thrift" {
+			t.Errorf("got content type %q", ct)
+		}
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1, 2, 3},
+		SpanID:  model.SpanID{4, 5, 6},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Hello"}},
+	}
+	log := &trace.Log{}
+	log.BeginRequest(req, 1)
+	log.FinishRequest(req, &model.Response{})
+
+	exp, err := New(Config{Endpoint: srv.URL, ServiceName: "my-app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exp.ExportTrace(context.Background(), log.GetAndClear()); err != nil {
+		t.Fatalf("ExportTrace: %v", err)
+	}
+
+	// The batch starts with the process, followed by the list of one span
+	// whose trace id (low and high) and span id are encoded first.
+	process := []byte{thriftStruct, 0, 1, thriftString, 0, 1, 0, 0, 0, 6, 'm', 'y', '-', 'a', 'p', 'p', 0}
+	spans := []byte{
+		thriftList, 0, 2, thriftStruct, 0, 0, 0, 1,
+		thriftI64, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0,
+		thriftI64, 0, 2, 1, 2, 3, 0, 0, 0, 0, 0,
+		thriftI64, 0, 3, 4, 5, 6, 0, 0, 0, 0, 0,
+	}
+	if !bytes.HasPrefix(body, append(process, spans...)) {
+		t.Errorf("got unexpected batch %v", body)
+	}
+	if !bytes.Contains(body, []byte("svc.Hello")) {
+		t.Errorf("batch does not contain the operation name")
+	}
+}
@@ -0,0 +1,67 @@
+package jaegertrace
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Thrift binary protocol type ids.
+const (
+	thriftBool   byte = 2
+	thriftDouble byte = 4
+	thriftI32    byte = 8
+	thriftI64    byte = 10
+	thriftString byte = 11
+	thriftStruct byte = 12
+	thriftList   byte = 15
+)
+
+// thriftWriter encodes values using the Thrift binary protocol,
+// as expected by the Jaeger collector's HTTP endpoint.
+type thriftWriter struct {
+	buf []byte
+}
+
+func (w *thriftWriter) field(typ byte, id int16) {
+	w.buf = append(w.buf, typ, byte(id>>8), byte(id))
+}
+
+// stop ends the current struct.
+func (w *thriftWriter) stop() {
+	w.buf = append(w.buf, 0)
+}
+
+// list begins a list of n elements of type typ.
+func (w *thriftWriter) list(typ byte, n int) {
+	w.buf = append(w.buf, typ)
+	w.i32(int32(n))
+}
+
+func (w *thriftWriter) bool(b bool) {
+	if b {
+		w.buf = append(w.buf, 1)
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+func (w *thriftWriter) i32(x int32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(x))
+	w.buf = append(w.buf, b[:]...)
+}
+
+func (w *thriftWriter) i64(x int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(x))
+	w.buf = append(w.buf, b[:]...)
+}
+
+func (w *thriftWriter) double(f float64) {
+	w.i64(int64(math.Float64bits(f)))
+}
+
+func (w *thriftWriter) string(s string) {
+	w.i32(int32(len(s)))
+	w.buf = append(w.buf, s...)
+}
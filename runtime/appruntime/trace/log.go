@@ -2,6 +2,7 @@ package trace
 
 import (
 	"math"
+	"sync"
 	"time"
 	_ "unsafe" // for go:linkname
 
@@ -15,9 +16,21 @@ type Factory interface {
 // DefaultFactory is a Factory that creates regular trace logs.
 var DefaultFactory = &defaultFactory{}
 
-type defaultFactory struct{}
+// NewFactory returns a Factory that creates regular trace logs
+// which redact the payloads they record with redactor,
+// and whose size is bounded by limits.
+func NewFactory(redactor *PayloadRedactor, limits Limits) Factory {
+	return &defaultFactory{redactor: redactor, limits: limits}
+}
 
-func (*defaultFactory) NewLogger() Logger { return &Log{} }
+type defaultFactory struct {
+	redactor *PayloadRedactor
+	limits   Limits
+}
+
+func (f *defaultFactory) NewLogger() Logger {
+	return &Log{redactor: f.redactor, maxSize: f.limits.MaxSize, policy: f.limits.Policy}
+}
 
 type Log struct {
 	// mu must be the runtime mutex and not a regular sync.Mutex,
@@ -26,6 +39,20 @@ type Log struct {
 	mu mutex
 
 	data []byte
+
+	// maxSize is the maximum number of bytes buffered in data,
+	// beyond which events other than request starts and ends
+	// are dropped according to policy. It is unlimited if zero.
+	maxSize int
+	policy  TruncationPolicy
+
+	// dropped records the events dropped since data was last cleared,
+	// which are reported by a TraceTruncated event.
+	dropped truncation
+
+	// redactor redacts sensitive fields from recorded payloads.
+	// It is nil if payloads are recorded as is.
+	redactor *PayloadRedactor
 }
 
 // Ensure Log implements Logger.
@@ -46,6 +73,14 @@ func (l *Log) Add(event EventType, data []byte) {
 	mutexLock(&l.mu)
 	defer mutexUnlock(&l.mu)
 
+	if l.maxSize > 0 && len(l.data)+13+ln > l.maxSize && !alwaysKept(event) {
+		// Keep the request boundaries so that the trace remains well-formed.
+		if l.policy != TruncateDropOldest || !l.dropOldest(13+ln) {
+			l.dropped.add(13 + ln)
+			return
+		}
+	}
+
 	// Do this in the critical section to ensure we don't get
 	// out-of-order timestamps.
 	t := nanotime()
@@ -63,12 +98,45 @@ func (l *Log) Add(event EventType, data []byte) {
 	b[10] = byte(ln >> 8)
 	b[11] = byte(ln >> 16)
 	b[12] = byte(ln >> 24)
-	l.data = append(l.data, append(b[:], data...)...)
+	l.data = append(append(l.data, b[:]...), data...)
+}
+
+// SetMaxSize sets the maximum number of bytes buffered by l,
+// beyond which events other than request starts and ends are dropped
+// according to l's truncation policy until the buffer is cleared with
+// GetAndClear. Zero means no limit.
+func (l *Log) SetMaxSize(n int) {
+	mutexLock(&l.mu)
+	l.maxSize = n
+	mutexUnlock(&l.mu)
+}
+
+// MaxSize returns the maximum number of bytes buffered by l,
+// or zero if there is no limit.
+func (l *Log) MaxSize() int {
+	mutexLock(&l.mu)
+	n := l.maxSize
+	mutexUnlock(&l.mu)
+	return n
+}
+
+// Len returns the number of bytes buffered by l.
+func (l *Log) Len() int {
+	mutexLock(&l.mu)
+	n := len(l.data)
+	mutexUnlock(&l.mu)
+	return n
 }
 
 // GetAndClear gets the data and clears the buffer.
+// If events were dropped since the buffer was last cleared,
+// the data ends with a TraceTruncated event reporting them.
 func (l *Log) GetAndClear() []byte {
 	mutexLock(&l.mu)
+	if l.dropped.events > 0 {
+		l.data = l.dropped.appendEvent(l.data, l.policy)
+		l.dropped = truncation{}
+	}
 	data := l.data
 	l.data = l.data[len(l.data):]
 	mutexUnlock(&l.mu)
@@ -89,6 +157,39 @@ func NewBuffer(size int) Buffer {
 	return Buffer{buf: make([]byte, 0, size)}
 }
 
+// maxPooledBufferSize is the capacity above which buffers
+// are not returned to the pool, so that the occasional large
+// event does not keep large buffers alive.
+const maxPooledBufferSize = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(Buffer) },
+}
+
+// GetBuffer returns an empty buffer from a pool of buffers,
+// with a capacity of at least size. It avoids allocating a new
+// buffer for each event on hot paths.
+//
+// The buffer must be returned with PutBuffer once its contents are
+// no longer used, such as after they have been passed to Logger.Add.
+func GetBuffer(size int) *Buffer {
+	tb := bufferPool.Get().(*Buffer)
+	if cap(tb.buf) < size {
+		tb.buf = make([]byte, 0, size)
+	}
+	return tb
+}
+
+// PutBuffer returns tb, as returned by GetBuffer, to the pool.
+// Neither tb nor its contents may be used after it is returned.
+func PutBuffer(tb *Buffer) {
+	if cap(tb.buf) > maxPooledBufferSize {
+		return
+	}
+	tb.buf = tb.buf[:0]
+	bufferPool.Put(tb)
+}
+
 func (tb *Buffer) Buf() []byte {
 	return tb.buf
 }
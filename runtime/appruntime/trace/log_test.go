@@ -0,0 +1,103 @@
+package trace
+
+import (
+	"bytes"
+	"testing"
+
+	"encore.dev/appruntime/model"
+)
+
+func TestBufferPool(t *testing.T) {
+	tb := GetBuffer(16)
+	if len(tb.Buf()) != 0 || cap(tb.Buf()) < 16 {
+		t.Fatalf("got buffer with len %d and cap %d", len(tb.Buf()), cap(tb.Buf()))
+	}
+	tb.String("hello")
+
+	var l Log
+	l.Add(LogMessage, tb.Buf())
+	PutBuffer(tb)
+
+	// Reusing the buffer must not affect the recorded event.
+	tb = GetBuffer(16)
+	if len(tb.Buf()) != 0 {
+		t.Errorf("got non-empty buffer from the pool")
+	}
+	tb.String("world")
+	PutBuffer(tb)
+	if data := l.GetAndClear(); !bytes.HasSuffix(data, []byte("hello")) {
+		t.Errorf("got event data %q", data)
+	}
+
+}
+
+func BenchmarkLogAdd(b *testing.B) {
+	var l Log
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tb := GetBuffer(64)
+		tb.UVarint(uint64(i))
+		tb.String("message")
+		l.Add(LogMessage, tb.Buf())
+		PutBuffer(tb)
+		if i%1024 == 0 {
+			l.GetAndClear()
+		}
+	}
+}
+
+func TestLogMaxSize(t *testing.T) {
+	var l Log
+	l.SetMaxSize(40)
+	l.Add(LogMessage, make([]byte, 20))
+	l.Add(LogMessage, make([]byte, 20)) // dropped
+	l.Add(RequestEnd, make([]byte, 20)) // kept regardless
+	if got, want := l.Len(), 2*(13+20); got != want {
+		t.Errorf("got %d bytes buffered, want %d", got, want)
+	}
+	l.GetAndClear()
+	l.Add(LogMessage, make([]byte, 20))
+	if got, want := l.Len(), 13+20; got != want {
+		t.Errorf("got %d bytes buffered after clearing, want %d", got, want)
+	}
+}
+
+func TestLogTruncation(t *testing.T) {
+	req := &model.Request{Type: model.Test, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	for _, policy := range []TruncationPolicy{TruncateStop, TruncateDropOldest} {
+		t.Run(policy.String(), func(t *testing.T) {
+			l := NewFactory(nil, Limits{MaxSize: 200, Policy: policy}).NewLogger().(*Log)
+			l.BeginRequest(req, 0)
+			for i := 0; i < 20; i++ {
+				l.Add(LogMessage, make([]byte, 20))
+			}
+			l.FinishRequest(req, &model.Response{})
+			if n := l.Len(); n > 200+13+64 {
+				t.Errorf("got %d bytes buffered, want at most the maximum plus the request end", n)
+			}
+
+			data := l.GetAndClear()
+			spans, err := DecodeSpans(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want the request span", len(spans))
+			}
+			s := spans[0]
+			if len(s.Events) != 1 || s.Events[0].Name != "trace truncated" {
+				t.Fatalf("got events %+v, want a truncation event", s.Events)
+			}
+			attrs := s.Events[0].Attrs
+			if attrs[0].Value != policy.String() || attrs[1].Value.(int64) == 0 {
+				t.Errorf("got truncation attributes %+v", attrs)
+			}
+
+			// The truncation is only reported once.
+			l.Add(LogMessage, make([]byte, 20))
+			if data := l.GetAndClear(); len(data) != 13+20 {
+				t.Errorf("got %d bytes after clearing, want a single event", len(data))
+			}
+		})
+	}
+}
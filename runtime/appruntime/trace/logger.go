@@ -10,6 +10,8 @@ import (
 //go:generate mockgen -source=./logger.go -destination ./mock_trace/mock_trace.go Logger
 
 type Logger interface {
+	// Add adds an event to the trace. It must not retain data
+	// after it returns, as callers reuse it for other events.
 	Add(event EventType, data []byte)
 	GetAndClear() []byte
 	BeginRequest(req *model.Request, goid uint32)
@@ -27,6 +29,7 @@ type Logger interface {
 	GoStart(spanID model.SpanID, goctr uint32)
 	GoClear(spanID model.SpanID, goctr uint32)
 	GoEnd(spanID model.SpanID, goctr uint32)
+	SpanLink(spanID model.SpanID, goctr uint32, traceID model.TraceID, linkedSpanID model.SpanID)
 	ServiceInitStart(p ServiceInitStartParams)
 	ServiceInitEnd(initCtr uint64, err error)
 	CacheOpStart(p CacheOpStartParams)
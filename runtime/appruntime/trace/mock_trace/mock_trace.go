@@ -329,3 +329,15 @@ func (mr *MockLoggerMockRecorder) ServiceInitStart(p interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceInitStart", reflect.TypeOf((*MockLogger)(nil).ServiceInitStart), p)
 }
+
+// SpanLink mocks base method.
+func (m *MockLogger) SpanLink(spanID model.SpanID, goctr uint32, traceID model.TraceID, linkedSpanID model.SpanID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SpanLink", spanID, goctr, traceID, linkedSpanID)
+}
+
+// SpanLink indicates an expected call of SpanLink.
+func (mr *MockLoggerMockRecorder) SpanLink(spanID, goctr, traceID, linkedSpanID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpanLink", reflect.TypeOf((*MockLogger)(nil).SpanLink), spanID, goctr, traceID, linkedSpanID)
+}
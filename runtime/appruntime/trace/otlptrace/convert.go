@@ -0,0 +1,89 @@
+package otlptrace
+
+import (
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+
+	"encore.dev/appruntime/trace"
+)
+
+// convertSpan converts a decoded span to an OTLP span.
+func convertSpan(s trace.Span) *tracepb.Span {
+	span := &tracepb.Span{
+		TraceId:           append([]byte(nil), s.TraceID[:]...),
+		SpanId:            append([]byte(nil), s.SpanID[:]...),
+		Name:              s.Name,
+		Kind:              spanKind(s.Kind),
+		StartTimeUnixNano: uint64(s.Start.UnixNano()),
+		EndTimeUnixNano:   uint64(s.End.UnixNano()),
+		Attributes:        attributes(s.Attrs),
+		Status:            &tracepb.Status{Code: tracepb.Status_STATUS_CODE_OK},
+	}
+	if !s.ParentID.IsZero() {
+		span.ParentSpanId = append([]byte(nil), s.ParentID[:]...)
+	}
+	if s.Err != "" {
+		span.Status = &tracepb.Status{Code: tracepb.Status_STATUS_CODE_ERROR, Message: s.Err}
+	}
+	for _, ev := range s.Events {
+		span.Events = append(span.Events, &tracepb.Span_Event{
+			TimeUnixNano: uint64(ev.Time.UnixNano()),
+			Name:         ev.Name,
+			Attributes:   attributes(ev.Attrs),
+		})
+	}
+	for _, l := range s.Links {
+		span.Links = append(span.Links, &tracepb.Span_Link{
+			TraceId:    append([]byte(nil), l.TraceID[:]...),
+			SpanId:     append([]byte(nil), l.SpanID[:]...),
+			Attributes: attributes(l.Attrs),
+		})
+	}
+	return span
+}
+
+// spanKind maps a span kind to the corresponding OTLP span kind.
+func spanKind(kind trace.SpanKind) tracepb.Span_SpanKind {
+	switch kind {
+	case trace.SpanKindInternal:
+		return tracepb.Span_SPAN_KIND_INTERNAL
+	case trace.SpanKindServer:
+		return tracepb.Span_SPAN_KIND_SERVER
+	case trace.SpanKindClient:
+		return tracepb.Span_SPAN_KIND_CLIENT
+	case trace.SpanKindProducer:
+		return tracepb.Span_SPAN_KIND_PRODUCER
+	case trace.SpanKindConsumer:
+		return tracepb.Span_SPAN_KIND_CONSUMER
+	default:
+		return tracepb.Span_SPAN_KIND_UNSPECIFIED
+	}
+}
+
+func attributes(attrs []trace.Attr) []*commonpb.KeyValue {
+	kvs := make([]*commonpb.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		var val *commonpb.AnyValue
+		switch v := a.Value.(type) {
+		case string:
+			val = &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+		case bool:
+			val = &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+		case int64:
+			val = &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+		case float64:
+			val = &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+		default:
+			val = &commonpb.AnyValue{}
+		}
+		kvs = append(kvs, &commonpb.KeyValue{Key: a.Key, Value: val})
+	}
+	return kvs
+}
+
+func keyValue(key, val string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: val}},
+	}
+}
@@ -0,0 +1,281 @@
+// Package otlptrace exports traces to an OpenTelemetry collector
+// using the OTLP protocol, over either gRPC or HTTP.
+//
+// The exporter decodes the trace data recorded by Encore into spans,
+// so that applications can be traced using systems such as Jaeger,
+// Tempo or Honeycomb without the Encore platform:
+//
+//	exp, err := otlptrace.New(otlptrace.Config{
+//		Endpoint:    "otel-collector:4317",
+//		Insecure:    true,
+//		ServiceName: "my-app",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	rt.AddTraceExporter(exp)
+package otlptrace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"encore.dev/appruntime/trace"
+)
+
+// The supported values of Config.Protocol.
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+)
+
+// Config configures an Exporter.
+type Config struct {
+	// Endpoint is the address of the collector. For the gRPC protocol
+	// it is a host and port, such as "localhost:4317", and for the HTTP
+	// protocol the URL to post traces to, such as "http://localhost:4318/v1/traces".
+	Endpoint string
+
+	// Protocol is the OTLP transport, ProtocolGRPC or ProtocolHTTP.
+	// It defaults to ProtocolGRPC.
+	Protocol string
+
+	// Insecure disables transport security for the gRPC connection
+	// to the collector. For HTTP, the scheme of Endpoint is used instead.
+	Insecure bool
+
+	// Headers are additional gRPC metadata or HTTP headers sent
+	// with each export, typically used for authentication.
+	Headers map[string]string
+
+	// ServiceName is reported as the "service.name" resource attribute.
+	ServiceName string
+
+	// ResourceAttributes are additional attributes of the resource
+	// the spans are reported for, such as "deployment.environment".
+	ResourceAttributes map[string]string
+
+	// MaxRetries is the maximum number of times a failed export is retried,
+	// with exponential backoff, before its spans are dropped.
+	// Only errors the collector reports as temporary are retried.
+	// It defaults to 3; a negative value disables retries.
+	MaxRetries int
+
+	// DialOptions are additional options for connecting to a gRPC collector.
+	DialOptions []grpc.DialOption
+
+	// HTTPClient is the client used to export to an HTTP collector.
+	// It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+const (
+	defaultMaxRetries = 3
+
+	initialBackoff = 100 * time.Millisecond
+	maxBackoff     = 2 * time.Second
+)
+
+// Exporter exports traces to an OpenTelemetry collector.
+// It implements trace.Exporter.
+type Exporter struct {
+	cfg      Config
+	resource *resourcepb.Resource
+
+	// Set for the gRPC protocol.
+	conn   *grpc.ClientConn
+	client coltracepb.TraceServiceClient
+	md     metadata.MD
+}
+
+var _ trace.Exporter = (*Exporter)(nil)
+
+// New creates an Exporter that exports to the collector configured by cfg.
+// For gRPC, it connects to the collector in the background.
+func New(cfg Config) (*Exporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("otlptrace: no endpoint configured")
+	}
+	if cfg.Protocol == "" {
+		cfg.Protocol = ProtocolGRPC
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+
+	e := &Exporter{
+		cfg:      cfg,
+		resource: newResource(cfg.ServiceName, cfg.ResourceAttributes),
+	}
+	switch cfg.Protocol {
+	case ProtocolGRPC:
+		creds := insecure.NewCredentials()
+		if !cfg.Insecure {
+			creds = credentials.NewClientTLSFromCert(nil, "")
+		}
+		opts := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, cfg.DialOptions...)
+		conn, err := grpc.Dial(cfg.Endpoint, opts...)
+		if err != nil {
+			return nil, err
+		}
+		e.conn = conn
+		e.client = coltracepb.NewTraceServiceClient(conn)
+		e.md = metadata.New(cfg.Headers)
+	case ProtocolHTTP:
+	default:
+		return nil, fmt.Errorf("otlptrace: unknown protocol %q", cfg.Protocol)
+	}
+	return e, nil
+}
+
+// ExportTrace decodes the spans in data and exports them,
+// waiting until they have been exported or ctx is done.
+func (e *Exporter) ExportTrace(ctx context.Context, data []byte) error {
+	decoded, err := trace.DecodeSpans(data)
+	if err != nil {
+		return err
+	} else if len(decoded) == 0 {
+		return nil
+	}
+
+	spans := make([]*tracepb.Span, len(decoded))
+	for i, s := range decoded {
+		spans[i] = convertSpan(s)
+	}
+	req := &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{{
+			Resource: e.resource,
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Scope: &commonpb.InstrumentationScope{Name: "encore.dev"},
+				Spans: spans,
+			}},
+		}},
+	}
+
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		err := e.export(ctx, req)
+		if err == nil {
+			return nil
+		} else if !retryable(err) || attempt >= e.cfg.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// Shutdown closes the connection to the collector, if any.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	if e.conn != nil {
+		return e.conn.Close()
+	}
+	return nil
+}
+
+func (e *Exporter) export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	if e.client != nil {
+		_, err := e.client.Export(metadata.NewOutgoingContext(ctx, e.md), req)
+		return err
+	}
+
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", e.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range e.cfg.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	resp, err := e.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &httpError{status: resp.StatusCode}
+	}
+	return nil
+}
+
+// httpError is an export error reported by an HTTP collector.
+type httpError struct {
+	status int
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("otlptrace: collector responded with status %d", e.status)
+}
+
+// retryable reports whether a failed export should be retried,
+// as defined by the OTLP specification.
+func retryable(err error) bool {
+	var httpErr *httpError
+	if errors.As(err, &httpErr) {
+		switch httpErr.status {
+		case http.StatusTooManyRequests, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// newResource returns the resource describing the exporting service.
+func newResource(serviceName string, attrs map[string]string) *resourcepb.Resource {
+	res := &resourcepb.Resource{}
+	if serviceName != "" {
+		res.Attributes = append(res.Attributes, keyValue("service.name", serviceName))
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		res.Attributes = append(res.Attributes, keyValue(k, attrs[k]))
+	}
+	return res
+}
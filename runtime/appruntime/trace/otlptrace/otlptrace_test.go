@@ -0,0 +1,173 @@
+package otlptrace
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+type fakeCollector struct {
+	coltracepb.UnimplementedTraceServiceServer
+
+	mu       sync.Mutex
+	failures int // number of requests to fail with Unavailable
+	requests []*coltracepb.ExportTraceServiceRequest
+}
+
+func (c *fakeCollector) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	c.requests = append(c.requests, req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *fakeCollector) spans() []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var spans []*tracepb.Span
+	for _, req := range c.requests {
+		spans = append(spans, req.ResourceSpans[0].ScopeSpans[0].Spans...)
+	}
+	return spans
+}
+
+// recordTrace records a traced request with a database query and a log message.
+func recordTrace(t *testing.T) (data []byte, req *model.Request) {
+	t.Helper()
+	req = &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1, 2, 3},
+		SpanID:  model.SpanID{4, 5, 6},
+		RPCData: &model.RPCData{
+			Desc:       &model.RPCDesc{Service: "svc", Endpoint: "Hello"},
+			HTTPMethod: "GET",
+			Path:       "/hello",
+		},
+	}
+	log := &trace.Log{}
+	log.BeginRequest(req, 1)
+	log.DBQueryStart(trace.DBQueryStartParams{Query: "SELECT 1", SpanID: req.SpanID, QueryID: 1})
+	log.DBQueryEnd(1, errors.New("boom"))
+
+	var tb trace.Buffer
+	tb.Bytes(req.SpanID[:])
+	tb.UVarint(1)      // goctr
+	tb.Byte(2)         // info level
+	tb.String("hi")    // message
+	tb.UVarint(1)      // number of fields
+	tb.Byte(2)         // string field
+	tb.String("key")   // field key
+	tb.String("value") // field value
+	tb.Byte(0)         // no stack
+	log.Add(trace.LogMessage, tb.Buf())
+
+	log.FinishRequest(req, &model.Response{})
+	return log.GetAndClear(), req
+}
+
+func checkSpans(t *testing.T, spans []*tracepb.Span, req *model.Request) {
+	t.Helper()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	reqSpan, query := spans[0], spans[1]
+	if reqSpan.Name != "svc.Hello" || reqSpan.Kind != tracepb.Span_SPAN_KIND_SERVER ||
+		string(reqSpan.TraceId) != string(req.TraceID[:]) || string(reqSpan.SpanId) != string(req.SpanID[:]) ||
+		reqSpan.Status.Code != tracepb.Status_STATUS_CODE_OK {
+		t.Errorf("got request span %v", reqSpan)
+	}
+	if len(reqSpan.Events) != 1 || reqSpan.Events[0].Name != "log" || len(reqSpan.Events[0].Attributes) != 3 ||
+		reqSpan.Events[0].Attributes[2].Value.GetStringValue() != "value" {
+		t.Errorf("got request span events %v", reqSpan.Events)
+	}
+	if query.Name != "db.query" || query.Kind != tracepb.Span_SPAN_KIND_CLIENT ||
+		string(query.TraceId) != string(req.TraceID[:]) || string(query.ParentSpanId) != string(req.SpanID[:]) ||
+		query.Status.Code != tracepb.Status_STATUS_CODE_ERROR || query.Status.Message != "boom" {
+		t.Errorf("got query span %v", query)
+	}
+	if query.StartTimeUnixNano < reqSpan.StartTimeUnixNano || query.EndTimeUnixNano > reqSpan.EndTimeUnixNano {
+		t.Errorf("query span [%d, %d] not within request span [%d, %d]",
+			query.StartTimeUnixNano, query.EndTimeUnixNano, reqSpan.StartTimeUnixNano, reqSpan.EndTimeUnixNano)
+	}
+}
+
+func TestExportGRPC(t *testing.T) {
+	collector := &fakeCollector{failures: 1}
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	coltracepb.RegisterTraceServiceServer(srv, collector)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	exp, err := New(Config{
+		Endpoint:    "bufconn",
+		Insecure:    true,
+		ServiceName: "my-app",
+		DialOptions: []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	data, req := recordTrace(t)
+	if err := exp.ExportTrace(context.Background(), data); err != nil {
+		t.Fatalf("ExportTrace: %v", err)
+	}
+	checkSpans(t, collector.spans(), req)
+	if res := collector.requests[0].ResourceSpans[0].Resource; res.Attributes[0].Value.GetStringValue() != "my-app" {
+		t.Errorf("got resource %v", res)
+	}
+}
+
+func TestExportHTTP(t *testing.T) {
+	var spans []*tracepb.Span
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req coltracepb.ExportTraceServiceRequest
+		if r.Header.Get("Content-Type") != "application/x-protobuf" || r.Header.Get("Authorization") != "secret" {
+			t.Errorf("got headers %v", r.Header)
+		} else if err := proto.Unmarshal(body, &req); err != nil {
+			t.Errorf("unmarshal request: %v", err)
+		} else {
+			spans = append(spans, req.ResourceSpans[0].ScopeSpans[0].Spans...)
+		}
+	}))
+	defer srv.Close()
+
+	exp, err := New(Config{
+		Endpoint: srv.URL + "/v1/traces",
+		Protocol: ProtocolHTTP,
+		Headers:  map[string]string{"Authorization": "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, req := recordTrace(t)
+	if err := exp.ExportTrace(context.Background(), data); err != nil {
+		t.Fatalf("ExportTrace: %v", err)
+	}
+	checkSpans(t, spans, req)
+}
@@ -0,0 +1,45 @@
+package propagation
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"encore.dev/appruntime/model"
+)
+
+// CloudTraceHeader is the header Cloud Trace propagates trace context in,
+// which Google Cloud load balancers and services add to the requests
+// they forward.
+const CloudTraceHeader = "X-Cloud-Trace-Context"
+
+// FormatCloudTraceHeader formats a Cloud Trace header value for the given
+// trace id and parent span id, such as
+// "105445aa7843bc8bf206b12000100000/1;o=1". Unlike elsewhere,
+// the span id is formatted as an unsigned decimal number.
+func FormatCloudTraceHeader(traceID model.TraceID, parentID model.SpanID, sampled bool) string {
+	s := hex.EncodeToString(traceID[:]) + "/" + strconv.FormatUint(binary.BigEndian.Uint64(parentID[:]), 10)
+	if sampled {
+		return s + ";o=1"
+	}
+	return s + ";o=0"
+}
+
+// ParseCloudTraceHeader parses a Cloud Trace header value.
+// It reports false if s has no valid trace id.
+// The parent span id is zero if s has none.
+func ParseCloudTraceHeader(s string) (traceID model.TraceID, parentID model.SpanID, sampled bool, ok bool) {
+	s, options, _ := strings.Cut(strings.TrimSpace(s), ";")
+	traceStr, spanStr, _ := strings.Cut(s, "/")
+	if len(traceStr) != 32 {
+		return model.TraceID{}, model.SpanID{}, false, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(traceStr)); err != nil || traceID.IsZero() {
+		return model.TraceID{}, model.SpanID{}, false, false
+	}
+	if n, err := strconv.ParseUint(spanStr, 10, 64); err == nil {
+		binary.BigEndian.PutUint64(parentID[:], n)
+	}
+	return traceID, parentID, options == "o=1", true
+}
@@ -0,0 +1,152 @@
+// Package propagation extracts the trace context of incoming requests
+// from the headers of the tracing systems that may have sent them,
+// so that requests continue the distributed traces of their callers.
+//
+// Besides the W3C Trace Context headers Encore itself propagates,
+// it supports the B3 headers used by Zipkin and by service meshes
+// built on Envoy, and the headers of AWS X-Ray and Google Cloud Trace,
+// which the load balancers of those clouds add to the requests they
+// forward. Other formats can be supported by implementing Propagator.
+package propagation
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"encore.dev/appruntime/model"
+)
+
+// Context is the trace context of an incoming request.
+type Context struct {
+	TraceID  model.TraceID
+	ParentID model.SpanID // the caller's span, or zero if it has none
+	Sampled  bool         // whether the caller sampled the trace
+
+	// State is the W3C "tracestate" value, which only
+	// the W3C Trace Context format carries.
+	State string
+}
+
+// A Propagator extracts the trace context of incoming requests
+// from their headers, in a particular format.
+type Propagator interface {
+	// Extract returns the trace context in h.
+	// It reports false if h has no valid trace context in the format.
+	Extract(h http.Header) (Context, bool)
+}
+
+// The names of the built-in propagators, as accepted by ByName.
+const (
+	NameTraceContext = "tracecontext"
+	NameB3           = "b3"
+	NameXRay         = "xray"
+	NameCloudTrace   = "cloudtrace"
+)
+
+// ByName returns the built-in propagator with the given name.
+// It reports false if there is no such propagator.
+func ByName(name string) (Propagator, bool) {
+	switch name {
+	case NameTraceContext:
+		return TraceContext{}, true
+	case NameB3:
+		return B3{}, true
+	case NameXRay:
+		return XRay{}, true
+	case NameCloudTrace:
+		return CloudTrace{}, true
+	default:
+		return nil, false
+	}
+}
+
+// Extract returns the trace context extracted from h by the first
+// of the propagators that finds one. It reports false if none does.
+func Extract(h http.Header, propagators []Propagator) (Context, bool) {
+	for _, p := range propagators {
+		if c, ok := p.Extract(h); ok {
+			return c, true
+		}
+	}
+	return Context{}, false
+}
+
+// TraceContext extracts the W3C Trace Context headers,
+// "traceparent" and "tracestate".
+type TraceContext struct{}
+
+func (TraceContext) Extract(h http.Header) (Context, bool) {
+	traceID, parentID, sampled, ok := model.ParseTraceParent(h.Get(model.TraceParentHeader))
+	if !ok {
+		return Context{}, false
+	}
+	state := strings.Join(h.Values(model.TraceStateHeader), ",")
+	return Context{
+		TraceID:  traceID,
+		ParentID: parentID,
+		Sampled:  sampled,
+		State:    model.NormalizeTraceState(state),
+	}, true
+}
+
+// B3 extracts the B3 headers, in either their single-header form,
+// "b3", or their multi-header form, "X-B3-TraceId" and friends.
+// 64-bit trace ids are extended to 128 bits by prefixing them with zeroes.
+type B3 struct{}
+
+func (B3) Extract(h http.Header) (Context, bool) {
+	if v := h.Get("b3"); v != "" {
+		// {TraceId}-{SpanId}[-{SamplingState}[-{ParentSpanId}]]
+		parts := strings.Split(v, "-")
+		if len(parts) < 2 {
+			// Only a sampling decision.
+			return Context{}, false
+		}
+		sampled := len(parts) > 2 && (parts[2] == "1" || parts[2] == "d")
+		return b3Context(parts[0], parts[1], sampled)
+	}
+	sampled := h.Get("X-B3-Sampled") == "1" || h.Get("X-B3-Flags") == "1"
+	return b3Context(h.Get("X-B3-TraceId"), h.Get("X-B3-SpanId"), sampled)
+}
+
+func b3Context(traceStr, spanStr string, sampled bool) (Context, bool) {
+	c := Context{Sampled: sampled}
+	switch len(traceStr) {
+	case 32:
+		if _, err := hex.Decode(c.TraceID[:], []byte(traceStr)); err != nil {
+			return Context{}, false
+		}
+	case 16:
+		if _, err := hex.Decode(c.TraceID[8:], []byte(traceStr)); err != nil {
+			return Context{}, false
+		}
+	default:
+		return Context{}, false
+	}
+	if len(spanStr) != 16 {
+		return Context{}, false
+	} else if _, err := hex.Decode(c.ParentID[:], []byte(spanStr)); err != nil {
+		return Context{}, false
+	}
+	if c.TraceID.IsZero() || c.ParentID.IsZero() {
+		return Context{}, false
+	}
+	return c, true
+}
+
+// XRay extracts the AWS X-Ray header, "X-Amzn-Trace-Id".
+type XRay struct{}
+
+func (XRay) Extract(h http.Header) (Context, bool) {
+	traceID, parentID, sampled, ok := ParseXRayHeader(h.Get(XRayHeader))
+	return Context{TraceID: traceID, ParentID: parentID, Sampled: sampled}, ok
+}
+
+// CloudTrace extracts the Google Cloud Trace header, "X-Cloud-Trace-Context".
+type CloudTrace struct{}
+
+func (CloudTrace) Extract(h http.Header) (Context, bool) {
+	traceID, parentID, sampled, ok := ParseCloudTraceHeader(h.Get(CloudTraceHeader))
+	return Context{TraceID: traceID, ParentID: parentID, Sampled: sampled}, ok
+}
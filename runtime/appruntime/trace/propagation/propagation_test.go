@@ -0,0 +1,167 @@
+package propagation
+
+import (
+	"net/http"
+	"testing"
+
+	"encore.dev/appruntime/model"
+)
+
+func TestExtract(t *testing.T) {
+	traceID := model.TraceID{0x46, 0x3a, 0xc3, 0x5c, 0x9f, 0x64, 0x13, 0xad, 0x48, 0x48, 0x5a, 0x39, 0x53, 0xbb, 0x61, 0x24}
+	spanID := model.SpanID{0xa2, 0xfb, 0x46, 0x44, 0x1e, 0xd8, 0x8d, 0x65}
+	all := []Propagator{TraceContext{}, B3{}, XRay{}, CloudTrace{}}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    Context
+		ok      bool
+	}{
+		{
+			name: "tracecontext",
+			headers: map[string]string{
+				"traceparent": "00-463ac35c9f6413ad48485a3953bb6124-a2fb46441ed88d65-01",
+				"tracestate":  "vendor=value",
+			},
+			want: Context{TraceID: traceID, ParentID: spanID, Sampled: true, State: "vendor=value"},
+			ok:   true,
+		},
+		{
+			name: "b3 multi",
+			headers: map[string]string{
+				"X-B3-TraceId": "463ac35c9f6413ad48485a3953bb6124",
+				"X-B3-SpanId":  "a2fb46441ed88d65",
+				"X-B3-Sampled": "1",
+			},
+			want: Context{TraceID: traceID, ParentID: spanID, Sampled: true},
+			ok:   true,
+		},
+		{
+			name:    "b3 single",
+			headers: map[string]string{"b3": "463ac35c9f6413ad48485a3953bb6124-a2fb46441ed88d65-0-05e3ac9a4f6e3b90"},
+			want:    Context{TraceID: traceID, ParentID: spanID},
+			ok:      true,
+		},
+		{
+			name:    "b3 64-bit trace id",
+			headers: map[string]string{"b3": "48485a3953bb6124-a2fb46441ed88d65"},
+			want: Context{
+				TraceID:  model.TraceID{8: 0x48, 9: 0x48, 10: 0x5a, 11: 0x39, 12: 0x53, 13: 0xbb, 14: 0x61, 15: 0x24},
+				ParentID: spanID,
+			},
+			ok: true,
+		},
+		{
+			name:    "b3 sampling only",
+			headers: map[string]string{"b3": "0"},
+		},
+		{
+			name:    "xray",
+			headers: map[string]string{"X-Amzn-Trace-Id": "Root=1-463ac35c-9f6413ad48485a3953bb6124;Parent=a2fb46441ed88d65;Sampled=1"},
+			want:    Context{TraceID: traceID, ParentID: spanID, Sampled: true},
+			ok:      true,
+		},
+		{
+			name:    "cloudtrace",
+			headers: map[string]string{"X-Cloud-Trace-Context": "463ac35c9f6413ad48485a3953bb6124/11744057711687929189;o=1"},
+			want:    Context{TraceID: traceID, ParentID: spanID, Sampled: true},
+			ok:      true,
+		},
+		{
+			name: "first propagator wins",
+			headers: map[string]string{
+				"traceparent":  "00-463ac35c9f6413ad48485a3953bb6124-a2fb46441ed88d65-00",
+				"X-B3-TraceId": "00000000000000000000000000000001",
+				"X-B3-SpanId":  "0000000000000001",
+			},
+			want: Context{TraceID: traceID, ParentID: spanID},
+			ok:   true,
+		},
+		{
+			name:    "none",
+			headers: map[string]string{"X-B3-TraceId": "not hex"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := make(http.Header)
+			for k, v := range test.headers {
+				h.Set(k, v)
+			}
+			got, ok := Extract(h, all)
+			if ok != test.ok || got != test.want {
+				t.Errorf("got %+v, %v, want %+v, %v", got, ok, test.want, test.ok)
+			}
+		})
+	}
+}
+
+func TestByName(t *testing.T) {
+	for _, name := range []string{NameTraceContext, NameB3, NameXRay, NameCloudTrace} {
+		if _, ok := ByName(name); !ok {
+			t.Errorf("ByName(%q) found no propagator", name)
+		}
+	}
+	if _, ok := ByName("unknown"); ok {
+		t.Errorf("ByName found an unknown propagator")
+	}
+}
+
+func TestXRayHeader(t *testing.T) {
+	traceID := model.TraceID{0x57, 0x59, 0xe9, 0x88, 0xbd, 0x86, 0x2e, 0x3f, 0xe1, 0xbe, 0x46, 0xa9, 0x94, 0x27, 0x27, 0x93}
+	spanID := model.SpanID{0x53, 0x99, 0x5c, 0x3f, 0x42, 0xcd, 0x8a, 0xd8}
+	const want = "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"
+	if got := FormatXRayHeader(traceID, spanID, true); got != want {
+		t.Errorf("FormatXRayHeader = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		header  string
+		parent  model.SpanID
+		sampled bool
+		ok      bool
+	}{
+		{header: want, parent: spanID, sampled: true, ok: true},
+		{header: "Root=1-5759e988-bd862e3fe1be46a994272793", ok: true},
+		{header: "Self=1-67891234-12456789abcdef012345678;Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=0", ok: true},
+		{header: "Parent=53995c3f42cd8ad8;Sampled=1"},
+		{header: "Root=2-5759e988-bd862e3fe1be46a994272793"},
+		{header: ""},
+	}
+	for _, test := range tests {
+		gotTrace, gotParent, sampled, ok := ParseXRayHeader(test.header)
+		if ok != test.ok || (ok && (gotTrace != traceID || gotParent != test.parent || sampled != test.sampled)) {
+			t.Errorf("ParseXRayHeader(%q) = %v, %v, %v, %v", test.header, gotTrace, gotParent, sampled, ok)
+		}
+	}
+}
+
+func TestCloudTraceHeader(t *testing.T) {
+	traceID := model.TraceID{0x10, 0x54, 0x45, 0xaa, 0x78, 0x43, 0xbc, 0x8b, 0xf2, 0x06, 0xb1, 0x20, 0x00, 0x10, 0x00, 0x00}
+	spanID := model.SpanID{0, 0, 0, 0, 0, 0, 0, 1}
+	const want = "105445aa7843bc8bf206b12000100000/1;o=1"
+	if got := FormatCloudTraceHeader(traceID, spanID, true); got != want {
+		t.Errorf("FormatCloudTraceHeader = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		header  string
+		parent  model.SpanID
+		sampled bool
+		ok      bool
+	}{
+		{header: want, parent: spanID, sampled: true, ok: true},
+		{header: "105445aa7843bc8bf206b12000100000/1", parent: spanID, ok: true},
+		{header: "105445aa7843bc8bf206b12000100000", ok: true},
+		{header: "105445aa7843bc8b/1;o=1"},
+		{header: "00000000000000000000000000000000/1;o=1"},
+		{header: ""},
+	}
+	for _, test := range tests {
+		gotTrace, gotParent, sampled, ok := ParseCloudTraceHeader(test.header)
+		if ok != test.ok || (ok && (gotTrace != traceID || gotParent != test.parent || sampled != test.sampled)) {
+			t.Errorf("ParseCloudTraceHeader(%q) = %v, %v, %v, %v", test.header, gotTrace, gotParent, sampled, ok)
+		}
+	}
+}
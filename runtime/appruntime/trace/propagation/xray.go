@@ -0,0 +1,51 @@
+package propagation
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace/xraytrace"
+)
+
+// XRayHeader is the header X-Ray propagates trace context in,
+// which AWS load balancers and services add to the requests they forward.
+const XRayHeader = "X-Amzn-Trace-Id"
+
+// FormatXRayHeader formats an X-Ray trace header value for the given
+// trace id and parent span id, such as
+// "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1".
+func FormatXRayHeader(traceID model.TraceID, parentID model.SpanID, sampled bool) string {
+	s := "Root=" + xraytrace.FormatTraceID(traceID) + ";Parent=" + hex.EncodeToString(parentID[:])
+	if sampled {
+		return s + ";Sampled=1"
+	}
+	return s + ";Sampled=0"
+}
+
+// ParseXRayHeader parses an X-Ray trace header value.
+// It reports false if s has no valid root trace id.
+// The parent span id is zero if s has none, as is the case
+// for requests forwarded by load balancers that are not traced.
+func ParseXRayHeader(s string) (traceID model.TraceID, parentID model.SpanID, sampled bool, ok bool) {
+	for _, field := range strings.Split(s, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch key {
+		case "Root":
+			traceID, ok = xraytrace.ParseTraceID(value)
+		case "Parent":
+			var id model.SpanID
+			if len(value) == 16 {
+				if _, err := hex.Decode(id[:], []byte(value)); err == nil {
+					parentID = id
+				}
+			}
+		case "Sampled":
+			sampled = value == "1"
+		}
+	}
+	if !ok {
+		return model.TraceID{}, model.SpanID{}, false, false
+	}
+	return traceID, parentID, sampled, true
+}
@@ -0,0 +1,108 @@
+package recording
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Defaults for the limits of a Dir.
+const (
+	DefaultMaxFiles    = 1000
+	DefaultMaxDiskSize = 1 << 30 // 1 GiB
+)
+
+// Dir writes recordings to a directory, bounding the number of
+// recordings it holds and their total size. Once a limit is exceeded,
+// the oldest recordings are removed. It is safe for concurrent use.
+type Dir struct {
+	path     string
+	maxFiles int
+	maxSize  int64
+
+	mu     sync.Mutex
+	loaded bool       // whether the existing recordings have been listed
+	files  []dirEntry // recordings in the directory, oldest first
+	size   int64      // total size of files
+}
+
+type dirEntry struct {
+	name string
+	size int64
+}
+
+// NewDir returns a Dir writing recordings to the directory path,
+// holding at most maxFiles recordings of at most maxSize bytes in total.
+// Zero limits mean DefaultMaxFiles and DefaultMaxDiskSize.
+func NewDir(path string, maxFiles int, maxSize int64) *Dir {
+	if maxFiles <= 0 {
+		maxFiles = DefaultMaxFiles
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDiskSize
+	}
+	return &Dir{path: path, maxFiles: maxFiles, maxSize: maxSize}
+}
+
+// Write writes rec to a new file in the directory, as WriteFile does,
+// removing the oldest recordings as needed to stay within the limits.
+// It returns the path of the file.
+func (d *Dir) Write(rec *Recording) (string, error) {
+	path, err := WriteFile(d.path, rec)
+	if err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if fi.Size() > d.maxSize {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("recording: %s is larger than the maximum disk size of %d bytes", filepath.Base(path), d.maxSize)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.loaded {
+		d.load()
+	} else {
+		d.add(dirEntry{name: filepath.Base(path), size: fi.Size()})
+	}
+	for len(d.files) > d.maxFiles || (d.size > d.maxSize && len(d.files) > 0) {
+		oldest := d.files[0]
+		d.files = d.files[1:]
+		d.size -= oldest.size
+		_ = os.Remove(filepath.Join(d.path, oldest.name))
+	}
+	return path, nil
+}
+
+// load lists the recordings in the directory, including ones
+// written by earlier processes.
+func (d *Dir) load() {
+	d.loaded = true
+	entries, _ := os.ReadDir(d.path)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		if fi, err := e.Info(); err == nil {
+			d.files = append(d.files, dirEntry{name: e.Name(), size: fi.Size()})
+			d.size += fi.Size()
+		}
+	}
+	// File names begin with the start time of the request.
+	sort.Slice(d.files, func(i, j int) bool { return d.files[i].name < d.files[j].name })
+}
+
+// add adds a newly written recording, keeping the files sorted.
+func (d *Dir) add(e dirEntry) {
+	i := sort.Search(len(d.files), func(i int) bool { return d.files[i].name > e.name })
+	d.files = append(d.files, dirEntry{})
+	copy(d.files[i+1:], d.files[i:])
+	d.files[i] = e
+	d.size += e.size
+}
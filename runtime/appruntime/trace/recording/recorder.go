@@ -0,0 +1,291 @@
+package recording
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/felixge/httpsnoop"
+
+	"encore.dev/appruntime/trace"
+)
+
+// DefaultMaxBodySize is the maximum number of bytes of each body
+// a Recorder records, unless configured otherwise.
+const DefaultMaxBodySize = 1 << 20 // 1 MiB
+
+// DefaultSensitiveHeaders are the headers whose values are always
+// redacted from recordings.
+var DefaultSensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// redactedValue replaces the values of sensitive headers.
+const redactedValue = "[redacted]"
+
+// Options configure a Recorder.
+type Options struct {
+	// MaxBodySize is the maximum number of bytes of each body recorded.
+	// If zero, DefaultMaxBodySize is used.
+	MaxBodySize int
+
+	// Redactor redacts sensitive fields from the recorded bodies
+	// of requests, responses and outgoing calls. If nil, bodies
+	// are recorded as is.
+	Redactor *trace.PayloadRedactor
+
+	// SensitiveHeader, if non-nil, reports whether the values of the
+	// named header are redacted, in addition to DefaultSensitiveHeaders.
+	SensitiveHeader func(name string) bool
+}
+
+// Recorder records a request as it is handled.
+// It is safe for concurrent use.
+type Recorder struct {
+	opts  Options
+	start time.Time
+
+	mu        sync.Mutex
+	rec       Recording
+	reqBody   body
+	respBody  body
+	reqTyped  any  // the decoded request payload, if known
+	respTyped any  // the response payload, if known
+	wrote     bool // whether the response headers have been written
+	calls     []*call
+}
+
+// body is a body being recorded.
+type body struct {
+	data      []byte
+	truncated bool
+}
+
+// call is an outgoing call being recorded.
+type call struct {
+	Call
+	body body
+}
+
+// NewRecorder creates a Recorder recording req, a request to the given
+// endpoint, according to opts.
+//
+// The body of req is replaced by one recording what is read from it.
+func NewRecorder(req *http.Request, service, endpoint string, opts Options) *Recorder {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultMaxBodySize
+	}
+	r := &Recorder{
+		opts:  opts,
+		start: time.Now(),
+		rec: Recording{
+			Service:  service,
+			Endpoint: endpoint,
+			Request: Request{
+				Method: req.Method,
+				URL:    req.URL.RequestURI(),
+				Header: req.Header.Clone(),
+			},
+		},
+	}
+	r.rec.Start = r.start
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = &teeBody{r: r, underlying: req.Body, b: &r.reqBody}
+	}
+	return r
+}
+
+// SetTypedPayloads sets the decoded request payload and the response
+// payload of the recorded request, whose fields tagged `encore:"sensitive"`
+// are redacted from the recorded bodies. Either may be nil if unknown.
+func (r *Recorder) SetTypedPayloads(req, resp any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if req != nil {
+		r.reqTyped = req
+	}
+	if resp != nil {
+		r.respTyped = resp
+	}
+}
+
+// WrapResponseWriter returns a ResponseWriter recording
+// the response written to w.
+func (r *Recorder) WrapResponseWriter(w http.ResponseWriter) http.ResponseWriter {
+	return httpsnoop.Wrap(w, httpsnoop.Hooks{
+		WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+			return func(code int) {
+				r.writeHeader(w, code)
+				next(code)
+			}
+		},
+		Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+			return func(p []byte) (int, error) {
+				r.writeHeader(w, http.StatusOK)
+				n, err := next(p)
+				r.write(&r.respBody, p[:n])
+				return n, err
+			}
+		},
+		ReadFrom: func(next httpsnoop.ReadFromFunc) httpsnoop.ReadFromFunc {
+			return func(src io.Reader) (int64, error) {
+				r.writeHeader(w, http.StatusOK)
+				return next(io.TeeReader(src, bodyWriter{r, &r.respBody}))
+			}
+		},
+	})
+}
+
+// writeHeader records the status code and a snapshot of the headers
+// of the response written to w, unless they have already been written.
+func (r *Recorder) writeHeader(w http.ResponseWriter, code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.wrote {
+		r.wrote = true
+		r.rec.Response.Status = code
+		r.rec.Response.Header = w.Header().Clone()
+	}
+}
+
+// write records p as part of b, up to the maximum body size.
+func (r *Recorder) write(b *body, p []byte) {
+	if len(p) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if remaining := r.opts.MaxBodySize - len(b.data); len(p) > remaining {
+		p = p[:remaining]
+		b.truncated = true
+	}
+	b.data = append(b.data, p...)
+}
+
+// callStartKey is the context key for the time an outgoing call began.
+type callStartKey struct{}
+
+// BeginCall records the beginning of the outgoing call req,
+// and returns the context to make the call with.
+func (r *Recorder) BeginCall(ctx context.Context, req *http.Request) context.Context {
+	return context.WithValue(ctx, callStartKey{}, time.Now())
+}
+
+// FinishCall records the completion of the outgoing call req, begun
+// with the context returned by BeginCall, with the given response or error.
+// The body of resp is replaced by one recording what is read from it.
+func (r *Recorder) FinishCall(req *http.Request, resp *http.Response, err error) {
+	now := time.Now()
+	start, ok := req.Context().Value(callStartKey{}).(time.Time)
+	if !ok {
+		start = now
+	}
+	c := &call{Call: Call{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Offset:   start.Sub(r.start),
+		Duration: now.Sub(start),
+	}}
+	if err != nil {
+		c.Err = err.Error()
+	} else if resp != nil {
+		c.Status = resp.StatusCode
+		c.Header = resp.Header.Clone()
+		if resp.Body != nil && resp.Body != http.NoBody {
+			resp.Body = &teeBody{r: r, underlying: resp.Body, b: &c.body}
+		}
+	}
+
+	r.mu.Lock()
+	r.calls = append(r.calls, c)
+	r.mu.Unlock()
+}
+
+// Finish completes the recording of the request, which
+// belongs to the given trace, and returns the recording.
+// Sensitive headers and body fields are redacted from it.
+// Bodies read after Finish is called are not recorded.
+func (r *Recorder) Finish(traceID string) *Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec := r.rec
+	rec.TraceID = traceID
+	rec.Duration = time.Since(r.start)
+	rec.Request.Header = r.redactHeader(rec.Request.Header)
+	rec.Request.Body, rec.Request.Truncated = r.redactBody(r.reqBody, r.reqTyped)
+	rec.Response.Header = r.redactHeader(rec.Response.Header)
+	rec.Response.Body, rec.Response.Truncated = r.redactBody(r.respBody, r.respTyped)
+	if !r.wrote {
+		// Nothing was written, so net/http responds with 200 OK.
+		rec.Response.Status = http.StatusOK
+	}
+	rec.Calls = make([]Call, len(r.calls))
+	for i, c := range r.calls {
+		rec.Calls[i] = c.Call
+		rec.Calls[i].Header = r.redactHeader(c.Header)
+		rec.Calls[i].Body, rec.Calls[i].Truncated = r.redactBody(c.body, nil)
+	}
+	return &rec
+}
+
+// redactHeader replaces the values of the sensitive headers in h,
+// which it modifies.
+func (r *Recorder) redactHeader(h http.Header) http.Header {
+	for name, values := range h {
+		if r.sensitiveHeader(name) {
+			for i := range values {
+				values[i] = redactedValue
+			}
+		}
+	}
+	return h
+}
+
+func (r *Recorder) sensitiveHeader(name string) bool {
+	for _, s := range DefaultSensitiveHeaders {
+		if strings.EqualFold(name, s) {
+			return true
+		}
+	}
+	return r.opts.SensitiveHeader != nil && r.opts.SensitiveHeader(name)
+}
+
+// redactBody returns a copy of the data of b with its sensitive fields
+// redacted, using typed to find the fields tagged as sensitive.
+func (r *Recorder) redactBody(b body, typed any) ([]byte, bool) {
+	if len(b.data) == 0 {
+		return nil, b.truncated
+	}
+	data := r.opts.Redactor.Redact(b.data, typed)
+	return append([]byte(nil), data...), b.truncated
+}
+
+// teeBody is an io.ReadCloser recording what is read from it.
+type teeBody struct {
+	r          *Recorder
+	underlying io.ReadCloser
+	b          *body
+}
+
+func (t *teeBody) Read(p []byte) (int, error) {
+	n, err := t.underlying.Read(p)
+	t.r.write(t.b, p[:n])
+	return n, err
+}
+
+func (t *teeBody) Close() error {
+	return t.underlying.Close()
+}
+
+// bodyWriter is an io.Writer recording what is written to it.
+type bodyWriter struct {
+	r *Recorder
+	b *body
+}
+
+func (w bodyWriter) Write(p []byte) (int, error) {
+	w.r.write(w.b, p)
+	return len(p), nil
+}
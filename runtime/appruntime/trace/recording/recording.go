@@ -0,0 +1,136 @@
+// Package recording records requests to files, along with the
+// responses of the outgoing HTTP calls made while handling them,
+// and replays the recordings against an application.
+//
+// A recording captures what a request depended on beyond the code
+// handling it: its inputs, its timings and the responses of the
+// services it called. Replaying it re-executes the endpoint with the
+// recorded inputs, serving the recorded responses to its outgoing
+// calls instead of making them, so that a request seen in production
+// can be debugged locally:
+//
+//	rec, err := recording.ReadFile(path)
+//	if err != nil {
+//		return err
+//	}
+//	res, err := (&recording.Replayer{Handler: h}).Replay(rec)
+//	if err != nil {
+//		return err
+//	}
+//	// ... compare res.Response with rec.Response ...
+//
+// The values of sensitive headers are redacted from recordings, as are
+// sensitive fields of the bodies when a Recorder is configured with a
+// trace.PayloadRedactor. Recordings may nonetheless contain personal
+// data, and should be treated with the same care as the data they contain.
+// Bodies with redacted fields are replayed with the redacted values.
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Recording is the recording of a request.
+type Recording struct {
+	Service  string        `json:"service"`
+	Endpoint string        `json:"endpoint"`
+	TraceID  string        `json:"trace_id,omitempty"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration_ns"`
+
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+
+	// Calls are the outgoing HTTP calls made while handling
+	// the request, in the order they completed.
+	Calls []Call `json:"calls,omitempty"`
+}
+
+// Request is a recorded incoming request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"` // the request URI, such as "/foo?bar=baz"
+	Header http.Header `json:"header,omitempty"`
+	Body   []byte      `json:"body,omitempty"`
+
+	// Truncated reports whether Body was truncated
+	// to the maximum body size of the recorder.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Response is a recorded response to an incoming request.
+type Response struct {
+	Status    int         `json:"status"`
+	Header    http.Header `json:"header,omitempty"`
+	Body      []byte      `json:"body,omitempty"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// Call is a recorded outgoing HTTP call.
+type Call struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+
+	// Offset is the time the call began, relative to the start of
+	// the request, and Duration is the time until its response
+	// headers were received.
+	Offset   time.Duration `json:"offset_ns"`
+	Duration time.Duration `json:"duration_ns"`
+
+	// Err is the error the call failed with, if any.
+	// The response fields are not set if it is.
+	Err string `json:"error,omitempty"`
+
+	Status int         `json:"status,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the part of the response body read by the application
+	// before the request completed.
+	Body      []byte `json:"body,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// FileName returns the name of the file rec is written to by WriteFile.
+// It begins with the start time of the request, so that listing
+// a directory of recordings lists them in the order they began.
+func (rec *Recording) FileName() string {
+	return fmt.Sprintf("%s-%s-%s.%s.json",
+		rec.Start.UTC().Format("20060102T150405.000000000Z"),
+		rec.TraceID, rec.Service, rec.Endpoint)
+}
+
+// WriteFile writes rec to a new file in dir, which is created
+// if it does not exist, and returns the path of the file.
+func WriteFile(dir string, rec *Recording) (string, error) {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("recording: %v", err)
+	}
+	path := filepath.Join(dir, rec.FileName())
+	// The file is not world-readable as it may contain credentials.
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("recording: %v", err)
+	}
+	return path, nil
+}
+
+// ReadFile reads the recording written to path by WriteFile.
+func ReadFile(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rec Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("recording: decode %s: %v", path, err)
+	}
+	return &rec, nil
+}
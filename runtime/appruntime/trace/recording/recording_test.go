@@ -0,0 +1,225 @@
+package recording
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/trace"
+)
+
+// recordingTransport records the calls made through it with rec,
+// as the request tracker does for applications.
+type recordingTransport struct {
+	rec *Recorder
+}
+
+func (t recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.WithContext(t.rec.BeginCall(req.Context(), req))
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	t.rec.FinishCall(req, resp, err)
+	return resp, err
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Upstream", "yes")
+		_, _ = io.WriteString(w, "world")
+	}))
+
+	// The handler greets the caller with the body of the upstream response.
+	var client *http.Client
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, _ := io.ReadAll(r.Body)
+		resp, err := client.Get(upstream.URL + "/greeting?lang=en")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		greeting, _ := io.ReadAll(resp.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, string(name)+", "+string(greeting))
+	})
+
+	req := httptest.NewRequest("POST", "/hello?x=1", strings.NewReader("hello"))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := NewRecorder(req, "svc", "Hello", Options{})
+	client = &http.Client{Transport: recordingTransport{rec}}
+	handler.ServeHTTP(rec.WrapResponseWriter(httptest.NewRecorder()), req)
+	recorded := rec.Finish("trace-id")
+
+	if recorded.Request.Method != "POST" || recorded.Request.URL != "/hello?x=1" ||
+		string(recorded.Request.Body) != "hello" || recorded.Request.Header.Get("Content-Type") != "text/plain" {
+		t.Errorf("got request %+v", recorded.Request)
+	}
+	if recorded.Response.Status != http.StatusCreated || string(recorded.Response.Body) != "hello, world" {
+		t.Errorf("got response %+v", recorded.Response)
+	}
+	if len(recorded.Calls) != 1 {
+		t.Fatalf("got %d calls, want 1", len(recorded.Calls))
+	}
+	if c := recorded.Calls[0]; c.Method != "GET" || c.URL != upstream.URL+"/greeting?lang=en" ||
+		c.Status != http.StatusOK || string(c.Body) != "world" || c.Header.Get("X-Upstream") != "yes" {
+		t.Errorf("got call %+v", c)
+	}
+
+	path, err := WriteFile(t.TempDir(), recorded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(path, "-trace-id-svc.Hello.json") {
+		t.Errorf("got path %q", path)
+	}
+	read, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replay with the upstream service gone; the recorded response is served instead.
+	upstream.Close()
+	client = http.DefaultClient
+	res, err := (&Replayer{Handler: handler}).Replay(read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Response.Body)
+	if res.Response.StatusCode != http.StatusCreated || string(body) != "hello, world" {
+		t.Errorf("got replayed response %d %q", res.Response.StatusCode, body)
+	}
+	if len(res.Unmatched) != 0 || len(res.Unused) != 0 {
+		t.Errorf("got unmatched %v, unused %v", res.Unmatched, res.Unused)
+	}
+}
+
+func TestTransportMatching(t *testing.T) {
+	tr := NewTransport(&Recording{Calls: []Call{
+		{Method: "GET", URL: "http://a/x?n=1", Status: 200, Body: []byte("first")},
+		{Method: "GET", URL: "http://a/x?n=2", Status: 200, Body: []byte("second")},
+		{Method: "POST", URL: "http://a/y", Err: "connection refused"},
+	}})
+	client := &http.Client{Transport: tr}
+
+	get := func(url string) string {
+		t.Helper()
+		resp, err := client.Get(url)
+		if err != nil {
+			t.Fatalf("GET %s: %v", url, err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	if got := get("http://a/x?n=2"); got != "second" {
+		t.Errorf("exact match: got %q, want second", got)
+	}
+	if got := get("http://a/x?n=3"); got != "first" {
+		t.Errorf("match without query: got %q, want first", got)
+	}
+	if _, err := client.Get("http://a/x?n=1"); err == nil {
+		t.Error("got response for a call already served")
+	}
+	if _, err := client.Post("http://a/y", "text/plain", nil); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("got error %v, want recorded error", err)
+	}
+	if got := tr.Unmatched(); len(got) != 1 || got[0] != "GET http://a/x?n=1" {
+		t.Errorf("got unmatched %v", got)
+	}
+	if got := tr.Unused(); len(got) != 0 {
+		t.Errorf("got unused %v", got)
+	}
+}
+
+func TestRecorderRedaction(t *testing.T) {
+	type loginReq struct {
+		User string
+		PIN  string `json:"pin" encore:"sensitive"`
+	}
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(`{"User":"alice","pin":"1234"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Api-Key", "secret")
+	rec := NewRecorder(req, "svc", "Login", Options{
+		Redactor:        trace.NewPayloadRedactor("token"),
+		SensitiveHeader: func(name string) bool { return strings.EqualFold(name, "X-Api-Key") },
+	})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = io.WriteString(w, `{"token":"secret"}`)
+	})
+	handler.ServeHTTP(rec.WrapResponseWriter(httptest.NewRecorder()), req)
+	rec.SetTypedPayloads(&loginReq{}, nil)
+	recorded := rec.Finish("trace-id")
+
+	for _, h := range []http.Header{recorded.Request.Header, recorded.Response.Header} {
+		for _, name := range []string{"Authorization", "X-Api-Key", "Set-Cookie"} {
+			if v := h.Get(name); v != "" && v != "[redacted]" {
+				t.Errorf("got %s header %q, want it redacted", name, v)
+			}
+		}
+	}
+	if got, want := string(recorded.Request.Body), `{"User":"alice","pin":"[redacted]"}`; got != want {
+		t.Errorf("got request body %s, want %s", got, want)
+	}
+	if got, want := string(recorded.Response.Body), `{"token":"[redacted]"}`; got != want {
+		t.Errorf("got response body %s, want %s", got, want)
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now()
+	write := func(d *Dir, i int) {
+		t.Helper()
+		rec := &Recording{Service: "svc", Endpoint: "E", TraceID: strconv.Itoa(i), Start: start.Add(time.Duration(i) * time.Second)}
+		if _, err := d.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := func() []string {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, e := range entries {
+			rec, err := ReadFile(filepath.Join(dir, e.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, rec.TraceID)
+		}
+		return ids
+	}
+
+	// The oldest recordings are removed beyond the maximum number of files.
+	d := NewDir(dir, 3, 0)
+	for i := 0; i < 5; i++ {
+		write(d, i)
+	}
+	if got, want := files(), []string{"2", "3", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got recordings %v, want %v", got, want)
+	}
+
+	// Recordings written by earlier processes count towards the limits,
+	// including the maximum disk size.
+	var size int64
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		fi, _ := e.Info()
+		size += fi.Size()
+	}
+	d = NewDir(dir, 10, size)
+	write(d, 5)
+	if got := files(); len(got) != 3 || got[2] != "5" {
+		t.Errorf("got recordings %v, want the 3 newest", got)
+	}
+}
@@ -0,0 +1,189 @@
+package recording
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Replayer replays recordings against an HTTP handler.
+type Replayer struct {
+	// Handler is the handler serving the application's endpoints.
+	Handler http.Handler
+
+	// SimulateLatency specifies whether the responses to outgoing calls
+	// are delayed by the durations of the recorded calls, to reproduce
+	// the timings of the recorded request.
+	SimulateLatency bool
+}
+
+// Result is the result of replaying a recording.
+type Result struct {
+	// Response is the response of the handler to the replayed request.
+	Response *http.Response
+
+	// Unmatched are the outgoing calls the handler made that were not
+	// in the recording, as "METHOD URL". They failed with an error,
+	// and are a sign that the handler no longer behaves as recorded.
+	Unmatched []string
+
+	// Unused are the recorded calls the handler did not make.
+	Unused []Call
+}
+
+// Replay re-executes the request of rec against the handler,
+// serving the recorded responses to the outgoing HTTP calls it makes.
+//
+// Outgoing calls are served from the recording by temporarily replacing
+// http.DefaultTransport, so only calls made with it are replayed,
+// and Replay must not run concurrently with other code using it.
+func (rp *Replayer) Replay(rec *Recording) (*Result, error) {
+	if rec.Request.Truncated {
+		return nil, errors.New("recording: cannot replay a request whose body was truncated")
+	}
+
+	t := NewTransport(rec)
+	t.SimulateLatency = rp.SimulateLatency
+	prev := http.DefaultTransport
+	http.DefaultTransport = t
+	defer func() { http.DefaultTransport = prev }()
+
+	req := httptest.NewRequest(rec.Request.Method, rec.Request.URL, bytes.NewReader(rec.Request.Body))
+	for k, v := range rec.Request.Header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	w := httptest.NewRecorder()
+	rp.Handler.ServeHTTP(w, req)
+
+	return &Result{
+		Response:  w.Result(),
+		Unmatched: t.Unmatched(),
+		Unused:    t.Unused(),
+	}, nil
+}
+
+// Transport is an http.RoundTripper serving the recorded responses
+// of the outgoing calls in a recording.
+//
+// Each call is matched to the first recorded call not yet served with
+// the same method and URL, or failing that, the same method and URL
+// without the query string. Calls not matching any recorded call fail.
+type Transport struct {
+	// SimulateLatency specifies whether responses are delayed
+	// by the durations of the recorded calls.
+	SimulateLatency bool
+
+	calls []Call
+
+	mu        sync.Mutex
+	used      []bool
+	unmatched []string
+}
+
+// NewTransport creates a Transport serving the calls recorded in rec.
+func NewTransport(rec *Recording) *Transport {
+	return &Transport{
+		calls: rec.Calls,
+		used:  make([]bool, len(rec.Calls)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	c, ok := t.match(req.Method, req.URL.String())
+	if !ok {
+		return nil, fmt.Errorf("recording: no recorded response for %s %s", req.Method, req.URL)
+	}
+
+	if t.SimulateLatency && c.Duration > 0 {
+		timer := time.NewTimer(c.Duration)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	if c.Err != "" {
+		return nil, errors.New(c.Err)
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(c.Status) + " " + http.StatusText(c.Status),
+		StatusCode:    c.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}, nil
+}
+
+// match marks the recorded call matching method and url as served
+// and returns it. It reports false if there is no such call.
+func (t *Transport) match(method, url string) (Call, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	idx := -1
+	for i, c := range t.calls {
+		if !t.used[i] && c.Method == method && c.URL == url {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		for i, c := range t.calls {
+			if !t.used[i] && c.Method == method && stripQuery(c.URL) == stripQuery(url) {
+				idx = i
+				break
+			}
+		}
+	}
+	if idx < 0 {
+		t.unmatched = append(t.unmatched, method+" "+url)
+		return Call{}, false
+	}
+	t.used[idx] = true
+	return t.calls[idx], true
+}
+
+// Unmatched returns the calls, as "METHOD URL",
+// that did not match any recorded call.
+func (t *Transport) Unmatched() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.unmatched...)
+}
+
+// Unused returns the recorded calls that have not been served.
+func (t *Transport) Unused() []Call {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var unused []Call
+	for i, c := range t.calls {
+		if !t.used[i] {
+			unused = append(unused, c)
+		}
+	}
+	return unused
+}
+
+func stripQuery(url string) string {
+	if idx := strings.IndexByte(url, '?'); idx >= 0 {
+		return url[:idx]
+	}
+	return url
+}
@@ -0,0 +1,156 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// redactedValue replaces the values of sensitive payload fields.
+const redactedValue = "[redacted]"
+
+// PayloadRedactor redacts the values of sensitive fields, such as
+// passwords and tokens, from the JSON payloads recorded in traces.
+//
+// A field is sensitive if its JSON name is one of the configured keys,
+// matched case-insensitively, or if the field is tagged as sensitive
+// in the payload's Go type with the struct tag `encore:"sensitive"`.
+// Sensitive fields are redacted wherever they appear in a payload,
+// including in nested objects and arrays.
+//
+// Redaction happens before payloads are written to the trace buffer.
+// It is separate from the redaction of log fields done by rlog.
+type PayloadRedactor struct {
+	keys  []string // lowercase JSON names of sensitive fields
+	types sync.Map // reflect.Type -> []string, the sensitive fields tagged in the type
+}
+
+// NewPayloadRedactor returns a PayloadRedactor that redacts the fields
+// with the given JSON names, in addition to fields tagged as sensitive.
+func NewPayloadRedactor(keys ...string) *PayloadRedactor {
+	r := &PayloadRedactor{keys: make([]string, len(keys))}
+	for i, k := range keys {
+		r.keys[i] = strings.ToLower(k)
+	}
+	return r
+}
+
+// Redact returns payload with the values of sensitive fields replaced.
+// typed is the decoded payload, whose type's struct tags determine the
+// sensitive fields in addition to the configured keys; it may be nil.
+// It returns payload itself if nothing needs redacting, and never
+// modifies payload. If r is nil, it returns payload.
+func (r *PayloadRedactor) Redact(payload []byte, typed any) []byte {
+	if r == nil || len(payload) == 0 {
+		return payload
+	}
+
+	candidates := r.keys
+	if typed != nil {
+		if tagged := r.taggedKeys(reflect.TypeOf(typed)); len(tagged) > 0 {
+			candidates = append(candidates[:len(candidates):len(candidates)], tagged...)
+		}
+	}
+	if len(candidates) == 0 {
+		return payload
+	}
+
+	// Only decode payloads that may contain a sensitive field.
+	lower := bytes.ToLower(payload)
+	keys := make(map[string]bool)
+	for _, k := range candidates {
+		if bytes.Contains(lower, []byte(`"`+k+`"`)) {
+			keys[k] = true
+		}
+	}
+	if len(keys) == 0 {
+		return payload
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		// Don't record payloads that may hold sensitive values unredacted.
+		return []byte(`"` + redactedValue + `"`)
+	}
+	if !redactValue(v, keys) {
+		return payload
+	}
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return []byte(`"` + redactedValue + `"`)
+	}
+	return redacted
+}
+
+// redactValue replaces the values of the sensitive keys in v,
+// reporting whether it replaced any.
+func redactValue(v any, keys map[string]bool) (redacted bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if keys[strings.ToLower(k)] {
+				v[k] = redactedValue
+				redacted = true
+			} else if redactValue(val, keys) {
+				redacted = true
+			}
+		}
+	case []any:
+		for _, val := range v {
+			if redactValue(val, keys) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+// taggedKeys returns the lowercase JSON names of the fields
+// tagged as sensitive in t and the types it contains.
+func (r *PayloadRedactor) taggedKeys(t reflect.Type) []string {
+	if keys, ok := r.types.Load(t); ok {
+		return keys.([]string)
+	}
+	var keys []string
+	collectTaggedKeys(t, make(map[reflect.Type]bool), &keys)
+	r.types.Store(t, keys)
+	return keys
+}
+
+func collectTaggedKeys(t reflect.Type, seen map[reflect.Type]bool, keys *[]string) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if isSensitiveField(f) {
+			name := f.Name
+			if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag != "" && tag != "-" {
+				name = tag
+			}
+			*keys = append(*keys, strings.ToLower(name))
+		}
+		collectTaggedKeys(f.Type, seen, keys)
+	}
+}
+
+// isSensitiveField reports whether f is tagged `encore:"sensitive"`.
+func isSensitiveField(f reflect.StructField) bool {
+	for _, opt := range strings.Split(f.Tag.Get("encore"), ",") {
+		if strings.TrimSpace(opt) == "sensitive" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package trace
+
+import (
+	"testing"
+)
+
+func TestPayloadRedactor(t *testing.T) {
+	type credentials struct {
+		User     string `json:"user"`
+		Password string `json:"password" encore:"sensitive"`
+	}
+	type login struct {
+		Creds  *credentials `json:"creds"`
+		Token  string
+		Secret string `encore:"optional,sensitive"`
+	}
+
+	r := NewPayloadRedactor("Token")
+	tests := []struct {
+		name    string
+		payload string
+		typed   any
+		want    string
+	}{
+		{
+			name:    "no sensitive fields",
+			payload: `{"user":"alice","count":1}`,
+			want:    `{"user":"alice","count":1}`,
+		},
+		{
+			name:    "configured key",
+			payload: `{"user":"alice","TOKEN":"t1"}`,
+			want:    `{"TOKEN":"[redacted]","user":"alice"}`,
+		},
+		{
+			name:    "tagged fields",
+			payload: `{"creds":{"user":"alice","password":"p"},"Token":"t","Secret":"s"}`,
+			typed:   login{},
+			want:    `{"Secret":"[redacted]","Token":"[redacted]","creds":{"password":"[redacted]","user":"alice"}}`,
+		},
+		{
+			name:    "nested in arrays",
+			payload: `[{"token":"a"},{"other":12345678901234567890}]`,
+			want:    `[{"token":"[redacted]"},{"other":12345678901234567890}]`,
+		},
+		{
+			name:    "key only in value",
+			payload: `{"kind":"token"}`,
+			want:    `{"kind":"token"}`,
+		},
+		{
+			name:    "invalid json",
+			payload: `{"token":`,
+			want:    `"[redacted]"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := string(r.Redact([]byte(test.payload), test.typed)); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+
+	var nilRedactor *PayloadRedactor
+	if got := string(nilRedactor.Redact([]byte(`{"token":"t"}`), nil)); got != `{"token":"t"}` {
+		t.Errorf("nil redactor redacted payload: %s", got)
+	}
+}
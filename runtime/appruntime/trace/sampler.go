@@ -0,0 +1,201 @@
+package trace
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/config"
+	"encore.dev/appruntime/model"
+)
+
+// Sampler decides which operations are traced, according to
+// the sampling rules in the runtime config.
+// A nil *Sampler traces every operation.
+type Sampler struct {
+	mu        sync.Mutex // protects rnd and the rules' rate limiters
+	rnd       *rand.Rand
+	now       func() time.Time
+	global    *samplingRule
+	endpoints map[string]*samplingRule
+	tail      *config.TailSampling // nil if tail-based sampling is disabled
+
+	excluded     map[string]bool // excluded endpoints, keyed by "service.endpoint"
+	excludePaths []string        // excluded paths, or path prefixes ending in "*"
+}
+
+// NewSampler returns a Sampler implementing the rules in cfg,
+// and the sampling rates declared by endpoints, keyed by
+// "service.endpoint", for endpoints without a rule in cfg.
+// It returns nil if both are empty, which traces every operation.
+func NewSampler(cfg *config.TraceSampling, declared map[string]float64) *Sampler {
+	if cfg == nil {
+		if len(declared) == 0 {
+			return nil
+		}
+		cfg = &config.TraceSampling{}
+	}
+	s := &Sampler{
+		rnd:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:       time.Now,
+		global:    newSamplingRule(cfg.TraceSamplingRule),
+		endpoints: make(map[string]*samplingRule, len(cfg.Endpoints)),
+		tail:      cfg.Tail,
+
+		excluded:     make(map[string]bool, len(cfg.Exclude)),
+		excludePaths: cfg.ExcludePaths,
+	}
+	for key, rate := range declared {
+		rate := rate
+		s.endpoints[key] = newSamplingRule(config.TraceSamplingRule{Rate: &rate})
+	}
+	for key, rule := range cfg.Endpoints {
+		s.endpoints[key] = newSamplingRule(rule)
+	}
+	for _, key := range cfg.Exclude {
+		s.excluded[key] = true
+	}
+	return s
+}
+
+// Exclude reports whether an operation handling a request to the given
+// endpoint and HTTP path is excluded from tracing altogether.
+// The path is empty for requests that are not HTTP requests.
+func (s *Sampler) Exclude(service, endpoint, path string) bool {
+	if s == nil {
+		return false
+	}
+	if s.excluded[service+"."+endpoint] {
+		return true
+	}
+	if path != "" {
+		for _, p := range s.excludePaths {
+			if prefix := strings.TrimSuffix(p, "*"); prefix != p {
+				if strings.HasPrefix(path, prefix) {
+					return true
+				}
+			} else if path == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Parent is the sampling decision of the caller of an operation,
+// propagated along with the request.
+type Parent int
+
+const (
+	NoParent         Parent = iota // the request carries no decision
+	ParentSampled                  // the caller traces the request
+	ParentNotSampled               // the caller does not trace the request
+)
+
+// ParentFlag returns the Parent for a caller's propagated sampled flag.
+func ParentFlag(sampled bool) Parent {
+	if sampled {
+		return ParentSampled
+	}
+	return ParentNotSampled
+}
+
+// Sample reports whether an operation handling a request
+// to the given endpoint should be traced.
+//
+// The decision of the caller, if any, takes precedence over the sampling
+// rules, so that the traces of distributed operations are either kept or
+// dropped in their entirety.
+func (s *Sampler) Sample(service, endpoint string, parent Parent) bool {
+	switch parent {
+	case ParentSampled:
+		return true
+	case ParentNotSampled:
+		return false
+	}
+	if s == nil {
+		return true
+	}
+	rule := s.endpoints[service+"."+endpoint]
+	if rule == nil {
+		rule = s.global
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rule.rate < 1 && s.rnd.Float64() >= rule.rate {
+		return false
+	}
+	return rule.allow(s.now())
+}
+
+// TailEnabled reports whether operations that are not sampled
+// should be traced provisionally, for tail-based sampling.
+func (s *Sampler) TailEnabled() bool {
+	return s != nil && s.tail != nil
+}
+
+// KeepTail reports whether the provisional trace of the operation
+// handling req should be kept, given the request's response,
+// because the request ended in error or was slow.
+func (s *Sampler) KeepTail(req *model.Request, resp *model.Response) bool {
+	if !s.TailEnabled() {
+		return false
+	}
+	if s.tail.KeepErrors && resp != nil && resp.Err != nil {
+		return true
+	}
+	return s.tail.LatencyThreshold > 0 && !req.Start.IsZero() &&
+		s.now().Sub(req.Start) >= s.tail.LatencyThreshold
+}
+
+// samplingRule is a parsed config.TraceSamplingRule.
+type samplingRule struct {
+	rate float64 // fraction of operations to trace
+
+	// Token bucket limiting the number of traced operations per second.
+	// The bucket holds up to burst tokens and is refilled at perSecond
+	// tokens per second. It is unlimited if perSecond is zero.
+	perSecond float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+}
+
+func newSamplingRule(cfg config.TraceSamplingRule) *samplingRule {
+	r := &samplingRule{rate: 1}
+	if cfg.Rate != nil {
+		r.rate = *cfg.Rate
+	}
+	if cfg.MaxPerSecond > 0 {
+		r.perSecond = cfg.MaxPerSecond
+		r.burst = cfg.MaxPerSecond
+		if r.burst < 1 {
+			r.burst = 1
+		}
+		r.tokens = r.burst
+	}
+	return r
+}
+
+// allow reports whether the rate limit permits tracing
+// another operation at time now, consuming a token if so.
+// It must be called with the Sampler's mutex held.
+func (r *samplingRule) allow(now time.Time) bool {
+	if r.perSecond == 0 {
+		return true
+	}
+	if !r.last.IsZero() {
+		r.tokens += now.Sub(r.last).Seconds() * r.perSecond
+		if r.tokens > r.burst {
+			r.tokens = r.burst
+		}
+	}
+	r.last = now
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
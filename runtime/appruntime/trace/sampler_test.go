@@ -0,0 +1,176 @@
+package trace
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/config"
+	"encore.dev/appruntime/model"
+)
+
+func TestSampler(t *testing.T) {
+	zero, half := 0.0, 0.5
+	s := NewSampler(&config.TraceSampling{
+		TraceSamplingRule: config.TraceSamplingRule{MaxPerSecond: 2},
+		Endpoints: map[string]config.TraceSamplingRule{
+			"svc.Never": {Rate: &zero},
+			"svc.Half":  {Rate: &half},
+		},
+	}, nil)
+	now := time.Unix(0, 0)
+	s.now = func() time.Time { return now }
+
+	// The global rule allows a burst of two, and then two per second.
+	for i, want := range []bool{true, true, false} {
+		if got := s.Sample("svc", "Other", NoParent); got != want {
+			t.Errorf("sample %d: got %v, want %v", i, got, want)
+		}
+	}
+	now = now.Add(500 * time.Millisecond)
+	if !s.Sample("svc", "Other", NoParent) || s.Sample("svc", "Other", NoParent) {
+		t.Errorf("want one sample after 500ms")
+	}
+
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		if s.Sample("svc", "Never", NoParent) {
+			t.Fatalf("sampled endpoint with rate 0")
+		}
+		if s.Sample("svc", "Half", NoParent) {
+			sampled++
+		}
+	}
+	if sampled < 400 || sampled > 600 {
+		t.Errorf("sampled %d of 1000 requests with rate 0.5", sampled)
+	}
+
+	var nilSampler *Sampler
+	if !nilSampler.Sample("svc", "Other", NoParent) {
+		t.Errorf("nil sampler did not sample")
+	}
+}
+
+func TestSampler_Declared(t *testing.T) {
+	zero := 0.0
+	s := NewSampler(&config.TraceSampling{
+		TraceSamplingRule: config.TraceSamplingRule{Rate: &zero},
+		Endpoints: map[string]config.TraceSamplingRule{
+			"svc.Overridden": {Rate: &zero},
+		},
+	}, map[string]float64{"svc.Checkout": 1, "svc.Overridden": 1})
+
+	for i := 0; i < 100; i++ {
+		if !s.Sample("svc", "Checkout", NoParent) {
+			t.Fatalf("did not sample endpoint declaring rate 1")
+		}
+		if s.Sample("svc", "Overridden", NoParent) {
+			t.Fatalf("sampled endpoint whose declared rate is overridden with rate 0")
+		}
+		if s.Sample("svc", "Other", NoParent) {
+			t.Fatalf("sampled endpoint with global rate 0")
+		}
+	}
+
+	// Declared rates apply even if no sampling is configured.
+	s = NewSampler(nil, map[string]float64{"svc.Feed": 0})
+	if s == nil || s.Sample("svc", "Feed", NoParent) || !s.Sample("svc", "Other", NoParent) {
+		t.Errorf("declared rates not applied without sampling config")
+	}
+	if NewSampler(nil, nil) != nil {
+		t.Errorf("got non-nil sampler without sampling config or declared rates")
+	}
+}
+
+func TestSampler_Parent(t *testing.T) {
+	zero, one := 0.0, 1.0
+	s := NewSampler(&config.TraceSampling{
+		TraceSamplingRule: config.TraceSamplingRule{Rate: &zero},
+		Endpoints: map[string]config.TraceSamplingRule{
+			"svc.Always": {Rate: &one},
+		},
+	}, nil)
+
+	// The caller's decision takes precedence over the sampling rules.
+	if !s.Sample("svc", "Other", ParentSampled) {
+		t.Errorf("did not sample request sampled by its caller")
+	}
+	if s.Sample("svc", "Always", ParentNotSampled) {
+		t.Errorf("sampled request not sampled by its caller")
+	}
+	if !s.Sample("svc", "Always", NoParent) || s.Sample("svc", "Other", NoParent) {
+		t.Errorf("sampling rules not applied without a caller decision")
+	}
+
+	var nilSampler *Sampler
+	if nilSampler.Sample("svc", "Other", ParentNotSampled) {
+		t.Errorf("nil sampler sampled request not sampled by its caller")
+	}
+	if ParentFlag(true) != ParentSampled || ParentFlag(false) != ParentNotSampled {
+		t.Errorf("ParentFlag returned wrong decisions")
+	}
+}
+
+func TestSampler_KeepTail(t *testing.T) {
+	s := NewSampler(&config.TraceSampling{
+		Tail: &config.TailSampling{KeepErrors: true, LatencyThreshold: time.Second},
+	}, nil)
+	start := time.Unix(0, 0)
+	now := start.Add(100 * time.Millisecond)
+	s.now = func() time.Time { return now }
+
+	req := &model.Request{Start: start}
+	if !s.TailEnabled() {
+		t.Fatalf("tail-based sampling not enabled")
+	}
+	if s.KeepTail(req, &model.Response{}) {
+		t.Errorf("kept fast, successful request")
+	}
+	if !s.KeepTail(req, &model.Response{Err: errors.New("boom")}) {
+		t.Errorf("did not keep failed request")
+	}
+	now = start.Add(time.Second)
+	if !s.KeepTail(req, &model.Response{}) {
+		t.Errorf("did not keep slow request")
+	}
+
+	if s := NewSampler(&config.TraceSampling{}, nil); s.TailEnabled() || s.KeepTail(req, &model.Response{Err: errors.New("boom")}) {
+		t.Errorf("tail-based sampling enabled without being configured")
+	}
+}
+
+func TestSampler_Exclude(t *testing.T) {
+	s := NewSampler(&config.TraceSampling{
+		TraceSamplingRule: config.TraceSamplingRule{MaxPerSecond: 1},
+		Exclude:           []string{"svc.Health"},
+		ExcludePaths:      []string{"/metrics", "/internal/*"},
+	}, nil)
+	tests := []struct {
+		service, endpoint, path string
+		want                    bool
+	}{
+		{"svc", "Health", "/health", true},
+		{"svc", "Health", "", true},
+		{"svc", "Metrics", "/metrics", true},
+		{"svc", "Metrics", "/metrics/foo", false},
+		{"svc", "Internal", "/internal/foo/bar", true},
+		{"svc", "Internal", "/internals", false},
+		{"svc", "Other", "/other", false},
+		{"svc", "Other", "", false},
+	}
+	for _, test := range tests {
+		if got := s.Exclude(test.service, test.endpoint, test.path); got != test.want {
+			t.Errorf("Exclude(%q, %q, %q) = %v, want %v", test.service, test.endpoint, test.path, got, test.want)
+		}
+	}
+
+	// Excluded requests don't consume the sampling budget.
+	if !s.Sample("svc", "Other", NoParent) {
+		t.Errorf("did not sample first request")
+	}
+
+	var nilSampler *Sampler
+	if nilSampler.Exclude("svc", "Health", "/health") {
+		t.Errorf("nil sampler excluded request")
+	}
+}
@@ -1,6 +1,8 @@
 package trace
 
 import (
+	"context"
+
 	"encore.dev/appruntime/config"
 )
 
@@ -10,7 +12,55 @@ type Version int
 const CurrentVersion Version = 12
 
 // Enabled reports whether tracing is enabled.
-// It is always enabled except for running tests and for ejected applications.
+// It is always enabled except for running tests and for ejected applications,
+// unless the application is configured to export traces itself
+// or to write them to local files.
 func Enabled(cfg *config.Config) bool {
-	return cfg.Runtime.TraceEndpoint != "" && len(cfg.Runtime.AuthKeys) > 0 && !cfg.Static.Testing
+	return (PlatformEnabled(cfg) || ExportEnabled(cfg) || FilesEnabled(cfg)) && !cfg.Static.Testing
+}
+
+// PlatformEnabled reports whether traces are sent to the Encore platform.
+func PlatformEnabled(cfg *config.Config) bool {
+	return cfg.Runtime.TraceEndpoint != "" && len(cfg.Runtime.AuthKeys) > 0
+}
+
+// ExportEnabled reports whether the application is configured
+// to export traces to a tracing system of its own.
+func ExportEnabled(cfg *config.Config) bool {
+	exp := cfg.Runtime.TraceExport
+	if exp == nil {
+		return false
+	}
+	switch exp.Format {
+	case "xray":
+		return true // the endpoint has a default
+	case "cloudtrace":
+		return exp.ProjectID != ""
+	default:
+		return exp.Endpoint != ""
+	}
+}
+
+// FilesEnabled reports whether the application is configured
+// to write traces to local files.
+func FilesEnabled(cfg *config.Config) bool {
+	return cfg.Runtime.TraceFiles != nil && cfg.Runtime.TraceFiles.Dir != ""
+}
+
+// LimitsFor returns the limits on the size of traces configured by cfg.
+// An unknown truncation policy is treated as TruncateStop.
+func LimitsFor(cfg *config.Config) Limits {
+	l := cfg.Runtime.TraceLimits
+	if l == nil || l.MaxSize <= 0 {
+		return Limits{}
+	}
+	policy, _ := ParseTruncationPolicy(l.Policy)
+	return Limits{MaxSize: l.MaxSize, Policy: policy}
+}
+
+// An Exporter exports trace data to a tracing system
+// other than the Encore platform.
+type Exporter interface {
+	// ExportTrace exports data, as returned by Log.GetAndClear.
+	ExportTrace(ctx context.Context, data []byte) error
 }
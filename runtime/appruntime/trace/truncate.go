@@ -0,0 +1,127 @@
+package trace
+
+import (
+	"encoding/binary"
+)
+
+// TruncationPolicy decides which events are dropped
+// once a trace log reaches its maximum size.
+type TruncationPolicy uint8
+
+const (
+	// TruncateStop stops recording events once the maximum size
+	// is reached, keeping the beginning of the trace.
+	TruncateStop TruncationPolicy = iota
+
+	// TruncateDropOldest drops the oldest events to make room for
+	// new ones once the maximum size is reached, keeping the end
+	// of the trace.
+	TruncateDropOldest
+)
+
+// ParseTruncationPolicy parses the name of a truncation policy,
+// "stop" or "drop_oldest". The empty string means TruncateStop.
+func ParseTruncationPolicy(s string) (TruncationPolicy, bool) {
+	switch s {
+	case "", "stop":
+		return TruncateStop, true
+	case "drop_oldest":
+		return TruncateDropOldest, true
+	default:
+		return TruncateStop, false
+	}
+}
+
+func (p TruncationPolicy) String() string {
+	switch p {
+	case TruncateStop:
+		return "stop"
+	case TruncateDropOldest:
+		return "drop_oldest"
+	default:
+		return "unknown"
+	}
+}
+
+// Limits bound the size of trace logs.
+type Limits struct {
+	// MaxSize is the maximum number of bytes of trace data a log
+	// buffers. It is unlimited if zero.
+	MaxSize int
+
+	// Policy decides which events are dropped once MaxSize is reached.
+	Policy TruncationPolicy
+}
+
+// alwaysKept reports whether events of type typ are kept regardless
+// of the size limit: the request boundaries, so that the trace remains
+// well-formed, and crash markers, which explain why it ends early.
+func alwaysKept(typ EventType) bool {
+	return typ == RequestStart || typ == RequestEnd || typ == TraceCrashed
+}
+
+// truncation records the events a log has dropped.
+type truncation struct {
+	events int64 // the number of events dropped
+	bytes  int64 // the number of bytes of the dropped events
+	first  int64 // the nanotime the first event was dropped
+}
+
+func (t *truncation) add(size int) {
+	if t.events == 0 {
+		t.first = nanotime()
+	}
+	t.events++
+	t.bytes += int64(size)
+}
+
+// appendEvent appends a TraceTruncated event reporting the dropped
+// events to data. The event is timestamped with the time the first
+// event was dropped, so that it marks where the trace is incomplete.
+func (t *truncation) appendEvent(data []byte, policy TruncationPolicy) []byte {
+	var tb Buffer
+	tb.Byte(byte(policy))
+	tb.UVarint(uint64(t.events))
+	tb.UVarint(uint64(t.bytes))
+
+	var b [13]byte
+	b[0] = byte(TraceTruncated)
+	binary.LittleEndian.PutUint64(b[1:9], uint64(t.first))
+	binary.LittleEndian.PutUint32(b[9:13], uint32(len(tb.Buf())))
+	return append(append(data, b[:]...), tb.Buf()...)
+}
+
+// dropOldest drops the oldest events other than request starts and ends
+// from l.data until at least need more bytes fit within l.maxSize.
+// To avoid compacting the buffer for every event once it is full,
+// it frees a quarter of the maximum size at a time where possible.
+// It reports whether enough room was made.
+//
+// It must be called with l.mu held, and does not allocate,
+// as it may be called by system goroutines.
+func (l *Log) dropOldest(need int) bool {
+	target := len(l.data) + need - l.maxSize
+	if target > len(l.data) {
+		// The event does not fit even in an empty buffer.
+		return false
+	}
+	if slack := l.maxSize / 4; target < slack {
+		target = slack
+	}
+
+	data := l.data
+	freed, w := 0, 0
+	for r := 0; r+13 <= len(data); {
+		size := 13 + int(binary.LittleEndian.Uint32(data[r+9:r+13]))
+		typ := EventType(data[r])
+		if freed < target && !alwaysKept(typ) {
+			l.dropped.add(size)
+			freed += size
+		} else {
+			w += copy(data[w:], data[r:r+size])
+		}
+		r += size
+	}
+	l.data = data[:w]
+	return len(l.data)+need <= l.maxSize
+}
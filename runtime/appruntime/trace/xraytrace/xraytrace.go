@@ -0,0 +1,262 @@
+// Package xraytrace exports traces to AWS X-Ray, by sending them
+// as segment documents to an X-Ray daemon over UDP.
+//
+// The daemon, or the AWS Distro for OpenTelemetry collector, runs
+// alongside the application and forwards the segments to X-Ray:
+//
+//	exp, err := xraytrace.New(xraytrace.Config{
+//		Endpoint:    "127.0.0.1:2000",
+//		ServiceName: "my-app",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	rt.AddTraceExporter(exp)
+//
+// X-Ray requires trace ids to begin with the time the trace started,
+// in Unix seconds. Enabling model.GenerateTimePrefixedTraceIDs makes
+// Encore generate such trace ids.
+package xraytrace
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+// DefaultEndpoint is the address the X-Ray daemon listens on by default.
+const DefaultEndpoint = "127.0.0.1:2000"
+
+// header begins every segment document sent to the daemon.
+const header = `{"format": "json", "version": 1}` + "\n"
+
+// maxDocumentSize is the maximum size of a segment document,
+// as the daemon reads each from a single UDP packet.
+const maxDocumentSize = 64<<10 - len(header)
+
+// Config configures an Exporter.
+type Config struct {
+	// Endpoint is the UDP address of the X-Ray daemon.
+	// It defaults to DefaultEndpoint.
+	Endpoint string
+
+	// ServiceName is the name of the segments recorded for requests
+	// to services that have no name of their own, and is the name
+	// X-Ray shows in its service map.
+	ServiceName string
+
+	// ResourceAttributes are reported as annotations of each segment,
+	// such as "deployment.environment".
+	ResourceAttributes map[string]string
+}
+
+// Exporter exports traces to an X-Ray daemon.
+// It implements trace.Exporter.
+type Exporter struct {
+	cfg  Config
+	conn net.Conn
+}
+
+var _ trace.Exporter = (*Exporter)(nil)
+
+// New creates an Exporter that exports to the daemon configured by cfg.
+func New(cfg Config) (*Exporter, error) {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultEndpoint
+	}
+	conn, err := net.Dial("udp", cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("xraytrace: %v", err)
+	}
+	return &Exporter{cfg: cfg, conn: conn}, nil
+}
+
+// ExportTrace decodes the spans in data and sends them to the daemon.
+// Spans of requests are sent as segments, and other spans as
+// subsegments of the request they belong to.
+func (e *Exporter) ExportTrace(ctx context.Context, data []byte) error {
+	spans, err := trace.DecodeSpans(data)
+	if err != nil {
+		return err
+	}
+	for _, s := range spans {
+		doc, err := e.encodeSegment(s)
+		if err != nil {
+			return err
+		}
+		if _, err := e.conn.Write(doc); err != nil {
+			return fmt.Errorf("xraytrace: %v", err)
+		}
+	}
+	return nil
+}
+
+// Shutdown closes the connection to the daemon.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return e.conn.Close()
+}
+
+// segment is an X-Ray segment or subsegment document.
+type segment struct {
+	Name        string         `json:"name"`
+	ID          string         `json:"id"`
+	TraceID     string         `json:"trace_id"`
+	ParentID    string         `json:"parent_id,omitempty"`
+	Type        string         `json:"type,omitempty"` // "subsegment" for subsegments
+	Namespace   string         `json:"namespace,omitempty"`
+	StartTime   float64        `json:"start_time"`
+	EndTime     float64        `json:"end_time"`
+	Fault       bool           `json:"fault,omitempty"`
+	Cause       *cause         `json:"cause,omitempty"`
+	HTTP        *httpInfo      `json:"http,omitempty"`
+	Annotations map[string]any `json:"annotations,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+type cause struct {
+	Exceptions []exception `json:"exceptions"`
+}
+
+type exception struct {
+	Message string `json:"message"`
+}
+
+type httpInfo struct {
+	Request httpRequest `json:"request"`
+}
+
+type httpRequest struct {
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type event struct {
+	Time  float64        `json:"time"`
+	Name  string         `json:"name"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// encodeSegment encodes s as a segment document, prefixed by the header.
+// Server and consumer spans are encoded as segments, named after the
+// service they belong to, and other spans as subsegments.
+func (e *Exporter) encodeSegment(s trace.Span) ([]byte, error) {
+	seg := segment{
+		Name:      s.Name,
+		ID:        hex.EncodeToString(s.SpanID[:]),
+		TraceID:   FormatTraceID(s.TraceID),
+		StartTime: unixSeconds(s.Start),
+		EndTime:   unixSeconds(s.End),
+	}
+	if !s.ParentID.IsZero() {
+		seg.ParentID = hex.EncodeToString(s.ParentID[:])
+	}
+
+	attrs := make(map[string]any, len(s.Attrs))
+	for _, a := range s.Attrs {
+		attrs[a.Key] = a.Value
+	}
+	switch s.Kind {
+	case trace.SpanKindServer, trace.SpanKindConsumer:
+		seg.Name = e.cfg.ServiceName
+		if svc, ok := attrs["encore.service"].(string); ok {
+			seg.Name = svc
+		}
+		seg.Annotations = make(map[string]any, len(e.cfg.ResourceAttributes)+1)
+		for k, v := range e.cfg.ResourceAttributes {
+			seg.Annotations[annotationKey(k)] = v
+		}
+		seg.Annotations["encore_operation"] = s.Name
+	case trace.SpanKindClient, trace.SpanKindProducer:
+		seg.Type = "subsegment"
+		seg.Namespace = "remote"
+	default:
+		seg.Type = "subsegment"
+	}
+	if method, _ := attrs["http.method"].(string); method != "" {
+		url, _ := attrs["http.url"].(string)
+		if url == "" {
+			url, _ = attrs["http.target"].(string)
+		}
+		seg.HTTP = &httpInfo{Request: httpRequest{Method: method, URL: url}}
+	}
+	if s.Err != "" {
+		seg.Fault = true
+		seg.Cause = &cause{Exceptions: []exception{{Message: s.Err}}}
+	}
+
+	metadata := map[string]any{}
+	if len(attrs) > 0 {
+		metadata["attributes"] = attrs
+	}
+	if len(s.Events) > 0 {
+		events := make([]event, len(s.Events))
+		for i, ev := range s.Events {
+			events[i] = event{Time: unixSeconds(ev.Time), Name: ev.Name}
+			if len(ev.Attrs) > 0 {
+				events[i].Attrs = make(map[string]any, len(ev.Attrs))
+				for _, a := range ev.Attrs {
+					events[i].Attrs[a.Key] = a.Value
+				}
+			}
+		}
+		metadata["events"] = events
+	}
+	// X-Ray has no concept of span links, so s.Links are not exported.
+	if len(metadata) > 0 {
+		seg.Metadata = map[string]any{"encore": metadata}
+	}
+
+	doc, err := json.Marshal(seg)
+	if err == nil && len(doc) > maxDocumentSize {
+		// Drop the metadata rather than the whole segment.
+		seg.Metadata = nil
+		doc, err = json.Marshal(seg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(header), doc...), nil
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+// annotationKey replaces the characters X-Ray does not allow
+// in annotation keys with underscores.
+func annotationKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, k)
+}
+
+// FormatTraceID formats id in the X-Ray trace id format, such as
+// "1-5759e988-bd862e3fe1be46a994272793", whose second part
+// is the first four bytes of id.
+func FormatTraceID(id model.TraceID) string {
+	return "1-" + hex.EncodeToString(id[:4]) + "-" + hex.EncodeToString(id[4:])
+}
+
+// ParseTraceID parses a trace id in the X-Ray trace id format.
+func ParseTraceID(s string) (id model.TraceID, ok bool) {
+	if len(s) != 35 || s[:2] != "1-" || s[10] != '-' {
+		return model.TraceID{}, false
+	}
+	if _, err := hex.Decode(id[:4], []byte(s[2:10])); err != nil {
+		return model.TraceID{}, false
+	}
+	if _, err := hex.Decode(id[4:], []byte(s[11:])); err != nil {
+		return model.TraceID{}, false
+	}
+	return id, !id.IsZero()
+}
@@ -0,0 +1,75 @@
+package xraytrace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+func TestExport(t *testing.T) {
+	daemon, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer daemon.Close()
+
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{0x5f, 1, 2, 3, 4},
+		SpanID:  model.SpanID{4, 5, 6},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Hello"}},
+	}
+	log := &trace.Log{}
+	log.BeginRequest(req, 1)
+	log.DBQueryStart(trace.DBQueryStartParams{Query: "SELECT 1", SpanID: req.SpanID, QueryID: 1})
+	log.DBQueryEnd(1, errors.New("boom"))
+	log.FinishRequest(req, &model.Response{})
+
+	exp, err := New(Config{
+		Endpoint:           daemon.LocalAddr().String(),
+		ResourceAttributes: map[string]string{"deployment.environment": "prod"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exp.Shutdown(context.Background())
+	if err := exp.ExportTrace(context.Background(), log.GetAndClear()); err != nil {
+		t.Fatalf("ExportTrace: %v", err)
+	}
+
+	var segs []segment
+	buf := make([]byte, 64<<10)
+	for i := 0; i < 2; i++ {
+		_ = daemon.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := daemon.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := string(buf[:n])
+		if !strings.HasPrefix(doc, header) {
+			t.Fatalf("got document %q without header", doc)
+		}
+		var seg segment
+		if err := json.Unmarshal([]byte(doc[len(header):]), &seg); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		segs = append(segs, seg)
+	}
+
+	reqSeg, query := segs[0], segs[1]
+	if reqSeg.TraceID != "1-5f010203-040000000000000000000000" || reqSeg.ID != "0405060000000000" ||
+		reqSeg.Name != "svc" || reqSeg.Type != "" || reqSeg.Annotations["deployment_environment"] != "prod" {
+		t.Errorf("got request segment %+v", reqSeg)
+	}
+	if query.TraceID != reqSeg.TraceID || query.ParentID != reqSeg.ID || query.Type != "subsegment" ||
+		query.Namespace != "remote" || !query.Fault || query.Cause.Exceptions[0].Message != "boom" {
+		t.Errorf("got query subsegment %+v", query)
+	}
+}
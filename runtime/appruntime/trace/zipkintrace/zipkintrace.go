@@ -0,0 +1,203 @@
+// Package zipkintrace exports traces to a Zipkin collector,
+// using the Zipkin JSON v2 format over HTTP.
+//
+// It is an alternative to the otlptrace package for collectors
+// that do not support OTLP:
+//
+//	exp, err := zipkintrace.New(zipkintrace.Config{
+//		Endpoint:    "http://zipkin:9411/api/v2/spans",
+//		ServiceName: "my-app",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	rt.AddTraceExporter(exp)
+package zipkintrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"encore.dev/appruntime/trace"
+)
+
+// Config configures an Exporter.
+type Config struct {
+	// Endpoint is the URL of the collector's span endpoint,
+	// such as "http://localhost:9411/api/v2/spans".
+	Endpoint string
+
+	// Headers are additional HTTP headers sent with each export,
+	// typically used for authentication.
+	Headers map[string]string
+
+	// ServiceName is reported as the service name of each span's local endpoint.
+	ServiceName string
+
+	// ResourceAttributes are reported as tags of each span,
+	// as Zipkin has no concept of resources.
+	ResourceAttributes map[string]string
+
+	// HTTPClient is the client used to export traces.
+	// It defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Exporter exports traces to a Zipkin collector.
+// It implements trace.Exporter.
+type Exporter struct {
+	cfg Config
+}
+
+var _ trace.Exporter = (*Exporter)(nil)
+
+// New creates an Exporter that exports to the collector configured by cfg.
+func New(cfg Config) (*Exporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("zipkintrace: no endpoint configured")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &Exporter{cfg: cfg}, nil
+}
+
+// ExportTrace decodes the spans in data and exports them,
+// waiting until they have been exported or ctx is done.
+func (e *Exporter) ExportTrace(ctx context.Context, data []byte) error {
+	decoded, err := trace.DecodeSpans(data)
+	if err != nil {
+		return err
+	} else if len(decoded) == 0 {
+		return nil
+	}
+
+	spans := make([]span, len(decoded))
+	for i, s := range decoded {
+		spans[i] = e.convertSpan(s)
+	}
+	body, err := json.Marshal(spans)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("zipkintrace: collector responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Shutdown does nothing, as the exporter holds no resources.
+// It exists for symmetry with the other exporters.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// span is a span in the Zipkin JSON v2 format.
+type span struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Kind          string            `json:"kind,omitempty"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint *endpoint         `json:"localEndpoint,omitempty"`
+	Annotations   []annotation      `json:"annotations,omitempty"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+type endpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type annotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+func (e *Exporter) convertSpan(s trace.Span) span {
+	zs := span{
+		TraceID:   hex.EncodeToString(s.TraceID[:]),
+		ID:        hex.EncodeToString(s.SpanID[:]),
+		Name:      s.Name,
+		Kind:      spanKind(s.Kind),
+		Timestamp: s.Start.UnixNano() / 1000,
+		Duration:  s.End.Sub(s.Start).Microseconds(),
+		Tags:      make(map[string]string, len(s.Attrs)+len(e.cfg.ResourceAttributes)),
+	}
+	if !s.ParentID.IsZero() {
+		zs.ParentID = hex.EncodeToString(s.ParentID[:])
+	}
+	if e.cfg.ServiceName != "" {
+		zs.LocalEndpoint = &endpoint{ServiceName: e.cfg.ServiceName}
+	}
+	for k, v := range e.cfg.ResourceAttributes {
+		zs.Tags[k] = v
+	}
+	for _, a := range s.Attrs {
+		zs.Tags[a.Key] = fmt.Sprint(a.Value)
+	}
+	if s.Err != "" {
+		zs.Tags["error"] = s.Err
+	}
+	for _, ev := range s.Events {
+		zs.Annotations = append(zs.Annotations, annotation{
+			Timestamp: ev.Time.UnixNano() / 1000,
+			Value:     annotationValue(ev),
+		})
+	}
+	// Zipkin has no concept of span links, so s.Links are not exported.
+	return zs
+}
+
+// annotationValue formats ev as a string, since Zipkin annotations
+// cannot have attributes: the event name followed by its sorted
+// attributes, such as "log level=info message=hello".
+func annotationValue(ev trace.SpanEvent) string {
+	attrs := make([]string, 0, len(ev.Attrs))
+	for _, a := range ev.Attrs {
+		attrs = append(attrs, fmt.Sprintf("%s=%v", a.Key, a.Value))
+	}
+	sort.Strings(attrs)
+	return strings.Join(append([]string{ev.Name}, attrs...), " ")
+}
+
+// spanKind returns the Zipkin span kind of kind,
+// or "" for internal spans.
+func spanKind(kind trace.SpanKind) string {
+	switch kind {
+	case trace.SpanKindServer:
+		return "SERVER"
+	case trace.SpanKindClient:
+		return "CLIENT"
+	case trace.SpanKindProducer:
+		return "PRODUCER"
+	case trace.SpanKindConsumer:
+		return "CONSUMER"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,60 @@
+package zipkintrace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/trace"
+)
+
+func TestExport(t *testing.T) {
+	var spans []span
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("got content type %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&spans); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	req := &model.Request{
+		Type:    model.RPCCall,
+		TraceID: model.TraceID{1, 2, 3},
+		SpanID:  model.SpanID{4, 5, 6},
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "svc", Endpoint: "Hello"}},
+	}
+	log := &trace.Log{}
+	log.BeginRequest(req, 1)
+	log.DBQueryStart(trace.DBQueryStartParams{Query: "SELECT 1", SpanID: req.SpanID, QueryID: 1})
+	log.DBQueryEnd(1, errors.New("boom"))
+	log.FinishRequest(req, &model.Response{})
+
+	exp, err := New(Config{Endpoint: srv.URL, ServiceName: "my-app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exp.ExportTrace(context.Background(), log.GetAndClear()); err != nil {
+		t.Fatalf("ExportTrace: %v", err)
+	}
+
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	reqSpan, query := spans[0], spans[1]
+	if reqSpan.TraceID != "01020300000000000000000000000000" || reqSpan.ID != "0405060000000000" ||
+		reqSpan.ParentID != "" || reqSpan.Name != "svc.Hello" || reqSpan.Kind != "SERVER" ||
+		reqSpan.LocalEndpoint == nil || reqSpan.LocalEndpoint.ServiceName != "my-app" {
+		t.Errorf("got request span %+v", reqSpan)
+	}
+	if query.TraceID != reqSpan.TraceID || query.ParentID != reqSpan.ID || query.Kind != "CLIENT" ||
+		query.Tags["db.statement"] != "SELECT 1" || query.Tags["error"] != "boom" {
+		t.Errorf("got query span %+v", query)
+	}
+}
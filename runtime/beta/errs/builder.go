@@ -2,6 +2,7 @@ package errs
 
 import (
 	"fmt"
+	"time"
 
 	"encore.dev/internal/stack"
 )
@@ -15,8 +16,12 @@ type Builder struct {
 	codeSet bool
 	det     ErrDetails
 	detSet  bool
+	typed   []ErrDetails
+
+	fullStack bool
 
 	msg  string
+	tmpl *msgTemplate
 	meta []interface{}
 	err  error
 }
@@ -34,12 +39,26 @@ func (b *Builder) Code(c ErrCode) *Builder {
 // Msg sets the error message.
 func (b *Builder) Msg(msg string) *Builder {
 	b.msg = msg
+	b.tmpl = nil
 	return b
 }
 
 // Msgf is like Msg but uses fmt.Sprintf to construct the message.
 func (b *Builder) Msgf(format string, args ...interface{}) *Builder {
 	b.msg = fmt.Sprintf(format, args...)
+	b.tmpl = nil
+	return b
+}
+
+// MsgID sets the error message to the localized message with the given ID,
+// rendered with the argument key-value pairs. The message is rendered in
+// DefaultLocale, and in the locale of the request when returned from an API.
+// If no locale has a template for id, id itself is used as the template.
+//
+// See RegisterMessages.
+func (b *Builder) MsgID(id string, argPairs ...interface{}) *Builder {
+	b.tmpl = &msgTemplate{id: id, args: mergeMeta(nil, argPairs)}
+	b.msg = b.tmpl.render(DefaultLocale)
 	return b
 }
 
@@ -56,6 +75,26 @@ func (b *Builder) Details(det ErrDetails) *Builder {
 	return b
 }
 
+// TypedDetail appends a typed detail. See RegisterDetail.
+func (b *Builder) TypedDetail(d ErrDetails) *Builder {
+	b.typed = append(b.typed, d)
+	return b
+}
+
+// Retryable marks the error as retryable, optionally waiting
+// for after before retrying. See RetryInfo.
+func (b *Builder) Retryable(after time.Duration) *Builder {
+	return b.TypedDetail(RetryInfo{After: after})
+}
+
+// Stack requests capturing the complete stack of the error, regardless
+// of the configured stack depth. See SetStackDepth.
+// If the cause has a stack, it is used instead.
+func (b *Builder) Stack() *Builder {
+	b.fullStack = true
+	return b
+}
+
 // Cause sets the underlying error cause.
 func (b *Builder) Cause(err error) *Builder {
 	b.err = err
@@ -90,20 +129,27 @@ func (b *Builder) Err() error {
 	}
 
 	var errMeta Metadata
+	var typed []ErrDetails
 	var s stack.Stack
 	if e, ok := b.err.(*Error); ok {
 		errMeta = e.Meta
+		typed = append(typed, e.TypedDetails...)
 		s = e.stack
-	} else {
+	} else if !b.fullStack {
+		s = buildStack(2, code)
+	}
+	if b.fullStack && len(s.Frames) == 0 {
 		s = stack.Build(2)
 	}
 
 	return &Error{
-		Code:       code,
-		Message:    msg,
-		Meta:       mergeMeta(errMeta, b.meta),
-		Details:    b.det,
-		underlying: b.err,
-		stack:      s,
+		Code:         code,
+		Message:      msg,
+		tmpl:         b.tmpl,
+		Meta:         mergeMeta(errMeta, b.meta),
+		Details:      b.det,
+		TypedDetails: append(typed, b.typed...),
+		underlying:   b.err,
+		stack:        s,
 	}
 }
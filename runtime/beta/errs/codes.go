@@ -159,14 +159,26 @@ const (
 // String returns the string representation of c.
 //publicapigen:keep
 func (c ErrCode) String() string {
-	return codeNames[c]
+	if c >= 0 && int(c) < len(codeNames) {
+		return codeNames[c]
+	}
+	if cc, ok := c.custom(); ok {
+		return cc.name
+	}
+	return codeNames[Unknown]
 }
 
 // HTTPStatus reports a suitable HTTP status code for an error, based on its code.
 // If err is nil it reports 200. If it's not an *Error it reports 500.
 //publicapigen:keep
 func (c ErrCode) HTTPStatus() int {
-	return codeStatus[c]
+	if c >= 0 && int(c) < len(codeStatus) {
+		return codeStatus[c]
+	}
+	if cc, ok := c.custom(); ok {
+		return cc.status
+	}
+	return codeStatus[Unknown]
 }
 
 //publicapigen:keep
@@ -175,6 +187,22 @@ func (c ErrCode) MarshalJSON() ([]byte, error) {
 	return []byte("\"" + s + "\""), nil
 }
 
+//publicapigen:keep
+func (c *ErrCode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	customCodes.mu.RLock()
+	code, ok := lookupCode(s)
+	customCodes.mu.RUnlock()
+	if !ok {
+		code = Unknown
+	}
+	*c = code
+	return nil
+}
+
 //publicapigen:keep
 var codeNames = [...]string{
 	OK:                 "ok",
@@ -0,0 +1,99 @@
+package errs
+
+import (
+	"fmt"
+	"sync"
+)
+
+// firstCustomCode is the code returned by the first call to RegisterCode.
+const firstCustomCode ErrCode = 1000
+
+// customCode is an error code registered with RegisterCode.
+type customCode struct {
+	name   string
+	base   ErrCode
+	status int
+
+	// stackDepth is the code's stack depth; see SetCodeStackDepth.
+	// It is a pointer so that copies of the customCode share it.
+	stackDepth *int32
+}
+
+// customCodes are the registered codes, indexed by code - firstCustomCode.
+var customCodes struct {
+	mu   sync.RWMutex
+	list []customCode
+}
+
+// RegisterCode registers a custom error code for domain-specific errors,
+// such as "insufficient_funds", and returns it. The name identifies errors
+// with the code in API responses, traces and metrics, as the names of the
+// built-in codes do.
+//
+// The base code is the built-in code that best describes the errors, which
+// is used where custom codes are not supported, such as for gRPC status codes.
+// The errors are returned with the given HTTP status, or with the HTTP status
+// of the base code if httpStatus is zero.
+//
+// Custom codes are typically registered in package-level variables:
+//
+//	var InsufficientFunds = errs.RegisterCode("insufficient_funds", errs.FailedPrecondition, 402)
+//
+// It panics if the name is already in use or base is not a built-in code.
+func RegisterCode(name string, base ErrCode, httpStatus int) ErrCode {
+	if base < 0 || int(base) >= len(codeNames) {
+		panic(fmt.Sprintf("errs: base code of %q is not a built-in code: %d", name, base))
+	}
+	if httpStatus == 0 {
+		httpStatus = codeStatus[base]
+	}
+
+	customCodes.mu.Lock()
+	defer customCodes.mu.Unlock()
+	if _, ok := lookupCode(name); ok {
+		panic(fmt.Sprintf("errs: code %q is already registered", name))
+	}
+	depth := int32(-1)
+	customCodes.list = append(customCodes.list, customCode{name: name, base: base, status: httpStatus, stackDepth: &depth})
+	return firstCustomCode + ErrCode(len(customCodes.list)-1)
+}
+
+// custom returns the custom code c, reporting false if it is not registered.
+func (c ErrCode) custom() (customCode, bool) {
+	idx := int(c - firstCustomCode)
+	customCodes.mu.RLock()
+	defer customCodes.mu.RUnlock()
+	if idx < 0 || idx >= len(customCodes.list) {
+		return customCode{}, false
+	}
+	return customCodes.list[idx], true
+}
+
+// Base returns the built-in code that c is based on.
+// For built-in codes it returns c itself, and for
+// codes that are not registered it returns Unknown.
+func (c ErrCode) Base() ErrCode {
+	if c >= 0 && int(c) < len(codeNames) {
+		return c
+	}
+	if cc, ok := c.custom(); ok {
+		return cc.base
+	}
+	return Unknown
+}
+
+// lookupCode returns the built-in or custom code with the given name.
+// For custom codes, customCodes.mu must be held.
+func lookupCode(name string) (ErrCode, bool) {
+	for code, n := range codeNames {
+		if n == name {
+			return ErrCode(code), true
+		}
+	}
+	for i, cc := range customCodes.list {
+		if cc.name == name {
+			return firstCustomCode + ErrCode(i), true
+		}
+	}
+	return 0, false
+}
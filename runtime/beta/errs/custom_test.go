@@ -0,0 +1,47 @@
+package errs
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var insufficientFunds = RegisterCode("insufficient_funds", FailedPrecondition, 402)
+
+func TestRegisterCode(t *testing.T) {
+	if got := insufficientFunds.String(); got != "insufficient_funds" {
+		t.Errorf("got name %q", got)
+	}
+	if got := insufficientFunds.Base(); got != FailedPrecondition {
+		t.Errorf("got base %v", got)
+	}
+
+	err := B().Code(insufficientFunds).Msg("balance too low").Err()
+	if got := err.Error(); got != "insufficient_funds: balance too low" {
+		t.Errorf("got error %q", got)
+	}
+
+	w := httptest.NewRecorder()
+	HTTPError(w, err)
+	if w.Code != 402 || !strings.Contains(w.Body.String(), `"code": "insufficient_funds"`) {
+		t.Errorf("got response %d %s", w.Code, w.Body.String())
+	}
+	var decoded Error
+	if err := json.Unmarshal(w.Body.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Code != insufficientFunds {
+		t.Errorf("got decoded code %v", decoded.Code)
+	}
+
+	if got := ErrCode(5000).String(); got != "unknown" {
+		t.Errorf("got %q for an unregistered code", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a code twice did not panic")
+		}
+	}()
+	RegisterCode("not_found", NotFound, 0)
+}
@@ -4,6 +4,7 @@
 package errs
 
 import (
+	stdjson "encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -25,7 +26,7 @@ var json = jsoniter.Config{
 // optionally additional structured details about the error
 // and arbitrary key-value metadata.
 //
-// The Details field is returned to external clients.
+// The Details and TypedDetails fields are returned to external clients.
 // The Meta field is only exposed to internal calls within Encore.
 //
 // Internally it captures an underlying error for printing
@@ -41,10 +42,25 @@ type Error struct {
 	Message string `json:"message"`
 	// Details are user-defined additional details.
 	Details ErrDetails `json:"details"`
+	// TypedDetails are additional details identified by the name of
+	// their type, which are decoded back into their types across
+	// service boundaries. See RegisterDetail and TypedDetail.
+	TypedDetails []ErrDetails `json:"typed_details,omitempty"`
 	// Meta are arbitrary key-value pairs for use within
 	// the Encore application. They are not exposed to external clients.
 	Meta Metadata `json:"-"`
 
+	// tmpl is the template of the message, if it is localized.
+	tmpl *msgTemplate
+
+	// withCauses specifies whether the JSON encoding of the error
+	// includes the messages of the underlying errors. See WithCauses.
+	withCauses bool
+
+	// joined are the errors joined into this error by Join.
+	// They are not propagated across RPC boundaries.
+	joined []error
+
 	// underlying is the underlying error,
 	// for use with errors.Is and errors.As.
 	// It is not propagated across RPC boundaries.
@@ -61,7 +77,7 @@ type Metadata map[string]interface{}
 // Wrap wraps the err, adding additional error information.
 // If err is nil it returns nil.
 //
-// If err is already an *Error its code, message, and details
+// If err is already an *Error its code, message, details and typed details
 // are copied over to the new error.
 func Wrap(err error, msg string, metaPairs ...interface{}) error {
 	if err == nil {
@@ -71,12 +87,13 @@ func Wrap(err error, msg string, metaPairs ...interface{}) error {
 	e := &Error{Code: Unknown, Message: msg, underlying: err}
 	if ee, ok := err.(*Error); ok {
 		e.Details = ee.Details
+		e.TypedDetails = ee.TypedDetails
 		e.Code = ee.Code
 		e.Meta = mergeMeta(ee.Meta, metaPairs)
 		e.stack = ee.stack
 	} else {
 		e.Meta = mergeMeta(nil, metaPairs)
-		e.stack = stack.Build(2)
+		e.stack = buildStack(2, e.Code)
 	}
 	return e
 }
@@ -91,12 +108,13 @@ func WrapCode(err error, code ErrCode, msg string, metaPairs ...interface{}) err
 	e := &Error{Code: code, Message: msg, underlying: err}
 	if ee, ok := err.(*Error); ok {
 		e.Details = ee.Details
+		e.TypedDetails = ee.TypedDetails
 		e.Code = ee.Code
 		e.Meta = mergeMeta(ee.Meta, metaPairs)
 		e.stack = ee.stack
 	} else {
 		e.Meta = mergeMeta(nil, metaPairs)
-		e.stack = stack.Build(2)
+		e.stack = buildStack(2, e.Code)
 	}
 	return e
 }
@@ -113,7 +131,7 @@ func Convert(err error) error {
 	return &Error{
 		Code:       Unknown,
 		underlying: err,
-		stack:      stack.Build(2),
+		stack:      buildStack(2, Unknown),
 	}
 }
 
@@ -228,7 +246,57 @@ func init() {
 		stream.WriteMore()
 		stream.WriteObjectField("details")
 		stream.WriteVal(e.Details)
+		if causes := e.causes(); e.withCauses && len(causes) > 0 {
+			stream.WriteMore()
+			stream.WriteObjectField("causes")
+			stream.WriteVal(causes)
+		}
+		if len(e.TypedDetails) > 0 {
+			stream.WriteMore()
+			stream.WriteObjectField("typed_details")
+			stream.WriteArrayStart()
+			for i, d := range e.TypedDetails {
+				if i > 0 {
+					stream.WriteMore()
+				}
+				ed, err := encodeDetail(d)
+				if err != nil {
+					stream.Error = err
+					return
+				}
+				stream.WriteVal(ed)
+			}
+			stream.WriteArrayEnd()
+		}
 		stream.WriteObjectEnd()
 	}, nil)
 
 }
+
+// UnmarshalJSON decodes an error from its JSON encoding, as written
+// by HTTPError. Typed details of registered types are decoded into
+// their types, and other typed details into RawDetail values.
+// Untyped details are decoded into a RawDetail with an empty type.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var enc struct {
+		Code         ErrCode            `json:"code"`
+		Message      string             `json:"message"`
+		Details      stdjson.RawMessage `json:"details"`
+		TypedDetails []encodedDetail    `json:"typed_details"`
+	}
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+	*e = Error{Code: enc.Code, Message: enc.Message}
+	if len(enc.Details) > 0 && string(enc.Details) != "null" {
+		e.Details = RawDetail{Value: append(stdjson.RawMessage(nil), enc.Details...)}
+	}
+	for _, ed := range enc.TypedDetails {
+		d, err := decodeDetail(ed)
+		if err != nil {
+			return err
+		}
+		e.TypedDetails = append(e.TypedDetails, d)
+	}
+	return nil
+}
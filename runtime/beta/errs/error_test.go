@@ -0,0 +1,31 @@
+package errs
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithCauses(t *testing.T) {
+	err := Wrap(WrapCode(errors.New("sql: no rows"), NotFound, "no user"), "get profile")
+
+	w := httptest.NewRecorder()
+	HTTPError(w, err)
+	if strings.Contains(w.Body.String(), "causes") {
+		t.Errorf("got causes without WithCauses: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	HTTPError(w, WithCauses(err))
+	var got struct {
+		Code   ErrCode  `json:"code"`
+		Causes []string `json:"causes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Code != NotFound || strings.Join(got.Causes, "|") != "no user|sql: no rows" {
+		t.Errorf("got code %v, causes %q", got.Code, got.Causes)
+	}
+}
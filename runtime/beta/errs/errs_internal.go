@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"encore.dev/internal/stack"
 )
@@ -56,7 +58,8 @@ func RoundTrip(err error) error {
 		e2 := &Error{
 			Code:    e.Code,
 			Message: e.Message,
-			stack:   stack.Build(3), // skip caller of RoundTrip as well
+			tmpl:    e.tmpl,
+			stack:   buildStack(3, e.Code), // skip caller of RoundTrip as well
 		}
 
 		if e.underlying != nil {
@@ -88,11 +91,16 @@ func RoundTrip(err error) error {
 			}
 		}
 
-		// Copy meta
+		// Copy typed details
+		if len(e.TypedDetails) > 0 {
+			e2.TypedDetails = copyTypedDetails(e.TypedDetails)
+		}
+
+		// Copy meta, without the values marked as sensitive
 		if e.Meta != nil {
 			var buf bytes.Buffer
 			enc := gob.NewEncoder(&buf)
-			if err := enc.Encode(e.Meta); err != nil {
+			if err := enc.Encode(e.Meta.scrub(false)); err != nil {
 				log.Printf("failed to encode error metadata: %v", err)
 			} else {
 				dec := gob.NewDecoder(&buf)
@@ -107,9 +115,39 @@ func RoundTrip(err error) error {
 		return &Error{
 			Code:    Unknown,
 			Message: err.Error(),
-			stack:   stack.Build(3), // skip caller of RoundTrip as well
+			stack:   buildStack(3, Unknown), // skip caller of RoundTrip as well
+		}
+	}
+}
+
+// WithCauses returns a copy of err whose JSON encoding, as written
+// by HTTPError, includes the messages of the errors it wraps as "causes",
+// outermost first. Stacks are never included.
+// If err is not an *Error it is converted to one.
+func WithCauses(err error) error {
+	if err == nil {
+		return nil
+	}
+	e := *(Convert(err).(*Error))
+	e.withCauses = true
+	return &e
+}
+
+// causes returns the messages of the errors underlying e, outermost first.
+func (e *Error) causes() []string {
+	var causes []string
+	for next := e.underlying; next != nil; {
+		if ee, ok := next.(*Error); ok {
+			if ee.Message != "" {
+				causes = append(causes, ee.Message)
+			}
+			next = ee.underlying
+		} else {
+			causes = append(causes, next.Error())
+			next = nil
 		}
 	}
+	return causes
 }
 
 func HTTPStatus(err error) int {
@@ -150,7 +188,7 @@ func HTTPStatus(err error) int {
 	case Unauthenticated:
 		return 401
 	default:
-		return 500
+		return code.HTTPStatus()
 	}
 }
 
@@ -158,6 +196,8 @@ func HTTPStatus(err error) int {
 // The given status code is used if it is non-zero, and otherwise
 // it is computed with HTTPStatus.
 //
+// If err is retryable with a delay, the Retry-After header is set.
+//
 // If err is nil it writes:
 //
 //	{"code": "ok", "message": "", "details": null}
@@ -166,8 +206,7 @@ func HTTPErrorWithCode(w http.ResponseWriter, err error, code int) {
 		code = HTTPStatus(err)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
+	setErrorHeaders(w, err, "application/json")
 
 	if err == nil {
 		w.WriteHeader(code)
@@ -190,3 +229,41 @@ func HTTPErrorWithCode(w http.ResponseWriter, err error, code int) {
 	w.WriteHeader(code)
 	w.Write(data)
 }
+
+// HTTPProblemWithCode is like HTTPProblem but writes the given status code
+// if it is non-zero, and otherwise the one computed with HTTPStatus.
+// If err is nil it writes the problem details of an OK error.
+func HTTPProblemWithCode(w http.ResponseWriter, err error, code int, instance string) {
+	if code == 0 {
+		code = HTTPStatus(err)
+	}
+	setErrorHeaders(w, err, ProblemContentType)
+
+	e := &Error{Code: OK}
+	if err != nil {
+		e = Convert(err).(*Error)
+	}
+	p, err2 := newProblem(e, code, instance)
+	var data []byte
+	if err2 == nil {
+		data, err2 = json.MarshalIndent(p, "", "  ")
+	}
+	if err2 != nil {
+		// Must be the details; drop them
+		p, _ = newProblem(&Error{Code: e.Code, Message: e.Message}, code, instance)
+		data, _ = json.MarshalIndent(p, "", "  ")
+	}
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+// setErrorHeaders sets the headers of a response with the error err,
+// encoded with the given content type.
+func setErrorHeaders(w http.ResponseWriter, err error, contentType string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if after := RetryAfter(err); after > 0 {
+		// Retry-After is in whole seconds; round up to not retry too early.
+		w.Header().Set("Retry-After", strconv.FormatInt(int64((after+time.Second-1)/time.Second), 10))
+	}
+}
@@ -0,0 +1,107 @@
+// Package errsgrpc translates between Encore errors and gRPC status errors,
+// for applications exposing or calling gRPC services.
+package errsgrpc
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encore.dev/beta/errs"
+)
+
+// toGRPC maps error codes to their gRPC equivalents.
+var toGRPC = map[errs.ErrCode]codes.Code{
+	errs.OK:                 codes.OK,
+	errs.Canceled:           codes.Canceled,
+	errs.Unknown:            codes.Unknown,
+	errs.InvalidArgument:    codes.InvalidArgument,
+	errs.DeadlineExceeded:   codes.DeadlineExceeded,
+	errs.NotFound:           codes.NotFound,
+	errs.AlreadyExists:      codes.AlreadyExists,
+	errs.PermissionDenied:   codes.PermissionDenied,
+	errs.ResourceExhausted:  codes.ResourceExhausted,
+	errs.FailedPrecondition: codes.FailedPrecondition,
+	errs.Aborted:            codes.Aborted,
+	errs.OutOfRange:         codes.OutOfRange,
+	errs.Unimplemented:      codes.Unimplemented,
+	errs.Internal:           codes.Internal,
+	errs.Unavailable:        codes.Unavailable,
+	errs.DataLoss:           codes.DataLoss,
+	errs.Unauthenticated:    codes.Unauthenticated,
+}
+
+// fromGRPC maps gRPC codes to their error code equivalents.
+var fromGRPC = func() map[codes.Code]errs.ErrCode {
+	m := make(map[codes.Code]errs.ErrCode, len(toGRPC))
+	for code, grpcCode := range toGRPC {
+		m[grpcCode] = code
+	}
+	return m
+}()
+
+// Code returns the gRPC code equivalent to c, or for custom codes,
+// to the code they are based on. Unrecognized codes map to codes.Unknown.
+func Code(c errs.ErrCode) codes.Code {
+	if grpcCode, ok := toGRPC[c.Base()]; ok {
+		return grpcCode
+	}
+	return codes.Unknown
+}
+
+// ErrCode returns the error code equivalent to the gRPC code c.
+// Unrecognized codes map to errs.Unknown.
+func ErrCode(c codes.Code) errs.ErrCode {
+	if code, ok := fromGRPC[c]; ok {
+		return code
+	}
+	return errs.Unknown
+}
+
+// Status converts err to a gRPC status, with the equivalent
+// code and the error message. If err is nil it returns an OK status.
+//
+// Errors that are not *errs.Error have code codes.Unknown.
+// If err already carries a gRPC status, that status is returned.
+func Status(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	var e *errs.Error
+	if errors.As(err, &e) {
+		return status.New(Code(e.Code), e.ErrorMessage())
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	return status.New(codes.Unknown, err.Error())
+}
+
+// ToGRPC converts err to a gRPC status error, for returning
+// from gRPC handlers. If err is nil it returns nil.
+func ToGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	return Status(err).Err()
+}
+
+// FromGRPC converts err, as returned by a gRPC client,
+// to an *errs.Error with the equivalent code and the status message.
+// If err is nil it returns nil.
+//
+// Errors without a gRPC status are converted with errs.Convert.
+func FromGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return errs.Convert(err)
+	}
+	if st.Code() == codes.OK {
+		return nil
+	}
+	return errs.B().Code(ErrCode(st.Code())).Msg(st.Message()).Err()
+}
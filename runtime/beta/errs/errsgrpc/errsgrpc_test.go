@@ -0,0 +1,44 @@
+package errsgrpc
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encore.dev/beta/errs"
+)
+
+func TestCodes(t *testing.T) {
+	for code := errs.OK; code <= errs.Unauthenticated; code++ {
+		grpcCode := Code(code)
+		if grpcCode.String() == "" || ErrCode(grpcCode) != code {
+			t.Errorf("code %v maps to gRPC %v, which maps back to %v", code, grpcCode, ErrCode(grpcCode))
+		}
+	}
+	if got := ErrCode(codes.Code(100)); got != errs.Unknown {
+		t.Errorf("got %v for unrecognized gRPC code, want unknown", got)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	err := errs.Wrap(errs.B().Code(errs.NotFound).Msg("no user").Err(), "get user")
+	grpcErr := ToGRPC(err)
+	st, _ := status.FromError(grpcErr)
+	if st.Code() != codes.NotFound || st.Message() != "get user: no user" {
+		t.Errorf("got status %v", st)
+	}
+
+	back := FromGRPC(grpcErr)
+	if errs.Code(back) != errs.NotFound || back.(*errs.Error).Message != "get user: no user" {
+		t.Errorf("got error %v", back)
+	}
+
+	if got := Status(errors.New("boom")).Code(); got != codes.Unknown {
+		t.Errorf("got code %v for plain error, want unknown", got)
+	}
+	if ToGRPC(nil) != nil || FromGRPC(nil) != nil || FromGRPC(status.Error(codes.OK, "")) != nil {
+		t.Error("nil errors should convert to nil")
+	}
+}
@@ -0,0 +1,124 @@
+package errs
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrorList is a typed detail listing the errors joined into an error
+// with Join, so that clients can tell the failures apart.
+type ErrorList struct {
+	Errors []ErrorListItem `json:"errors"`
+}
+
+func (ErrorList) ErrDetails() {}
+
+// An ErrorListItem is an error in an ErrorList.
+type ErrorListItem struct {
+	Code    ErrCode `json:"code"`
+	Message string  `json:"message"`
+}
+
+func init() {
+	RegisterDetail[ErrorList]("encore.ErrorList")
+}
+
+// Join returns an error combining the given errors, for operations that
+// fail in several independent ways, such as processing a batch of items.
+// Nil errors are discarded, and if all errors are nil it returns nil.
+//
+// The error has the code of the joined errors if they all have the same
+// code, and Unknown otherwise. The codes and messages of the joined errors
+// are included as an ErrorList typed detail. Use Errors to get the errors
+// back from the joined error.
+func Join(errs ...error) error {
+	var joined []error
+	for _, err := range errs {
+		if err != nil {
+			joined = append(joined, err)
+		}
+	}
+	if len(joined) == 0 {
+		return nil
+	}
+
+	code := Code(joined[0])
+	list := ErrorList{Errors: make([]ErrorListItem, len(joined))}
+	msgs := make([]string, len(joined))
+	for i, err := range joined {
+		if Code(err) != code {
+			code = Unknown
+		}
+		msg := err.Error()
+		if e, ok := err.(*Error); ok {
+			msg = e.ErrorMessage()
+		}
+		list.Errors[i] = ErrorListItem{Code: Code(err), Message: msg}
+		msgs[i] = msg
+	}
+
+	var msg string
+	if len(joined) == 1 {
+		msg = msgs[0]
+	} else {
+		msg = strconv.Itoa(len(joined)) + " errors: " + strings.Join(msgs, "; ")
+	}
+	return &Error{
+		Code:         code,
+		Message:      msg,
+		TypedDetails: []ErrDetails{list},
+		joined:       joined,
+		stack:        buildStack(2, code),
+	}
+}
+
+// Errors returns the errors joined into err with Join.
+// If err was not created by Join it returns nil.
+//
+// Once the error has crossed a service boundary the errors are
+// *Error values with the codes and messages of the joined errors.
+func Errors(err error) []error {
+	e, ok := err.(*Error)
+	if !ok {
+		return nil
+	}
+	if e.joined != nil {
+		return e.joined
+	}
+	list, ok := TypedDetail[ErrorList](e)
+	if !ok {
+		return nil
+	}
+	errs := make([]error, len(list.Errors))
+	for i, item := range list.Errors {
+		errs[i] = &Error{Code: item.Code, Message: item.Message}
+	}
+	return errs
+}
+
+// A List collects errors to join with Join.
+// The zero value is ready for use.
+type List struct {
+	errs []error
+}
+
+// Add adds err to the list. Nil errors are discarded.
+func (l *List) Add(err error) {
+	if err != nil {
+		l.errs = append(l.errs, err)
+	}
+}
+
+// Len reports the number of errors in the list.
+func (l *List) Len() int {
+	return len(l.errs)
+}
+
+// Err joins the errors in the list with Join.
+// If the list is empty it returns nil.
+func (l *List) Err() error {
+	if len(l.errs) == 0 {
+		return nil
+	}
+	return Join(l.errs...)
+}
@@ -0,0 +1,46 @@
+package errs
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	if Join(nil, nil) != nil {
+		t.Error("joining nil errors did not return nil")
+	}
+
+	sentinel := errors.New("disk full")
+	var l List
+	l.Add(B().Code(NotFound).Msg("item 1 not found").Err())
+	l.Add(nil)
+	l.Add(B().Code(NotFound).Msg("item 3 not found").Err())
+	if err := l.Err(); Code(err) != NotFound {
+		t.Errorf("got code %v for errors with the same code", Code(err))
+	}
+	l.Add(sentinel)
+
+	err := l.Err()
+	if Code(err) != Unknown {
+		t.Errorf("got code %v for errors with different codes", Code(err))
+	}
+	if got := err.(*Error).Message; got != "3 errors: item 1 not found; item 3 not found; disk full" {
+		t.Errorf("got message %q", got)
+	}
+	if got := Errors(err); len(got) != 3 || got[2] != sentinel {
+		t.Errorf("got errors %v", got)
+	}
+
+	got := Errors(RoundTrip(err))
+	if len(got) != 3 || Code(got[0]) != NotFound || got[2].Error() != "unknown code: disk full" {
+		t.Errorf("got errors %v after round trip", got)
+	}
+
+	w := httptest.NewRecorder()
+	HTTPError(w, err)
+	if !strings.Contains(w.Body.String(), `"type": "encore.ErrorList"`) {
+		t.Errorf("response lacks error list: %s", w.Body.String())
+	}
+}
@@ -0,0 +1,164 @@
+package errs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of error messages
+// when no other locale is requested.
+const DefaultLocale = "en"
+
+// catalogs maps lowercased locales to their message templates, keyed by message ID.
+var catalogs = struct {
+	mu   sync.RWMutex
+	byID map[string]map[string]string
+}{byID: make(map[string]map[string]string)}
+
+// RegisterMessages registers the message templates of locale,
+// such as "en" or "pt-BR", keyed by message ID.
+// Templates reference the arguments of a message by name, as in
+// "no user named {name}". Registering the same ID for the same
+// locale again replaces its template.
+//
+// See Builder.MsgID for creating errors with localized messages.
+func RegisterMessages(locale string, templates map[string]string) {
+	locale = strings.ToLower(locale)
+	catalogs.mu.Lock()
+	defer catalogs.mu.Unlock()
+	catalog := catalogs.byID[locale]
+	if catalog == nil {
+		catalog = make(map[string]string, len(templates))
+		catalogs.byID[locale] = catalog
+	}
+	for id, tmpl := range templates {
+		catalog[id] = tmpl
+	}
+}
+
+// msgTemplate is the message ID and arguments of a localized message.
+type msgTemplate struct {
+	id   string
+	args Metadata
+}
+
+// render renders the message in locale, falling back to DefaultLocale
+// and then to the message ID itself if the locale lacks the message.
+func (t *msgTemplate) render(locale string) string {
+	catalogs.mu.RLock()
+	tmpl, ok := catalogs.byID[strings.ToLower(locale)][t.id]
+	if !ok {
+		tmpl, ok = catalogs.byID[DefaultLocale][t.id]
+	}
+	catalogs.mu.RUnlock()
+	if !ok {
+		tmpl = t.id
+	}
+	return renderTemplate(tmpl, t.args)
+}
+
+// renderTemplate replaces the {name} references in tmpl with the
+// corresponding arguments. References to unknown arguments are kept.
+func renderTemplate(tmpl string, args Metadata) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		b.WriteString(tmpl[:start])
+		if v, ok := args[tmpl[start+1:end]]; ok {
+			b.WriteString(fmt.Sprint(v))
+		} else {
+			b.WriteString(tmpl[start : end+1])
+		}
+		tmpl = tmpl[end+1:]
+	}
+	b.WriteString(tmpl)
+	return b.String()
+}
+
+// Localize returns err with the messages created with Builder.MsgID,
+// including those of wrapped errors, rendered in locale.
+// If locale is empty or err has no such messages it returns err unmodified.
+func Localize(err error, locale string) error {
+	if locale == "" {
+		return err
+	}
+	if e, ok := err.(*Error); ok && hasTemplate(e) {
+		return localize(e, locale)
+	}
+	return err
+}
+
+func hasTemplate(e *Error) bool {
+	for e != nil {
+		if e.tmpl != nil {
+			return true
+		}
+		e, _ = e.underlying.(*Error)
+	}
+	return false
+}
+
+func localize(e *Error, locale string) *Error {
+	e2 := *e
+	if e.tmpl != nil {
+		e2.Message = e.tmpl.render(locale)
+	}
+	if u, ok := e.underlying.(*Error); ok {
+		e2.underlying = localize(u, locale)
+	}
+	return &e2
+}
+
+// MatchLocale returns the registered locale best matching the
+// value of an Accept-Language header, such as "da, en-GB;q=0.8".
+// A language also matches its base language, so "en-GB" matches "en".
+// It returns "" if no registered locale matches.
+func MatchLocale(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return ""
+	}
+
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			if parsed, err := strconv.ParseFloat(params[len("q="):], 64); err == nil {
+				q = parsed
+			}
+		}
+		if tag != "" && tag != "*" && q > 0 {
+			tags = append(tags, weighted{strings.ToLower(tag), q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	catalogs.mu.RLock()
+	defer catalogs.mu.RUnlock()
+	for _, t := range tags {
+		if _, ok := catalogs.byID[t.tag]; ok {
+			return t.tag
+		}
+		if base, _, ok := strings.Cut(t.tag, "-"); ok {
+			if _, ok := catalogs.byID[base]; ok {
+				return base
+			}
+		}
+	}
+	return ""
+}
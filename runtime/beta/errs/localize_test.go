@@ -0,0 +1,52 @@
+package errs
+
+import (
+	"testing"
+)
+
+func init() {
+	RegisterMessages("en", map[string]string{"user.notfound": "no user named {name}"})
+	RegisterMessages("sv", map[string]string{"user.notfound": "ingen användare med namnet {name}"})
+}
+
+func TestLocalize(t *testing.T) {
+	err := B().Code(NotFound).MsgID("user.notfound", "name", "alice").Err()
+	if got := err.(*Error).Message; got != "no user named alice" {
+		t.Errorf("got default message %q", got)
+	}
+
+	wrapped := B().Cause(err).MsgID("could not load {what}", "what", "profile").Err()
+	if got := Localize(wrapped, "sv").(*Error).ErrorMessage(); got != "could not load profile: ingen användare med namnet alice" {
+		t.Errorf("got localized message %q", got)
+	}
+	if got := Localize(err, "de").(*Error).Message; got != "no user named alice" {
+		t.Errorf("got message %q for locale without the message", got)
+	}
+	if got := Localize(RoundTrip(err), "sv").(*Error).Message; got != "ingen användare med namnet alice" {
+		t.Errorf("got message %q after round trip", got)
+	}
+
+	plain := B().Code(NotFound).Msg("plain").Err()
+	if Localize(plain, "sv") != plain {
+		t.Error("error without localized messages was copied")
+	}
+}
+
+func TestMatchLocale(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"sv", "sv"},
+		{"da, sv-SE;q=0.8, en;q=0.7", "sv"},
+		{"en;q=0.5, sv;q=0.9", "sv"},
+		{"sv;q=0, en", "en"},
+		{"de, *", ""},
+	}
+	for _, tt := range tests {
+		if got := MatchLocale(tt.header); got != tt.want {
+			t.Errorf("MatchLocale(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package errs
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// PanicInfo describes a panic recovered while handling an API request.
+type PanicInfo struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+
+	// Service and Endpoint are the names of the endpoint being called.
+	Service, Endpoint string
+	// Middleware is the name of the middleware that panicked,
+	// as "pkg.Name", or "" if the endpoint handler panicked.
+	Middleware string
+}
+
+// A PanicHandler converts a panic recovered while handling an API request
+// into the error to respond with, such as an *Error with a custom code,
+// metadata and a user-friendly message. It is also the place to report
+// panics to error tracking services such as Sentry or Bugsnag.
+//
+// The context is that of the request. If the handler returns nil,
+// or itself panics, the default Internal error is used.
+type PanicHandler func(ctx context.Context, p PanicInfo) error
+
+// panicHandler holds the PanicHandler set with SetPanicHandler.
+var panicHandler atomic.Value
+
+// SetPanicHandler sets the handler converting panics recovered while
+// handling API requests into errors, replacing the default Internal
+// error describing the panic. If h is nil the default is restored.
+func SetPanicHandler(h PanicHandler) {
+	panicHandler.Store(h)
+}
+
+// ConvertPanic converts the recovered panic p into an error,
+// using the handler set with SetPanicHandler if there is one.
+func ConvertPanic(ctx context.Context, p PanicInfo) error {
+	if err := handlePanic(ctx, p); err != nil {
+		return Convert(err)
+	}
+
+	var msg string
+	if p.Middleware != "" {
+		msg = fmt.Sprintf("panic executing middleware %s: %v\n%s", p.Middleware, p.Value, p.Stack)
+	} else {
+		msg = fmt.Sprintf("panic handling request: %v\n%s", p.Value, p.Stack)
+	}
+	return B().Code(Internal).Meta("panic_stack", string(p.Stack)).Msg(msg).Err()
+}
+
+// handlePanic calls the panic handler, if any. It returns nil
+// if there is no handler, or it returns nil or panics.
+func handlePanic(ctx context.Context, p PanicInfo) (err error) {
+	h, _ := panicHandler.Load().(PanicHandler)
+	if h == nil {
+		return nil
+	}
+	defer func() {
+		if recover() != nil {
+			err = nil
+		}
+	}()
+	return h(ctx, p)
+}
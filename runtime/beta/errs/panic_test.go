@@ -0,0 +1,32 @@
+package errs
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestConvertPanic(t *testing.T) {
+	defer SetPanicHandler(nil)
+	p := PanicInfo{Value: "boom", Stack: []byte("stack"), Service: "svc", Endpoint: "Foo"}
+
+	err := ConvertPanic(context.Background(), p)
+	if Code(err) != Internal || !strings.HasPrefix(err.(*Error).Message, "panic handling request: boom") {
+		t.Errorf("got default error %v", err)
+	}
+
+	var reported PanicInfo
+	SetPanicHandler(func(ctx context.Context, p PanicInfo) error {
+		reported = p
+		return B().Code(Unavailable).Msg("something went wrong").Meta("panic", p.Value).Err()
+	})
+	err = ConvertPanic(context.Background(), p)
+	if Code(err) != Unavailable || Meta(err)["panic"] != "boom" || reported.Endpoint != "Foo" {
+		t.Errorf("got error %v with meta %v, reported %+v", err, Meta(err), reported)
+	}
+
+	SetPanicHandler(func(ctx context.Context, p PanicInfo) error { panic("handler failed") })
+	if err := ConvertPanic(context.Background(), p); Code(err) != Internal {
+		t.Errorf("got %v when the handler panics, want the default error", err)
+	}
+}
@@ -0,0 +1,71 @@
+package errs
+
+import (
+	"net/http"
+)
+
+// ProblemContentType is the content type of the problem details
+// written by HTTPProblem.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypePrefix is the prefix of the "type" member of the problem
+// details written by HTTPProblem. It is followed by the error code,
+// as in "urn:encore:error:not_found".
+const ProblemTypePrefix = "urn:encore:error:"
+
+// problem is the JSON encoding of an error as RFC 7807 problem details.
+// The code, details, causes and typed details of the error are
+// included as extension members, named as in the default encoding.
+type problem struct {
+	Type         string          `json:"type"`
+	Title        string          `json:"title"`
+	Status       int             `json:"status"`
+	Detail       string          `json:"detail,omitempty"`
+	Instance     string          `json:"instance,omitempty"`
+	Code         ErrCode         `json:"code"`
+	Details      ErrDetails      `json:"details,omitempty"`
+	Causes       []string        `json:"causes,omitempty"`
+	TypedDetails []encodedDetail `json:"typed_details,omitempty"`
+}
+
+func newProblem(e *Error, status int, instance string) (*problem, error) {
+	p := &problem{
+		Type:     ProblemTypePrefix + e.Code.String(),
+		Title:    e.Code.String(),
+		Status:   status,
+		Detail:   e.ErrorMessage(),
+		Instance: instance,
+		Code:     e.Code,
+		Details:  e.Details,
+	}
+	if e.withCauses {
+		p.Causes = e.causes()
+	}
+	for _, d := range e.TypedDetails {
+		ed, err := encodeDetail(d)
+		if err != nil {
+			return nil, err
+		}
+		p.TypedDetails = append(p.TypedDetails, ed)
+	}
+	return p, nil
+}
+
+// HTTPProblem is like HTTPError but writes the error as RFC 7807
+// problem details, with the content type "application/problem+json":
+//
+//	{
+//	  "type": "urn:encore:error:not_found",
+//	  "title": "not_found",
+//	  "status": 404,
+//	  "detail": "user not found",
+//	  "instance": "/users/5",
+//	  "code": "not_found"
+//	}
+//
+// The instance is the URI reference of the failed request, such as its path,
+// and is omitted if empty. The details and typed details of the error are
+// included if present.
+func HTTPProblem(w http.ResponseWriter, err error, instance string) {
+	HTTPProblemWithCode(w, err, 0, instance)
+}
@@ -0,0 +1,53 @@
+package errs
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPProblem(t *testing.T) {
+	err := B().Code(NotFound).Msg("user not found").Retryable(2 * time.Second).Err()
+	w := httptest.NewRecorder()
+	HTTPProblem(w, err, "/users/5")
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("got content type %q", ct)
+	}
+	if ra := w.Header().Get("Retry-After"); ra != "2" {
+		t.Errorf("got Retry-After %q", ra)
+	}
+	if w.Code != 404 {
+		t.Errorf("got status %d", w.Code)
+	}
+
+	var got struct {
+		Type         string          `json:"type"`
+		Title        string          `json:"title"`
+		Status       int             `json:"status"`
+		Detail       string          `json:"detail"`
+		Instance     string          `json:"instance"`
+		Code         ErrCode         `json:"code"`
+		TypedDetails []encodedDetail `json:"typed_details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "urn:encore:error:not_found" || got.Title != "not_found" || got.Status != 404 ||
+		got.Detail != "user not found" || got.Instance != "/users/5" || got.Code != NotFound {
+		t.Errorf("got problem %+v", got)
+	}
+	if len(got.TypedDetails) != 1 || got.TypedDetails[0].Type != "encore.RetryInfo" {
+		t.Errorf("got typed details %+v", got.TypedDetails)
+	}
+
+	// The status reported in the body matches the status of the response.
+	w = httptest.NewRecorder()
+	HTTPProblemWithCode(w, err, 410, "")
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != 410 || got.Status != 410 {
+		t.Errorf("got status %d, %d in body", w.Code, got.Status)
+	}
+}
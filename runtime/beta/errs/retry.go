@@ -0,0 +1,57 @@
+package errs
+
+import (
+	"time"
+)
+
+// RetryInfo is a typed error detail marking an error as retryable,
+// meaning the failed operation may succeed if it is retried unchanged.
+//
+// Encore sets the Retry-After header of HTTP responses with retryable
+// errors, and retries service-to-service calls failing with them.
+type RetryInfo struct {
+	// After is how long to wait before retrying.
+	// If zero it is up to the caller.
+	After time.Duration `json:"after_ns"`
+}
+
+func (RetryInfo) ErrDetails() {}
+
+func init() {
+	RegisterDetail[RetryInfo]("encore.RetryInfo")
+}
+
+// Retryable marks err as retryable, optionally waiting for after
+// before retrying. If err is nil it returns nil.
+//
+// If err is not an *Error it is converted to one with code Unknown.
+func Retryable(err error, after time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		return &Error{
+			Code:         Unknown,
+			TypedDetails: []ErrDetails{RetryInfo{After: after}},
+			underlying:   err,
+			stack:        buildStack(2, Unknown),
+		}
+	}
+	e2 := *e
+	e2.TypedDetails = append(append([]ErrDetails(nil), e.TypedDetails...), RetryInfo{After: after})
+	return &e2
+}
+
+// IsRetryable reports whether err is marked as retryable.
+func IsRetryable(err error) bool {
+	_, ok := TypedDetail[RetryInfo](err)
+	return ok
+}
+
+// RetryAfter reports how long to wait before retrying err.
+// It reports 0 if err is not retryable or does not specify a delay.
+func RetryAfter(err error) time.Duration {
+	info, _ := TypedDetail[RetryInfo](err)
+	return info.After
+}
@@ -0,0 +1,35 @@
+package errs
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryable(t *testing.T) {
+	if IsRetryable(B().Code(Unavailable).Err()) {
+		t.Error("error is retryable without being marked")
+	}
+
+	err := Retryable(errors.New("boom"), 1500*time.Millisecond)
+	if !IsRetryable(err) || RetryAfter(err) != 1500*time.Millisecond {
+		t.Errorf("got retryable %v, after %v", IsRetryable(err), RetryAfter(err))
+	}
+	err = RoundTrip(Wrap(err, "wrapped"))
+	if !IsRetryable(err) || RetryAfter(err) != 1500*time.Millisecond {
+		t.Errorf("after round trip: got retryable %v, after %v", IsRetryable(err), RetryAfter(err))
+	}
+
+	w := httptest.NewRecorder()
+	HTTPError(w, err)
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("got Retry-After %q, want 2", got)
+	}
+
+	w = httptest.NewRecorder()
+	HTTPError(w, B().Code(Unavailable).Retryable(0).Err())
+	if got := w.Header().Get("Retry-After"); got != "" {
+		t.Errorf("got Retry-After %q without a delay", got)
+	}
+}
@@ -0,0 +1,93 @@
+package errs
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// redactedValue replaces the values of sensitive metadata.
+const redactedValue = "[redacted]"
+
+// defaultSensitiveMetaKeys are the metadata keys whose values are always scrubbed.
+var defaultSensitiveMetaKeys = []string{"password", "secret", "token", "api_key", "authorization", "cookie"}
+
+// sensitiveMetaKeys holds the lowercased sensitive metadata keys, as a map[string]bool.
+var sensitiveMetaKeys atomic.Value
+
+func init() {
+	SetSensitiveMetaKeys()
+}
+
+// SetSensitiveMetaKeys configures additional metadata keys, beyond the
+// default set, whose values are scrubbed from error metadata before it is
+// logged. The default set is password, secret, token, api_key, authorization
+// and cookie. Keys are matched case-insensitively, and calling it again
+// replaces the previously configured keys.
+//
+// To scrub individual values regardless of their key, use Sensitive.
+func SetSensitiveMetaKeys(keys ...string) {
+	m := make(map[string]bool, len(defaultSensitiveMetaKeys)+len(keys))
+	for _, k := range append(defaultSensitiveMetaKeys[:len(defaultSensitiveMetaKeys):len(defaultSensitiveMetaKeys)], keys...) {
+		m[strings.ToLower(k)] = true
+	}
+	sensitiveMetaKeys.Store(m)
+}
+
+// SensitiveValue is a metadata value marked as sensitive with Sensitive.
+// It is rendered as "[redacted]" when formatted or encoded, and is
+// replaced by "[redacted]" when the error crosses a service boundary.
+type SensitiveValue struct {
+	v interface{}
+}
+
+// Sensitive marks v as sensitive, so that it is scrubbed
+// from error metadata regardless of its key:
+//
+//	errs.B().Meta("card", errs.Sensitive(cardNumber))
+func Sensitive(v interface{}) SensitiveValue {
+	return SensitiveValue{v: v}
+}
+
+// Value returns the sensitive value.
+func (s SensitiveValue) Value() interface{} { return s.v }
+
+// String returns "[redacted]".
+func (s SensitiveValue) String() string { return redactedValue }
+
+// MarshalJSON encodes the value as "[redacted]".
+func (s SensitiveValue) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redactedValue + `"`), nil
+}
+
+// Scrub returns a copy of md with the values of sensitive keys, and
+// values marked with Sensitive, replaced by "[redacted]".
+// If md has no sensitive values it returns md itself.
+//
+// Encore scrubs error metadata before logging it.
+func (md Metadata) Scrub() Metadata {
+	return md.scrub(true)
+}
+
+// scrub is like Scrub, but only scrubs the values
+// of sensitive keys if byKey is true.
+func (md Metadata) scrub(byKey bool) Metadata {
+	keys, _ := sensitiveMetaKeys.Load().(map[string]bool)
+	var scrubbed Metadata
+	for k, v := range md {
+		_, marked := v.(SensitiveValue)
+		if !marked && !(byKey && keys[strings.ToLower(k)]) {
+			continue
+		}
+		if scrubbed == nil {
+			scrubbed = make(Metadata, len(md))
+			for k2, v2 := range md {
+				scrubbed[k2] = v2
+			}
+		}
+		scrubbed[k] = redactedValue
+	}
+	if scrubbed == nil {
+		return md
+	}
+	return scrubbed
+}
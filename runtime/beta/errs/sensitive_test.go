@@ -0,0 +1,30 @@
+package errs
+
+import (
+	"testing"
+)
+
+func TestMetadataScrub(t *testing.T) {
+	defer SetSensitiveMetaKeys()
+	SetSensitiveMetaKeys("SSN")
+
+	md := Meta(B().Meta("user_id", 1, "Password", "hunter2", "ssn", "123", "card", Sensitive("4242")).Err())
+	got := md.Scrub()
+	if got["user_id"] != 1 || got["Password"] != redactedValue || got["ssn"] != redactedValue || got["card"] != redactedValue {
+		t.Errorf("got scrubbed metadata %v", got)
+	}
+	if md["Password"] != "hunter2" || md["card"].(SensitiveValue).Value() != "4242" {
+		t.Errorf("scrubbing modified the metadata: %v", md)
+	}
+
+	clean := Metadata{"user_id": 1}
+	if got := clean.Scrub(); got["user_id"] != 1 || len(got) != 1 {
+		t.Errorf("got %v for metadata without sensitive values", got)
+	}
+
+	// Sensitive values do not cross service boundaries.
+	rt := Meta(RoundTrip(B().Meta("password", "hunter2", "card", Sensitive("4242")).Err()))
+	if rt["card"] != redactedValue || rt["password"] != "hunter2" {
+		t.Errorf("got metadata %v after round trip", rt)
+	}
+}
@@ -0,0 +1,100 @@
+package errs
+
+import (
+	"sync/atomic"
+
+	"encore.dev/internal/stack"
+)
+
+// stackDepth is the maximum number of stack frames captured for errors.
+var stackDepth int32 = stack.MaxFrames
+
+// codeStackDepths are the maximum numbers of stack frames captured
+// for errors with each built-in code, overriding stackDepth if non-negative.
+// The depths of custom codes are kept with their registrations.
+var codeStackDepths = func() (depths [len(codeNames)]int32) {
+	for i := range depths {
+		depths[i] = -1
+	}
+	return depths
+}()
+
+// SetStackDepth sets the maximum number of stack frames captured
+// when creating errors, as stacks are costly to capture in hot paths.
+// A depth of zero disables capturing stacks, and a negative depth
+// restores the default of capturing complete stacks.
+//
+// Errors created with Builder.Stack, or converted with WithStack,
+// always capture complete stacks.
+func SetStackDepth(depth int) {
+	if depth < 0 || depth > stack.MaxFrames {
+		depth = stack.MaxFrames
+	}
+	atomic.StoreInt32(&stackDepth, int32(depth))
+}
+
+// SetCodeStackDepth is like SetStackDepth but only applies to errors
+// with the given code, taking precedence over SetStackDepth.
+// A negative depth removes the code's depth, so that the depth set
+// with SetStackDepth applies.
+//
+// For example, SetCodeStackDepth(errs.NotFound, 0) disables capturing
+// stacks for NotFound errors, which are often expected.
+//
+// The code may be a custom code returned by RegisterCode.
+// Codes that are not registered are ignored.
+func SetCodeStackDepth(code ErrCode, depth int) {
+	slot := codeStackDepth(code)
+	if slot == nil {
+		return
+	}
+	if depth > stack.MaxFrames {
+		depth = stack.MaxFrames
+	} else if depth < 0 {
+		depth = -1
+	}
+	atomic.StoreInt32(slot, int32(depth))
+}
+
+// codeStackDepth returns the stack depth of errors with the given code,
+// or nil if the code is neither a built-in code nor a registered one.
+func codeStackDepth(code ErrCode) *int32 {
+	if code >= 0 && int(code) < len(codeStackDepths) {
+		return &codeStackDepths[code]
+	}
+	if cc, ok := code.custom(); ok {
+		return cc.stackDepth
+	}
+	return nil
+}
+
+// buildStack captures the stack of the caller, skipping skip frames,
+// up to the depth configured for errors with the given code.
+func buildStack(skip int, code ErrCode) stack.Stack {
+	depth := atomic.LoadInt32(&stackDepth)
+	if slot := codeStackDepth(code); slot != nil {
+		if d := atomic.LoadInt32(slot); d >= 0 {
+			depth = d
+		}
+	}
+	return stack.BuildN(skip+1, int(depth))
+}
+
+// WithStack returns a copy of err with the complete stack of the caller,
+// regardless of the configured stack depth, for errors created where
+// capturing stacks is disabled. If err is nil it returns nil.
+//
+// If err is not an *Error it is converted to one.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e Error
+	if ee, ok := err.(*Error); ok {
+		e = *ee
+	} else {
+		e = Error{Code: Unknown, underlying: err}
+	}
+	e.stack = stack.Build(2)
+	return &e
+}
@@ -0,0 +1,63 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStackDepth(t *testing.T) {
+	defer SetStackDepth(-1)
+	defer SetCodeStackDepth(NotFound, -1)
+
+	frames := func(err error) int { return len(Stack(err).Frames) }
+	if n := frames(Wrap(errors.New("x"), "wrap")); n == 0 {
+		t.Fatal("no stack captured by default")
+	}
+
+	SetStackDepth(2)
+	if n := frames(Wrap(errors.New("x"), "wrap")); n != 2 {
+		t.Errorf("got %d frames with depth 2", n)
+	}
+
+	SetCodeStackDepth(NotFound, 0)
+	if n := frames(B().Code(NotFound).Msg("x").Err()); n != 0 {
+		t.Errorf("got %d frames for code with depth 0", n)
+	}
+	if n := frames(B().Code(Internal).Msg("x").Err()); n != 2 {
+		t.Errorf("got %d frames for code without depth", n)
+	}
+	if n := frames(B().Code(NotFound).Msg("x").Stack().Err()); n <= 2 {
+		t.Errorf("got %d frames for explicitly requested stack", n)
+	}
+	if n := frames(WithStack(B().Code(NotFound).Msg("x").Err())); n <= 2 {
+		t.Errorf("got %d frames with WithStack", n)
+	}
+
+	SetStackDepth(-1)
+	if n := frames(B().Code(Internal).Msg("x").Err()); n <= 2 {
+		t.Errorf("got %d frames after restoring the default depth", n)
+	}
+}
+
+func TestStackDepth_CustomCode(t *testing.T) {
+	defer SetStackDepth(-1)
+	defer SetCodeStackDepth(insufficientFunds, -1)
+
+	frames := func(err error) int { return len(Stack(err).Frames) }
+	SetStackDepth(2)
+	SetCodeStackDepth(insufficientFunds, 0)
+	if n := frames(B().Code(insufficientFunds).Msg("x").Err()); n != 0 {
+		t.Errorf("got %d frames for custom code with depth 0", n)
+	}
+	if n := frames(B().Code(FailedPrecondition).Msg("x").Err()); n != 2 {
+		t.Errorf("got %d frames for the custom code's base code", n)
+	}
+
+	SetCodeStackDepth(insufficientFunds, -1)
+	if n := frames(B().Code(insufficientFunds).Msg("x").Err()); n != 2 {
+		t.Errorf("got %d frames after removing the custom code's depth", n)
+	}
+
+	// Codes that are not registered are ignored.
+	SetCodeStackDepth(ErrCode(5000), 0)
+}
@@ -0,0 +1,162 @@
+package errs
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	stdjson "encoding/json"
+)
+
+// detailTypes maps the names of typed error details to their types.
+var detailTypes = struct {
+	mu     sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}{
+	byName: make(map[string]reflect.Type),
+	byType: make(map[reflect.Type]string),
+}
+
+// RegisterDetail registers the error detail type T under name,
+// such as "payments.RetryInfo". Typed details are identified by name
+// in API responses, and details of registered types are decoded back
+// into their types when errors are decoded or cross service boundaries.
+//
+// Types that are not registered are named after their package path
+// and type name. Register types whose errors are decoded in another
+// process, such as by a client, under the same name in both.
+//
+// It panics if name is already registered for another type.
+func RegisterDetail[T ErrDetails](name string) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	detailTypes.mu.Lock()
+	defer detailTypes.mu.Unlock()
+	if prev, ok := detailTypes.byName[name]; ok && prev != typ {
+		panic(fmt.Sprintf("errs: detail name %q registered for both %v and %v", name, prev, typ))
+	}
+	detailTypes.byName[name] = typ
+	detailTypes.byType[typ] = name
+}
+
+// detailName returns the name of the type of d, registering
+// it under its default name if it is not registered.
+func detailName(d ErrDetails) string {
+	if raw, ok := d.(RawDetail); ok {
+		return raw.Type
+	}
+	typ := reflect.TypeOf(d)
+	detailTypes.mu.RLock()
+	name, ok := detailTypes.byType[typ]
+	detailTypes.mu.RUnlock()
+	if ok {
+		return name
+	}
+
+	named := typ
+	if named.Kind() == reflect.Pointer {
+		named = named.Elem()
+	}
+	name = named.PkgPath() + "." + named.Name()
+	detailTypes.mu.Lock()
+	defer detailTypes.mu.Unlock()
+	if _, taken := detailTypes.byName[name]; !taken {
+		detailTypes.byName[name] = typ
+	}
+	detailTypes.byType[typ] = name
+	return name
+}
+
+// RawDetail is a typed error detail whose type
+// is not registered in the decoding process.
+type RawDetail struct {
+	// Type is the name of the detail's type.
+	Type string
+	// Value is the JSON encoding of the detail.
+	Value stdjson.RawMessage
+}
+
+func (RawDetail) ErrDetails() {}
+
+// encodedDetail is the JSON encoding of a typed detail.
+type encodedDetail struct {
+	Type  string             `json:"type"`
+	Value stdjson.RawMessage `json:"value"`
+}
+
+func encodeDetail(d ErrDetails) (encodedDetail, error) {
+	if raw, ok := d.(RawDetail); ok {
+		return encodedDetail{Type: raw.Type, Value: raw.Value}, nil
+	}
+	value, err := json.Marshal(d)
+	if err != nil {
+		return encodedDetail{}, err
+	}
+	return encodedDetail{Type: detailName(d), Value: value}, nil
+}
+
+// decodeDetail decodes ed into a value of its registered type,
+// or into a RawDetail if its type is not registered.
+func decodeDetail(ed encodedDetail) (ErrDetails, error) {
+	detailTypes.mu.RLock()
+	typ, ok := detailTypes.byName[ed.Type]
+	detailTypes.mu.RUnlock()
+	if !ok {
+		return RawDetail{Type: ed.Type, Value: ed.Value}, nil
+	}
+
+	var ptr reflect.Value
+	if typ.Kind() == reflect.Pointer {
+		ptr = reflect.New(typ.Elem())
+	} else {
+		ptr = reflect.New(typ)
+	}
+	if err := json.Unmarshal(ed.Value, ptr.Interface()); err != nil {
+		return nil, fmt.Errorf("decode error detail %s: %v", ed.Type, err)
+	}
+	if typ.Kind() == reflect.Pointer {
+		return ptr.Interface().(ErrDetails), nil
+	}
+	return ptr.Elem().Interface().(ErrDetails), nil
+}
+
+// TypedDetail returns the first typed detail of type T included in err,
+// and reports whether there is one.
+func TypedDetail[T ErrDetails](err error) (T, bool) {
+	if e, ok := err.(*Error); ok {
+		for _, d := range e.TypedDetails {
+			if t, ok := d.(T); ok {
+				return t, true
+			}
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// TypedDetails reports the typed details included in the error.
+// If err is nil or the error lacks typed details it reports nil.
+func TypedDetails(err error) []ErrDetails {
+	if e, ok := err.(*Error); ok {
+		return e.TypedDetails
+	}
+	return nil
+}
+
+// copyTypedDetails returns a deep copy of details, made by encoding
+// and decoding them, for replicating them across RPC boundaries.
+// Details that cannot be encoded are dropped.
+func copyTypedDetails(details []ErrDetails) []ErrDetails {
+	copied := make([]ErrDetails, 0, len(details))
+	for _, d := range details {
+		ed, err := encodeDetail(d)
+		if err == nil {
+			d, err = decodeDetail(ed)
+		}
+		if err != nil {
+			continue
+		}
+		copied = append(copied, d)
+	}
+	return copied
+}
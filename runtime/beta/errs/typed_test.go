@@ -0,0 +1,82 @@
+package errs
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type retryInfo struct {
+	DelaySeconds int `json:"delay_seconds"`
+}
+
+func (retryInfo) ErrDetails() {}
+
+type quotaInfo struct {
+	Limit int
+}
+
+func (*quotaInfo) ErrDetails() {}
+
+func init() {
+	RegisterDetail[retryInfo]("test.RetryInfo")
+}
+
+func TestTypedDetails_RoundTrip(t *testing.T) {
+	err := B().Code(Unavailable).Msg("try again").
+		TypedDetail(retryInfo{DelaySeconds: 5}).
+		TypedDetail(&quotaInfo{Limit: 10}).
+		Err()
+	err = Wrap(err, "wrapped")
+
+	got := RoundTrip(err)
+	if ri, ok := TypedDetail[retryInfo](got); !ok || ri.DelaySeconds != 5 {
+		t.Errorf("got retry info %+v, %v", ri, ok)
+	}
+	if qi, ok := TypedDetail[*quotaInfo](got); !ok || qi.Limit != 10 {
+		t.Errorf("got quota info %+v, %v", qi, ok)
+	}
+	if _, ok := TypedDetail[RawDetail](got); ok {
+		t.Error("got raw detail for registered types")
+	}
+}
+
+func TestTypedDetails_HTTP(t *testing.T) {
+	err := B().Code(Unavailable).Msg("try again").TypedDetail(retryInfo{DelaySeconds: 5}).Err()
+	w := httptest.NewRecorder()
+	HTTPError(w, err)
+	body := w.Body.String()
+	if !strings.Contains(body, `"type": "test.RetryInfo"`) {
+		t.Fatalf("response lacks typed detail: %s", body)
+	}
+
+	var decoded Error
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Code != Unavailable || decoded.Message != "try again" {
+		t.Errorf("got code %v, message %q", decoded.Code, decoded.Message)
+	}
+	if ri, ok := TypedDetail[retryInfo](&decoded); !ok || ri.DelaySeconds != 5 {
+		t.Errorf("got retry info %+v, %v", ri, ok)
+	}
+
+	// Errors without typed details are encoded as before.
+	w = httptest.NewRecorder()
+	HTTPError(w, B().Code(NotFound).Msg("gone").Err())
+	if strings.Contains(w.Body.String(), "typed_details") {
+		t.Errorf("got typed details in %s", w.Body.String())
+	}
+}
+
+func TestTypedDetails_Unregistered(t *testing.T) {
+	data := `{"code":"internal","message":"m","details":null,"typed_details":[{"type":"other.Info","value":{"a":1}}]}`
+	var e Error
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		t.Fatal(err)
+	}
+	raw, ok := TypedDetail[RawDetail](&e)
+	if !ok || raw.Type != "other.Info" || string(raw.Value) != `{"a":1}` {
+		t.Errorf("got raw detail %+v, %v", raw, ok)
+	}
+}
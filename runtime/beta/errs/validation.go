@@ -0,0 +1,110 @@
+package errs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A FieldViolation describes why a field of a request is invalid.
+type FieldViolation struct {
+	// Field is the path of the field, such as "address.zip"
+	// or "items[2].quantity".
+	Field string `json:"field"`
+	// Constraint is the violated constraint, such as "required",
+	// "max_length" or "format".
+	Constraint string `json:"constraint"`
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+// ValidationErrors collects the field violations of a request,
+// for reporting them all at once. The zero value is ready for use.
+//
+// It is both an error and a typed detail: returning it from a
+// Validate method, or the error returned by Err from an endpoint,
+// responds with an InvalidArgument error including the violations
+// as a typed detail.
+type ValidationErrors struct {
+	Violations []FieldViolation `json:"violations"`
+}
+
+func (ValidationErrors) ErrDetails() {}
+
+func init() {
+	RegisterDetail[ValidationErrors]("encore.ValidationErrors")
+}
+
+// Add records a violation of constraint by field.
+func (v *ValidationErrors) Add(field, constraint, msg string) {
+	v.Violations = append(v.Violations, FieldViolation{Field: field, Constraint: constraint, Message: msg})
+}
+
+// Addf is like Add but uses fmt.Sprintf to construct the message.
+func (v *ValidationErrors) Addf(field, constraint, format string, args ...interface{}) {
+	v.Add(field, constraint, fmt.Sprintf(format, args...))
+}
+
+// Merge records the violations of other, prefixing their fields
+// with prefix and a dot, for validating nested structures.
+// If prefix is empty the fields are left as is.
+func (v *ValidationErrors) Merge(prefix string, other *ValidationErrors) {
+	if other == nil {
+		return
+	}
+	for _, fv := range other.Violations {
+		if prefix != "" {
+			fv.Field = prefix + "." + fv.Field
+		}
+		v.Violations = append(v.Violations, fv)
+	}
+}
+
+// Len reports the number of violations.
+func (v *ValidationErrors) Len() int {
+	if v == nil {
+		return 0
+	}
+	return len(v.Violations)
+}
+
+// Error describes the violations.
+func (v *ValidationErrors) Error() string {
+	switch n := v.Len(); n {
+	case 0:
+		return "no validation errors"
+	case 1:
+		return v.Violations[0].String()
+	default:
+		return v.Violations[0].String() + " (and " + strconv.Itoa(n-1) + " more)"
+	}
+}
+
+// Err returns an InvalidArgument error including the violations
+// as a typed detail. If there are no violations it returns nil.
+func (v *ValidationErrors) Err() error {
+	if v.Len() == 0 {
+		return nil
+	}
+	return B().Code(InvalidArgument).Msg("validation failed: " + v.Error()).
+		TypedDetail(ValidationErrors{Violations: append([]FieldViolation(nil), v.Violations...)}).Err()
+}
+
+func (fv FieldViolation) String() string {
+	var b strings.Builder
+	if fv.Field != "" {
+		b.WriteString(fv.Field)
+		b.WriteString(": ")
+	}
+	b.WriteString(fv.Message)
+	return b.String()
+}
+
+// Violations reports the field violations included in err,
+// by Err of ValidationErrors. If there are none it reports nil.
+func Violations(err error) []FieldViolation {
+	if ve, ok := TypedDetail[ValidationErrors](err); ok {
+		return ve.Violations
+	}
+	return nil
+}
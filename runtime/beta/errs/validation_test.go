@@ -0,0 +1,41 @@
+package errs
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidationErrors(t *testing.T) {
+	var v ValidationErrors
+	if v.Err() != nil {
+		t.Fatal("got error without violations")
+	}
+
+	var addr ValidationErrors
+	addr.Add("zip", "format", "must be 5 digits")
+	v.Addf("name", "max_length", "must be at most %d characters", 10)
+	v.Merge("address", &addr)
+
+	err := WrapCode(v.Err(), InvalidArgument, "decode request")
+	if Code(err) != InvalidArgument {
+		t.Errorf("got code %v", Code(err))
+	}
+	want := []FieldViolation{
+		{"name", "max_length", "must be at most 10 characters"},
+		{"address.zip", "format", "must be 5 digits"},
+	}
+	got := Violations(RoundTrip(err))
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got violations %+v, want %+v", got, want)
+	}
+
+	w := httptest.NewRecorder()
+	HTTPError(w, err)
+	var decoded Error
+	if err := json.Unmarshal(w.Body.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := Violations(&decoded); len(got) != 2 || got[1] != want[1] {
+		t.Errorf("got decoded violations %+v", got)
+	}
+}
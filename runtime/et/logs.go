@@ -0,0 +1,29 @@
+//go:build encore_app
+
+package et
+
+import (
+	"testing"
+
+	"encore.dev/rlog"
+)
+
+// CaptureLogs captures the log entries written by the current test,
+// including its subtests and the API calls it makes, until the test completes.
+// Use it to assert on the level, message and fields of logged entries:
+//
+//	logs := et.CaptureLogs(t)
+//	// ... call the code under test ...
+//	rec, ok := logs.Find(rlog.LevelError, "payment failed")
+//	if !ok {
+//		t.Fatal("payment failure was not logged")
+//	}
+//	if id, _ := rec.Field("order_id"); id != "123" {
+//		t.Errorf("got order_id %v, want 123", id)
+//	}
+//
+// Only entries written to the log output are captured;
+// use rlog.SetLevel to capture entries below the output level.
+func CaptureLogs(t testing.TB) *rlog.CapturedLogs {
+	return rlog.Singleton.CaptureLogs(t)
+}
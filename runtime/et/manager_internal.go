@@ -1,17 +1,19 @@
 package et
 
 import (
+	"encore.dev/appruntime/api"
 	"encore.dev/appruntime/config"
 	"encore.dev/appruntime/reqtrack"
 )
 
 //publicapigen:drop
 type Manager struct {
-	cfg *config.Config
-	rt  *reqtrack.RequestTracker
+	cfg    *config.Config
+	rt     *reqtrack.RequestTracker
+	server *api.Server
 }
 
 //publicapigen:drop
-func NewManager(cfg *config.Config, rt *reqtrack.RequestTracker) *Manager {
-	return &Manager{cfg, rt}
+func NewManager(cfg *config.Config, rt *reqtrack.RequestTracker, server *api.Server) *Manager {
+	return &Manager{cfg, rt, server}
 }
@@ -0,0 +1,24 @@
+package et
+
+import (
+	"net/http"
+	"testing"
+
+	"encore.dev/appruntime/trace/recording"
+)
+
+func (mgr *Manager) ReplayRecording(t testing.TB, path string) *http.Response {
+	t.Helper()
+	rec, err := recording.ReadFile(path)
+	if err != nil {
+		t.Fatalf("replay recording: %v", err)
+	}
+	res, err := mgr.server.ReplayRecording(rec, false)
+	if err != nil {
+		t.Fatalf("replay recording: %v", err)
+	}
+	for _, call := range res.Unmatched {
+		t.Errorf("replay recording: the call %s was not recorded", call)
+	}
+	return res.Response
+}
@@ -0,0 +1,30 @@
+//go:build encore_app
+
+package et
+
+import (
+	"net/http"
+	"testing"
+)
+
+// ReplayRecording replays the request recorded to the file at path,
+// re-executing the endpoint it was made to with the recorded request
+// and returning its response. The outgoing HTTP calls made while
+// handling it are served the recorded responses instead of being made,
+// so that a request recorded in production can be debugged locally:
+//
+//	resp := et.ReplayRecording(t, "testdata/checkout.json")
+//	if resp.StatusCode != http.StatusOK {
+//		t.Errorf("got status %d", resp.StatusCode)
+//	}
+//
+// Requests are recorded to files when the runtime is configured
+// with a trace recording directory. Outgoing calls that were not
+// recorded fail, and are reported as test errors.
+//
+// ReplayRecording must not run in parallel with other tests
+// making HTTP calls with http.DefaultTransport.
+func ReplayRecording(t testing.TB, path string) *http.Response {
+	t.Helper()
+	return Singleton.ReplayRecording(t, path)
+}
@@ -0,0 +1,11 @@
+package encore
+
+import (
+	"context"
+)
+
+// Go runs fn in a new goroutine that stays attached to the current request.
+// See the package-level Go function for details.
+func (mgr *Manager) Go(ctx context.Context, fn func(ctx context.Context)) {
+	mgr.rt.Go(func() { fn(ctx) })
+}
@@ -15,8 +15,20 @@ type Stack struct {
 	Off    uintptr
 }
 
+// Build captures the stack of the caller, skipping skip frames.
 func Build(skip int) Stack {
-	pcs := make([]uintptr, 101)
+	return BuildN(skip+1, MaxFrames)
+}
+
+// MaxFrames is the maximum number of frames captured by Build.
+const MaxFrames = 101
+
+// BuildN is like Build but captures at most n frames.
+func BuildN(skip, n int) Stack {
+	if n <= 0 {
+		return Stack{}
+	}
+	pcs := make([]uintptr, n)
 	idx, off := encoreCallers(skip+1, pcs)
 	pcs = pcs[:idx]
 	if idx == 0 {
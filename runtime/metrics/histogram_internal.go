@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"math"
+	"sync/atomic"
 
 	"encore.dev/internal/nativehist"
 )
@@ -32,6 +33,7 @@ func newHistogramInternal[V Value](m *metricInfo[V]) *Histogram[V] {
 			n = 1
 		}
 		ts.value = make([]*nativehist.Histogram, n)
+		ts.exemplars = make([]atomic.Value, n)
 		for i := range ts.value {
 			ts.value[i] = nativehist.New(bucketFactor)
 		}
@@ -57,6 +59,7 @@ func (h *Histogram[V]) Observe(val V) {
 	}
 	if idx, ok := h.svcIdx(); ok {
 		h.ts.value[idx].Observe(f)
+		h.ts.recordExemplar(h.reg.rt, idx, f)
 	}
 }
 
@@ -103,6 +106,7 @@ func (c *HistogramGroup[L, V]) get(labels L) *timeseries[*nativehist.Histogram]
 			n = 1
 		}
 		ts.value = make([]*nativehist.Histogram, n)
+		ts.exemplars = make([]atomic.Value, n)
 		for i := range ts.value {
 			ts.value[i] = nativehist.New(bucketFactor)
 		}
@@ -116,6 +120,8 @@ func makeToFloat[V Value]() func(V) float64 {
 	switch any(zero).(type) {
 	case int64:
 		return func(val V) float64 { return float64(val) }
+	case uint64:
+		return func(val V) float64 { return float64(val) }
 	case float64:
 		return func(val V) float64 { return float64(val) }
 	default:
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"sync/atomic"
 )
 
 type Labels interface {
@@ -41,6 +42,7 @@ type Counter[V Value] struct {
 func (c *Counter[V]) Increment() {
 	if idx, ok := c.svcIdx(); ok {
 		c.inc(&c.ts.value[idx])
+		c.ts.recordExemplar(c.reg.rt, idx, 1)
 	}
 }
 
@@ -52,6 +54,7 @@ func (c *Counter[V]) Add(delta V) {
 	}
 	if idx, ok := c.svcIdx(); ok {
 		c.add(&c.ts.value[idx], delta)
+		c.ts.recordExemplar(c.reg.rt, idx, c.toFloat(delta))
 	}
 }
 
@@ -126,12 +129,14 @@ type Gauge[V Value] struct {
 func (g *Gauge[V]) Set(val V) {
 	if idx, ok := g.svcIdx(); ok {
 		g.set(&g.ts.value[idx], val)
+		g.ts.recordExemplar(g.reg.rt, idx, g.toFloat(val))
 	}
 }
 
 func (g *Gauge[V]) Add(val V) {
 	if idx, ok := g.svcIdx(); ok {
 		g.add(&g.ts.value[idx], val)
+		g.ts.recordExemplar(g.reg.rt, idx, g.toFloat(val))
 	}
 }
 
@@ -179,9 +184,10 @@ func newMetricInfo[V Value](mgr *Registry, name string, typ MetricType, svcNum u
 		typ:    typ,
 		svcNum: svcNum,
 
-		add: add,
-		set: set,
-		inc: inc,
+		add:     add,
+		set:     set,
+		inc:     inc,
+		toFloat: makeToFloat[V](),
 	}
 }
 
@@ -194,6 +200,8 @@ type metricInfo[V Value] struct {
 	add func(addr *V, val V)
 	set func(addr *V, val V)
 	inc func(addr *V)
+
+	toFloat func(V) float64
 }
 
 func (m *metricInfo[V]) svcIdx() (idx uint16, ok bool) {
@@ -215,6 +223,7 @@ func (m *metricInfo[V]) getTS(labels any) (ts *timeseries[V], setup bool) {
 			n = 1
 		}
 		ts.value = make([]V, n)
+		ts.exemplars = make([]atomic.Value, n)
 	}
 
 	return ts, setup
@@ -10,6 +10,7 @@ import (
 
 	"encore.dev/appruntime/model"
 	"encore.dev/appruntime/reqtrack"
+	"encore.dev/appruntime/trace"
 )
 
 func TestCounter(t *testing.T) {
@@ -97,6 +98,34 @@ func TestCounter_MultipleServices(t *testing.T) {
 	eq(t, ts.value[1], 1)
 }
 
+func TestCounter_Exemplars(t *testing.T) {
+	rt := reqtrack.New(zerolog.Logger{}, nil, trace.DefaultFactory)
+	mgr := NewRegistry(rt, 1)
+	m := newMetricInfo[uint64](mgr, "foo", CounterType, 1)
+	c := newCounterInternal(m)
+
+	// Values recorded outside traced requests have no exemplar.
+	c.Increment()
+	if got := mgr.Collect()[0].Exemplars; got != nil {
+		t.Fatalf("got exemplars %+v, want nil", got)
+	}
+
+	req := &model.Request{Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	c.Add(2)
+	c.Add(3) // within exemplarInterval of the first
+	rt.FinishRequest()
+
+	exemplars := mgr.Collect()[0].Exemplars
+	if len(exemplars) != 1 || exemplars[0] == nil {
+		t.Fatalf("got exemplars %+v, want one", exemplars)
+	}
+	e := exemplars[0]
+	eq(t, e.TraceID, req.TraceID)
+	eq(t, e.SpanID, req.SpanID)
+	eq(t, e.Value, 2.0)
+}
+
 func TestGauge(t *testing.T) {
 	rt := reqtrack.New(zerolog.Logger{}, nil, nil)
 	mgr := NewRegistry(rt, 1)
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"encore.dev/appruntime/model"
 	"encore.dev/appruntime/reqtrack"
 	"encore.dev/internal/nativehist"
 )
@@ -32,6 +34,7 @@ func (r *Registry) Collect() []CollectedMetric {
 				TimeSeriesID: val.id,
 				Labels:       val.labels,
 				Val:          val.value,
+				Exemplars:    val.collectExemplars(),
 			})
 		case *timeseries[uint64]:
 			metrics = append(metrics, CollectedMetric{
@@ -39,6 +42,7 @@ func (r *Registry) Collect() []CollectedMetric {
 				TimeSeriesID: val.id,
 				Labels:       val.labels,
 				Val:          val.value,
+				Exemplars:    val.collectExemplars(),
 			})
 		case *timeseries[float64]:
 			metrics = append(metrics, CollectedMetric{
@@ -46,6 +50,7 @@ func (r *Registry) Collect() []CollectedMetric {
 				TimeSeriesID: val.id,
 				Labels:       val.labels,
 				Val:          val.value,
+				Exemplars:    val.collectExemplars(),
 			})
 		case *timeseries[*nativehist.Histogram]:
 			metrics = append(metrics, CollectedMetric{
@@ -53,6 +58,7 @@ func (r *Registry) Collect() []CollectedMetric {
 				TimeSeriesID: val.id,
 				Labels:       val.labels,
 				Val:          val.value,
+				Exemplars:    val.collectExemplars(),
 			})
 		default:
 			panic(fmt.Sprintf("unhandled timeseries type %T", val))
@@ -81,6 +87,19 @@ type CollectedMetric struct {
 	TimeSeriesID uint64
 	Labels       []KeyValue
 	Val          any // []T where T is any of Value
+
+	// Exemplars holds the latest exemplar of each value in Val,
+	// or nil if no value has an exemplar.
+	Exemplars []*Exemplar
+}
+
+// Exemplar links a value recorded for a metric to the trace of the
+// request it was recorded in, so that dashboards can link to the trace.
+type Exemplar struct {
+	TraceID model.TraceID
+	SpanID  model.SpanID
+	Value   float64 // the value recorded, such as the amount a counter was incremented by
+	Time    time.Time
 }
 
 type registryKey struct {
@@ -94,6 +113,50 @@ type timeseries[T any] struct {
 	init   initGate
 	labels []KeyValue
 	value  []T
+
+	// exemplars holds the latest *Exemplar of each value.
+	exemplars []atomic.Value
+}
+
+// collectExemplars returns the latest exemplar of each value,
+// or nil if there are none.
+func (ts *timeseries[T]) collectExemplars() []*Exemplar {
+	var exemplars []*Exemplar
+	for i := range ts.exemplars {
+		if e, _ := ts.exemplars[i].Load().(*Exemplar); e != nil {
+			if exemplars == nil {
+				exemplars = make([]*Exemplar, len(ts.exemplars))
+			}
+			exemplars[i] = e
+		}
+	}
+	return exemplars
+}
+
+// exemplarInterval is the minimum time between updates
+// of the exemplar of a value, to bound the cost of recording them.
+const exemplarInterval = time.Second
+
+// recordExemplar records an exemplar for the value at idx if the
+// current request is traced, unless one was recorded recently.
+func (ts *timeseries[T]) recordExemplar(rt *reqtrack.RequestTracker, idx uint16, value float64) {
+	if int(idx) >= len(ts.exemplars) {
+		return
+	}
+	curr := rt.Current()
+	if curr.Req == nil || curr.Trace == nil || curr.Req.TraceID.IsZero() {
+		return
+	}
+	now := time.Now()
+	if prev, _ := ts.exemplars[idx].Load().(*Exemplar); prev != nil && now.Sub(prev.Time) < exemplarInterval {
+		return
+	}
+	ts.exemplars[idx].Store(&Exemplar{
+		TraceID: curr.Req.TraceID,
+		SpanID:  curr.Req.SpanID,
+		Value:   value,
+		Time:    now,
+	})
 }
 
 func (ts *timeseries[V]) setup(labels []KeyValue) {
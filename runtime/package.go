@@ -21,6 +21,7 @@
 //
 // Package encore
 //
-// This package provides the APIs for getting AppMetadata about the current application and the CurrentRequest.
+// This package provides the APIs for getting AppMetadata about the current application and the CurrentRequest,
+// and for starting goroutines that stay attached to the current request with Go.
 // For more information see https://encore.dev/docs/develop/metadata.
 package encore
@@ -2,6 +2,10 @@
 
 package encore
 
+import (
+	"context"
+)
+
 //publicapigen:drop
 var Singleton *Manager
 
@@ -21,3 +25,20 @@ func Meta() *AppMetadata {
 func CurrentRequest() *Request {
 	return Singleton.CurrentRequest()
 }
+
+// Go runs fn in a new goroutine that stays attached to the current request,
+// so that the log messages and trace events it records appear in the
+// request's trace, even if fn completes after the request does.
+// fn is called with ctx:
+//
+//	encore.Go(ctx, func(ctx context.Context) {
+//		if err := warmCache(ctx); err != nil {
+//			rlog.Error("could not warm cache", "err", err)
+//		}
+//	})
+//
+// The request's trace is not sent until fn returns,
+// so fn should not run for longer than necessary.
+func Go(ctx context.Context, fn func(ctx context.Context)) {
+	Singleton.Go(ctx, fn)
+}
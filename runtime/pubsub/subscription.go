@@ -105,8 +105,13 @@ func NewSubscription[T any](topic *Topic[T], name string, subscriptionCfg Subscr
 		defer mgr.outstanding.Dec()
 
 		if !mgr.cfg.Static.Testing {
-			// Under test we're already inside an operation
-			mgr.rt.BeginOperation()
+			// Under test we're already inside an operation.
+			// Follow the publisher's sampling decision, if it propagated one.
+			parent := trace.NoParent
+			if _, _, sampled, ok := model.ParseTraceParent(attrs[traceParentAttribute]); ok {
+				parent = trace.ParentFlag(sampled)
+			}
+			mgr.rt.BeginSampledOperation(staticCfg.Service, name, "", parent)
 			defer mgr.rt.FinishOperation()
 		}
 
@@ -122,7 +127,20 @@ func NewSubscription[T any](topic *Topic[T], name string, subscriptionCfg Subscr
 		if err != nil {
 			log.Err(err).Str("msg_id", msgID).Int("delivery_attempt", deliveryAttempt).Msg("failed to generate trace id")
 			return errs.B().Code(errs.Internal).Cause(err).Msg("failed to generate trace id").Err()
-		} else if traceID != (model.TraceID{}) {
+		}
+
+		// Continue the distributed trace of messages from publishers other
+		// than Encore. Messages published by Encore are linked to the
+		// publishing trace using the parent trace id instead.
+		var parentID model.SpanID
+		var traceState string
+		if attrs[parentTraceIDAttribute] == "" {
+			if tid, pid, _, ok := model.ParseTraceParent(attrs[traceParentAttribute]); ok {
+				traceID, parentID = tid, pid
+				traceState = model.NormalizeTraceState(attrs[traceStateAttribute])
+			}
+		}
+		if traceID != (model.TraceID{}) {
 			logCtx = logCtx.Str("trace_id", traceID.String())
 		}
 
@@ -152,8 +170,11 @@ func NewSubscription[T any](topic *Topic[T], name string, subscriptionCfg Subscr
 			Type:             model.PubSubMessage,
 			TraceID:          traceID,
 			SpanID:           spanID,
+			ParentID:         parentID,
 			ParentTraceID:    parentTraceID,
 			ExtCorrelationID: extCorrelationID,
+			TraceState:       traceState,
+			Baggage:          model.ParseBaggage(attrs[baggageAttribute]),
 			Start:            time.Now(),
 			MsgData: &model.PubSubMsgData{
 				Service:        staticCfg.Service,
@@ -94,6 +94,17 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 			// Otherwise this is the first request in the event chain, so this trace ID becomes the correlation ID
 			attrs[extCorrelationIDAttribute] = req.TraceID.String()
 		}
+
+		// Propagate the W3C Trace Context for consumers other than Encore.
+		if !req.TraceID.IsZero() && !req.SpanID.IsZero() {
+			attrs[traceParentAttribute] = model.FormatTraceParent(req.TraceID, req.SpanID, req.Traced)
+			if req.TraceState != "" {
+				attrs[traceStateAttribute] = req.TraceState
+			}
+		}
+		if len(req.Baggage) > 0 {
+			attrs[baggageAttribute] = model.FormatBaggage(req.Baggage)
+		}
 	}
 
 	// Start the trace span
@@ -13,6 +13,16 @@ const parentTraceIDAttribute = "encore_parent_trace_id"
 // extCorrelationIDAttribute is the attribute name we use to track externally provided correlation IDs
 const extCorrelationIDAttribute = "encore_ext_correlation_id"
 
+// traceParentAttribute and traceStateAttribute are the attribute names we use to
+// propagate the W3C Trace Context, for consumers and publishers other than Encore.
+const (
+	traceParentAttribute = "traceparent"
+	traceStateAttribute  = "tracestate"
+)
+
+// baggageAttribute is the attribute name we use to propagate W3C Baggage.
+const baggageAttribute = "baggage"
+
 // SubscriptionConfig is used when creating a subscription
 //
 // The values given here may be clamped to the supported values by
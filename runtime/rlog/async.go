@@ -0,0 +1,216 @@
+package rlog
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// DropPolicy determines what happens when the queue
+// of asynchronously written log entries is full.
+type DropPolicy int
+
+const (
+	// DropNewest drops the entry being logged.
+	DropNewest DropPolicy = iota
+
+	// DropOldest drops the oldest queued entry
+	// to make room for the entry being logged.
+	DropOldest
+
+	// Block blocks the logging goroutine until
+	// there is room in the queue.
+	Block
+)
+
+// SetAsyncOutput configures the log output to be written asynchronously,
+// by a background goroutine, using a queue holding up to queueSize entries.
+// This takes writing the log output off the logging goroutine,
+// which reduces logging latency under high throughput.
+// A queueSize less than or equal to zero writes the log output synchronously,
+// which is the default.
+//
+// The policy determines what happens when the queue is full. Entries at
+// error level and above, including critical entries, are never queued or
+// dropped: they are written synchronously after the entries already queued.
+// Queued entries are flushed when the application shuts down gracefully
+// and before Fatal and Panic terminate it.
+//
+// Writers added with AddWriter are written to asynchronously as well.
+// SetAsyncOutput does nothing if the Manager has no Output configured.
+func (l *Manager) SetAsyncOutput(queueSize int, policy DropPolicy) {
+	out := l.config().output
+	if out == nil {
+		return
+	}
+
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	var q *asyncQueue
+	if queueSize > 0 {
+		q = newAsyncQueue(out, queueSize, policy)
+		go q.run()
+	}
+	// Write out the entries of the previous queue, if any,
+	// so that none are lost, and stop its goroutine.
+	prev, _ := out.async.Load().(*asyncQueue)
+	out.async.Store(q)
+	if prev != nil {
+		prev.flush(context.Background())
+		prev.close()
+	}
+}
+
+// DroppedOutput reports the number of log entries dropped
+// because the queue of asynchronously written entries was full.
+func (l *Manager) DroppedOutput() uint64 {
+	if out := l.config().output; out != nil {
+		return atomic.LoadUint64(&out.dropped)
+	}
+	return 0
+}
+
+// flushOutput waits until the queued log entries have been written,
+// or until ctx is done.
+func (l *Manager) flushOutput(ctx context.Context) {
+	if out := l.config().output; out != nil {
+		if q, _ := out.async.Load().(*asyncQueue); q != nil {
+			q.flush(ctx)
+		}
+	}
+}
+
+// Shutdown flushes the log entries that are pending because they are written
+// asynchronously, rate limited or buffered by sinks, waiting at most until
+// ctx is done. It is called when the application shuts down gracefully,
+// once its shutdown handlers have returned.
+//
+//publicapigen:drop
+func (l *Manager) Shutdown(ctx context.Context) {
+	l.flushRepeats()
+	l.flushOutput(ctx)
+	l.flushSinks(ctx)
+}
+
+// asyncQueue is a queue of log entries written by a background goroutine.
+type asyncQueue struct {
+	out    *Output
+	ch     chan []byte
+	policy DropPolicy
+
+	// flushes receives flush requests, each a channel closed once the
+	// entries queued before the request have been written. They are
+	// kept separate from the entries so that they are never dropped.
+	flushes chan chan struct{}
+
+	// stop is closed when the queue is replaced. Loggers that still
+	// hold the queue then write their entries synchronously.
+	// It is closed with mu held for writing, and entries are queued
+	// with mu held for reading, so that no entry is queued after
+	// the goroutine has drained the queue and stopped.
+	mu   sync.RWMutex
+	stop chan struct{}
+}
+
+func newAsyncQueue(out *Output, size int, policy DropPolicy) *asyncQueue {
+	return &asyncQueue{
+		out:     out,
+		ch:      make(chan []byte, size),
+		policy:  policy,
+		flushes: make(chan chan struct{}),
+		stop:    make(chan struct{}),
+	}
+}
+
+func (q *asyncQueue) run() {
+	for {
+		select {
+		case p := <-q.ch:
+			_, _ = q.out.writeNow(p)
+		case ack := <-q.flushes:
+			q.drain()
+			close(ack)
+		case <-q.stop:
+			q.drain()
+			return
+		}
+	}
+}
+
+// drain writes the entries currently queued. It does not wait for
+// entries queued while it runs, so that it returns under steady logging.
+func (q *asyncQueue) drain() {
+	for n := len(q.ch); n > 0; n-- {
+		select {
+		case p := <-q.ch:
+			_, _ = q.out.writeNow(p)
+		default:
+			// Dropped to make room for newer entries.
+			return
+		}
+	}
+}
+
+// close stops the queue's goroutine after it has written
+// the entries currently queued.
+func (q *asyncQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	close(q.stop)
+}
+
+// enqueue queues p for writing, according to the drop policy.
+// If the queue has been stopped, p is written synchronously.
+func (q *asyncQueue) enqueue(p []byte) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	select {
+	case <-q.stop:
+		_, _ = q.out.writeNow(p)
+		return
+	default:
+	}
+
+	switch q.policy {
+	case Block:
+		// The goroutine keeps writing entries until the queue is stopped,
+		// which waits for the send to complete.
+		q.ch <- p
+	case DropOldest:
+		for {
+			select {
+			case q.ch <- p:
+				return
+			default:
+			}
+			select {
+			case <-q.ch:
+				atomic.AddUint64(&q.out.dropped, 1)
+			default:
+			}
+		}
+	default:
+		select {
+		case q.ch <- p:
+		default:
+			atomic.AddUint64(&q.out.dropped, 1)
+		}
+	}
+}
+
+// flush waits until the entries queued before the call
+// have been written, or until ctx is done.
+func (q *asyncQueue) flush(ctx context.Context) {
+	ack := make(chan struct{})
+	select {
+	case q.flushes <- ack:
+	case <-q.stop:
+		return
+	case <-ctx.Done():
+		return
+	}
+	select {
+	case <-ack:
+	case <-ctx.Done():
+	}
+}
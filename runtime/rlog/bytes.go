@@ -0,0 +1,75 @@
+package rlog
+
+import (
+	"strconv"
+)
+
+// ByteUnits configures how byte counts logged with Bytes
+// are rendered in human-readable form.
+type ByteUnits byte
+
+const (
+	// SIUnits renders byte counts using powers of 1000 (kB, MB, GB, ...).
+	SIUnits ByteUnits = iota
+	// BinaryUnits renders byte counts using powers of 1024 (KiB, MiB, GiB, ...).
+	BinaryUnits
+)
+
+// byteCount is a number of bytes, logged with Bytes.
+type byteCount int64
+
+// Bytes constructs a Field for a number of bytes, such as a request size
+// or a transfer amount.
+//
+// The raw number is recorded in structured (JSON) log output and in traces.
+// When logging to the console it is rendered in human-readable form
+// (such as "1.5 MB"), using the units configured with SetByteUnits.
+func Bytes(key string, n int64) Field {
+	return Field{key, byteCount(n)}
+}
+
+// SetByteUnits configures the units used to render byte counts
+// in human-readable form. It defaults to SIUnits.
+func (l *Manager) SetByteUnits(units ByteUnits) {
+	l.updateConfig(func(c *config) {
+		c.byteUnits = units
+	})
+}
+
+// SetConsoleOutput configures whether the log output is written
+// in a human-friendly console format, as opposed to structured JSON.
+//
+// It can be changed at any time; the Encore runtime sets it at startup
+// from the ENCORE_LOG_OUTPUT environment variable or the runtime
+// configuration. Writers added with AddWriter always receive JSON.
+func (l *Manager) SetConsoleOutput(console bool) {
+	l.updateConfig(func(c *config) {
+		c.console = console
+		if c.output != nil {
+			c.output.setConsole(console)
+		}
+	})
+}
+
+// formatBytes renders n in human-readable form using the given units.
+func formatBytes(n int64, units ByteUnits) string {
+	base, prefixes, suffix := int64(1000), "kMGTPE", "B"
+	if units == BinaryUnits {
+		base, prefixes, suffix = 1024, "KMGTPE", "iB"
+	}
+
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs < base {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+
+	div, exp := base, 0
+	for x := abs / base; x >= base && exp < len(prefixes)-1; x /= base {
+		div *= base
+		exp++
+	}
+	return strconv.FormatFloat(float64(n)/float64(div), 'f', 1, 64) + " " + prefixes[exp:exp+1] + suffix
+}
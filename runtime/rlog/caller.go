@@ -0,0 +1,66 @@
+package rlog
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+
+	"encore.dev/internal/stack"
+)
+
+// SetIncludeCaller configures whether log entries include the file and
+// line of the logging call as the "caller" field, such as "svc/user.go:42".
+//
+// The caller is the first stack frame outside of rlog and the logging
+// libraries it provides adapters for, so that entries logged through
+// helpers such as Observe or SlogHandler report the application code
+// that logged them. The field is only added to the log output,
+// as traces already include the full stack trace.
+func (l *Manager) SetIncludeCaller(include bool) {
+	l.updateConfig(func(c *config) {
+		c.includeCaller = include
+	})
+}
+
+// loggingPackages are the prefixes of the functions
+// that are skipped when determining the caller.
+var loggingPackages = []string{
+	"encore.dev/rlog.",
+	"encore.dev/rlog/",
+	"log/slog.",
+	"go.uber.org/zap.",
+	"go.uber.org/zap/",
+	"github.com/go-logr/logr.",
+}
+
+// callerOf returns the file and line of the first frame in s
+// that is not part of a logging package, or "" if there is none.
+func callerOf(s stack.Stack) string {
+	if len(s.Frames) == 0 {
+		return ""
+	}
+	cf := runtime.CallersFrames(s.Frames)
+	for {
+		f, more := cf.Next()
+		if !isLoggingFrame(f) {
+			return f.File + ":" + strconv.Itoa(f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isLoggingFrame reports whether f is part of a logging package.
+// Frames in test files are never considered part of a logging package.
+func isLoggingFrame(f runtime.Frame) bool {
+	if strings.HasSuffix(f.File, "_test.go") {
+		return false
+	}
+	for _, prefix := range loggingPackages {
+		if strings.HasPrefix(f.Function, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package rlog
+
+import (
+	"sync"
+	"testing"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/reqtrack"
+)
+
+// CapturedLogs holds the log entries captured by CaptureLogs.
+// It is safe for concurrent use.
+type CapturedLogs struct {
+	rt   *reqtrack.RequestTracker
+	t    testing.TB
+	mu   sync.Mutex
+	recs []Record
+}
+
+// CaptureLogs captures the log entries written by the test t, including
+// its subtests and the API calls and other requests it makes, until t
+// completes. Only entries written to the log output are captured; use
+// SetLevel to also capture entries below the output level.
+//
+//publicapigen:drop
+func (l *Manager) CaptureLogs(t testing.TB) *CapturedLogs {
+	c := &CapturedLogs{rt: l.rt, t: t}
+	l.AddSink(c)
+	t.Cleanup(func() {
+		l.removeSink(c)
+	})
+	return c
+}
+
+// Emit implements Sink.
+func (c *CapturedLogs) Emit(rec Record) {
+	if !c.inTest(c.rt.Current().Req) {
+		return
+	}
+	c.mu.Lock()
+	c.recs = append(c.recs, rec)
+	c.mu.Unlock()
+}
+
+// inTest reports whether req is running as part of the captured test.
+func (c *CapturedLogs) inTest(req *model.Request) bool {
+	for req != nil && req.Test != nil {
+		if req.Test.Current != nil && testing.TB(req.Test.Current) == c.t {
+			return true
+		}
+		req = req.Test.Parent
+	}
+	return false
+}
+
+// Entries returns the captured log entries, in the order they were logged.
+func (c *CapturedLogs) Entries() []Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Record(nil), c.recs...)
+}
+
+// Find returns the first captured entry with the given level and message.
+func (c *CapturedLogs) Find(level Level, msg string) (rec Record, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.recs {
+		if r.Level == level && r.Message == msg {
+			return r, true
+		}
+	}
+	return Record{}, false
+}
+
+// Reset discards the log entries captured so far.
+func (c *CapturedLogs) Reset() {
+	c.mu.Lock()
+	c.recs = nil
+	c.mu.Unlock()
+}
@@ -0,0 +1,69 @@
+package rlog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Collector accumulates the outcomes of many sub-operations,
+// such as the children of a fan-out, and logs them as a single
+// summary log entry instead of one entry per sub-operation.
+//
+// A Collector is safe for concurrent use.
+type Collector struct {
+	mgr *Manager
+
+	mu       sync.Mutex
+	children []collectedChild
+}
+
+// collectedChild is the outcome of a single sub-operation.
+type collectedChild struct {
+	Name     string        `json:"name"`
+	OK       bool          `json:"ok"`
+	Duration time.Duration `json:"-"`
+	// DurationVal is Duration encoded according to zerolog.DurationFieldUnit.
+	DurationVal float64 `json:"duration"`
+}
+
+// Collector returns a new Collector that logs using l.
+func (l *Manager) Collector() *Collector {
+	return &Collector{mgr: l}
+}
+
+// Add records the outcome of the sub-operation with the given name.
+func (c *Collector) Add(name string, ok bool, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.children = append(c.children, collectedChild{Name: name, OK: ok, Duration: duration})
+}
+
+// Log emits a single log entry at the given level summarizing all
+// the sub-operations recorded so far in a "children" array field,
+// together with the "children_total" and "children_failed" counts.
+//
+// The variadic key-value pairs are treated as they are in With.
+func (c *Collector) Log(level Level, msg string, keysAndValues ...any) {
+	c.mu.Lock()
+	children := make([]collectedChild, len(c.children))
+	copy(children, c.children)
+	c.mu.Unlock()
+
+	failed := 0
+	for i := range children {
+		if !children[i].OK {
+			failed++
+		}
+		children[i].DurationVal = float64(children[i].Duration) / float64(zerolog.DurationFieldUnit)
+	}
+
+	fields := c.mgr.checkPairs(keysAndValues)
+	fields = append(fields[:len(fields):len(fields)],
+		"children_total", len(children),
+		"children_failed", failed,
+		"children", children,
+	)
+	c.mgr.doLog(level, event(c.mgr.rt.Logger(), level), msg, nil, fields, logOpts{})
+}
@@ -0,0 +1,325 @@
+package rlog
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/metrics"
+)
+
+// config holds the runtime-adjustable configuration of a Manager.
+//
+// A config is never modified once it has been installed in a Manager;
+// updates are made to a copy which then atomically replaces the current
+// config. This keeps the logging hot path free of lock contention.
+type config struct {
+	// outputLevel is the minimum level of log entries written to
+	// the log output. Entries below it are recorded in traces only.
+	outputLevel Level
+
+	// serviceLevels are the output levels of individual services,
+	// overriding outputLevel for log entries emitted by requests
+	// to those services.
+	serviceLevels map[string]Level
+
+	// namedLevels are the output levels of named loggers and
+	// their descendants, overriding serviceLevels and outputLevel.
+	namedLevels map[string]Level
+
+	// sampleRates are the sample rates of each level, where a rate of n
+	// means that 1 in n entries are kept. Rates of 1 or less keep all entries.
+	sampleRates [numLevels]int
+
+	// repeatLimit is the maximum number of identical entries written
+	// per repeatInterval, or zero if there is no limit.
+	repeatLimit    int
+	repeatInterval time.Duration
+
+	// releaseID is the deploy/release identifier attached to every
+	// log entry as the "release" field. It is empty if unset.
+	releaseID string
+
+	// includeTraceParent configures whether to attach the W3C
+	// traceparent of the current request as the "traceparent" field.
+	includeTraceParent bool
+
+	// baggageKeys matches the keys of the W3C Baggage members attached
+	// to log entries. It is nil if no members are attached.
+	baggageKeys keyMatcher
+
+	// includeSpanID configures whether to attach the id of the span
+	// a warning or error is logged in as the "span_id" field,
+	// if the current request is traced.
+	includeSpanID bool
+
+	// console is whether log output is written to a
+	// human-readable console rather than as JSON.
+	console bool
+
+	// byteUnits are the units used to render byte counts
+	// in human-readable form.
+	byteUnits ByteUnits
+
+	// sensitiveHeaders matches the HTTP headers that are
+	// redacted when logged with Headers.
+	sensitiveHeaders keyMatcher
+
+	// redactedKeys matches the keys of fields whose values are redacted,
+	// and redactionPatterns match the parts of string values that are redacted.
+	redactedKeys      keyMatcher
+	redactionPatterns []*regexp.Regexp
+
+	// includePprofLabels configures whether to attach the pprof labels
+	// of the context logged with, restricted to pprofLabelKeys if non-nil.
+	includePprofLabels bool
+	pprofLabelKeys     map[string]bool
+
+	// traceOnlyKeepErrors configures whether error-level log entries of
+	// requests whose logs are recorded in the trace only are still
+	// written to the log output.
+	traceOnlyKeepErrors bool
+
+	// selfMetrics configures whether to collect statistics
+	// about the logging itself.
+	selfMetrics bool
+
+	// maxFields is the maximum number of fields of a log entry,
+	// or zero if there is no limit.
+	maxFields int
+
+	// workflowExtractor, if non-nil, reports the workflow the
+	// logging goroutine is executing as part of.
+	workflowExtractor func() (workflowID, runID string, ok bool)
+
+	// globalFields are the fields attached to every log entry.
+	globalFields []any
+
+	// envName is the name of the environment the application runs in,
+	// and envFields are the fields attached in each environment.
+	envName   string
+	envFields map[string][]any
+
+	// stacklessErrors are the errors for which no stack trace is captured.
+	stacklessErrors []error
+
+	// jsonEncoder encodes values that are logged as JSON.
+	// If nil, encoding/json is used.
+	jsonEncoder jsonEncoder
+
+	// sinks receive the log entries written to the log output.
+	sinks []Sink
+
+	// hooks are called for every log entry before it is written.
+	hooks []Hook
+
+	// includeCaller configures whether to attach the file and line
+	// of the logging call as the "caller" field.
+	includeCaller bool
+
+	// output is the destination of the log output,
+	// or nil if it was not configured.
+	output *Output
+
+	// strictPairs configures whether malformed key-value pairs
+	// are reported. See Manager.SetStrictPairs.
+	strictPairs bool
+
+	// logsTotal are the log volume counters of each level,
+	// or nil if the metrics have not been registered.
+	logsTotal *[numLevels]*metrics.Counter[uint64]
+}
+
+// clone returns a deep copy of c that can be modified
+// without affecting c.
+func (c *config) clone() *config {
+	cp := *c
+	cp.serviceLevels = cloneMap(c.serviceLevels)
+	cp.namedLevels = cloneMap(c.namedLevels)
+	cp.baggageKeys = cloneMap(c.baggageKeys)
+	cp.sensitiveHeaders = cloneMap(c.sensitiveHeaders)
+	cp.redactedKeys = cloneMap(c.redactedKeys)
+	cp.redactionPatterns = cloneSlice(c.redactionPatterns)
+	cp.pprofLabelKeys = cloneMap(c.pprofLabelKeys)
+	cp.globalFields = cloneSlice(c.globalFields)
+	if c.envFields != nil {
+		cp.envFields = make(map[string][]any, len(c.envFields))
+		for k, v := range c.envFields {
+			cp.envFields[k] = cloneSlice(v)
+		}
+	}
+	cp.stacklessErrors = cloneSlice(c.stacklessErrors)
+	cp.sinks = cloneSlice(c.sinks)
+	cp.hooks = cloneSlice(c.hooks)
+	return &cp
+}
+
+// cloneSlice returns a copy of s, or nil if s is nil.
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+// cloneMap returns a copy of m, or nil if m is nil.
+func cloneMap[M ~map[K]V, K comparable, V any](m M) M {
+	if m == nil {
+		return nil
+	}
+	cp := make(M, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+	return cp
+}
+
+// Config is a snapshot of a Manager's logging configuration,
+// as returned by SnapshotConfig.
+type Config struct {
+	cfg *config
+}
+
+// SnapshotConfig returns a snapshot of the current logging configuration,
+// for later use with RestoreConfig.
+//
+// It is primarily intended for tests that modify the logging configuration,
+// to avoid affecting other tests.
+func (l *Manager) SnapshotConfig() Config {
+	return Config{cfg: l.config().clone()}
+}
+
+// RestoreConfig restores the logging configuration to that of
+// the given snapshot, undoing any configuration changes made since.
+// It does nothing if cfg is the zero value.
+func (l *Manager) RestoreConfig(cfg Config) {
+	if cfg.cfg == nil {
+		return
+	}
+	l.cfgMu.Lock()
+	defer l.cfgMu.Unlock()
+	l.cfg.Store(cfg.cfg.clone())
+}
+
+// config returns the current configuration.
+// The returned value must not be modified.
+func (l *Manager) config() *config {
+	return l.cfg.Load()
+}
+
+// updateConfig applies fn to a copy of the current configuration
+// and installs the result as the new configuration.
+func (l *Manager) updateConfig(fn func(c *config)) {
+	l.cfgMu.Lock()
+	defer l.cfgMu.Unlock()
+	cfg := l.config().clone()
+	fn(cfg)
+	l.cfg.Store(cfg)
+}
+
+// SetReleaseID sets the deploy/release identifier that is attached
+// to every log and trace entry as the "release" field.
+//
+// It is set automatically by the Encore runtime from the deploy metadata
+// and is not expected to change during the lifetime of the process.
+//
+//publicapigen:drop
+func (l *Manager) SetReleaseID(id string) {
+	l.updateConfig(func(c *config) {
+		c.releaseID = id
+	})
+}
+
+// managerFields returns the fields the Manager attaches to every log entry
+// logged as part of the given request, which may be nil.
+func (c *config) managerFields(req *model.Request) []any {
+	var fields []any
+	if c.releaseID != "" {
+		fields = append(fields, "release", c.releaseID)
+	}
+	if c.includeTraceParent {
+		if tp, ok := traceParent(req); ok {
+			fields = append(fields, "traceparent", tp)
+		}
+	}
+	fields = append(fields, workflowFields(c.workflowExtractor)...)
+	fields = append(fields, c.globalFields...)
+	fields = append(fields, c.envFields[c.envName]...)
+	fields = append(fields, baggageFields(req, c.baggageKeys, fields)...)
+	return fields
+}
+
+// resolveFields returns fields with configuration-dependent values
+// resolved to their final representation, used both in the log output
+// and in traces. It returns fields itself if no values needed resolving,
+// and never modifies fields.
+func (c *config) resolveFields(fields []any) []any {
+	var resolved []any
+	for i := 1; i < len(fields); i += 2 {
+		val, changed := fields[i], false
+		if p, ok := val.(prioritized); ok {
+			val, changed = p.val, true
+		}
+		if converted, ok := c.resolveValue(val); ok {
+			val, changed = converted, true
+		}
+		if redacted, ok := c.redactField(fields[i-1], val); ok {
+			val, changed = redacted, true
+		}
+		if !changed {
+			continue
+		}
+		if resolved == nil {
+			resolved = make([]any, len(fields))
+			copy(resolved, fields)
+		}
+		resolved[i] = val
+	}
+	if resolved == nil {
+		return fields
+	}
+	return resolved
+}
+
+// resolveValue resolves a single configuration-dependent value.
+// It reports false if val does not need resolving.
+func (c *config) resolveValue(val any) (any, bool) {
+	switch v := val.(type) {
+	case Lazy:
+		return c.resolveLazy(v), true
+	case headerValues:
+		return redactHeaders(v, c.sensitiveHeaders), true
+	case FieldMarshaler:
+		return c.marshalFields(v), true
+	case zerolog.LogObjectMarshaler:
+		return c.marshalZerologObject(v), true
+	case error:
+		if c.stackless(v) {
+			return stacklessError{v}, true
+		}
+		return convertValue(v)
+	default:
+		return convertValue(v)
+	}
+}
+
+// eventValue returns the representation of val to use in the log output,
+// for values whose representation depends on the configuration.
+// Other values are returned unchanged.
+func (c *config) eventValue(val any) any {
+	switch val := val.(type) {
+	case byteCount:
+		if c.console {
+			return formatBytes(int64(val), c.byteUnits)
+		}
+		return int64(val)
+	case moneyValue:
+		if c.console {
+			val.Display = formatMoney(val)
+		}
+		return val
+	}
+	return val
+}
@@ -0,0 +1,34 @@
+package rlog
+
+import (
+	"context"
+)
+
+// ctxKey is the context key for the Ctx stored by NewContext.
+type ctxKey struct{}
+
+// NewContext returns a copy of parent that carries the logging context logCtx,
+// which can be retrieved with FromContext.
+//
+// This makes the logging context available to helper functions that
+// only receive a context.Context, without threading a Ctx through
+// every function signature.
+func NewContext(parent context.Context, logCtx Ctx) context.Context {
+	return context.WithValue(parent, ctxKey{}, logCtx)
+}
+
+// FromContext returns the logging context carried by ctx, as added with
+// NewContext. If ctx carries no logging context it returns an empty
+// logging context, which logs like the package-level functions.
+// If SetIncludePprofLabels is enabled, the pprof labels of ctx
+// are added to the returned logging context.
+func (l *Manager) FromContext(ctx context.Context) Ctx {
+	logCtx, ok := ctx.Value(ctxKey{}).(Ctx)
+	if !ok {
+		logCtx = l.With()
+	}
+	if labels := l.config().pprofLabelFields(ctx); len(labels) > 0 {
+		logCtx = logCtx.With(labels...)
+	}
+	return logCtx
+}
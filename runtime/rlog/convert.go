@@ -0,0 +1,41 @@
+package rlog
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	convertersMu sync.Mutex   // serializes RegisterValueConverter
+	converters   atomic.Value // []func(any) (any, bool)
+)
+
+// RegisterValueConverter registers a function that converts values of
+// types rlog has no native support for into a loggable representation,
+// before they are written to the log output and to traces.
+//
+// The function reports false if it does not handle the given value,
+// in which case the next registered converter is tried.
+//
+// It is intended to be called from the init function of packages
+// adding support for additional value types, such as rlogproto.
+func RegisterValueConverter(fn func(val any) (converted any, ok bool)) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	existing, _ := converters.Load().([]func(any) (any, bool))
+	updated := make([]func(any) (any, bool), len(existing), len(existing)+1)
+	copy(updated, existing)
+	converters.Store(append(updated, fn))
+}
+
+// convertValue converts val using the registered value converters.
+// It reports false if no converter handled val.
+func convertValue(val any) (any, bool) {
+	fns, _ := converters.Load().([]func(any) (any, bool))
+	for _, fn := range fns {
+		if converted, ok := fn(val); ok {
+			return converted, true
+		}
+	}
+	return nil, false
+}
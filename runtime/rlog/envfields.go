@@ -0,0 +1,55 @@
+package rlog
+
+// SetEnvironment sets the name of the environment the application is
+// running in, which determines the fields added by SetEnvFields.
+//
+// It is set automatically by the Encore runtime from the runtime
+// configuration, so the same binary attaches the right fields
+// as it is promoted between environments.
+func (l *Manager) SetEnvironment(name string) {
+	l.updateConfig(func(c *config) {
+		c.envName = name
+	})
+}
+
+// SetGlobalFields configures fields that are attached to every log entry,
+// such as the build version, region or pod name. The fields are given
+// as key-value pairs, as with With.
+//
+// It is intended to be called once during service initialization,
+// so that the fields need not be added through a shared logging context.
+// Calling it again replaces the fields, and calling it with no fields
+// removes them.
+func (l *Manager) SetGlobalFields(fields ...any) {
+	l.updateConfig(func(c *config) {
+		fields := c.resolveFields(pairs(fields))
+		if len(fields) == 0 {
+			c.globalFields = nil
+			return
+		}
+		c.globalFields = append([]any(nil), fields...)
+	})
+}
+
+// SetEnvFields configures fields that are attached to every log entry,
+// but only when running in the environment with the given name.
+// The fields are given as key-value pairs, as with With.
+//
+// This makes it possible to enrich logs differently per environment,
+// such as adding extra debugging fields in staging but not in production,
+// without branching on the environment in application code.
+// Calling it again for the same environment replaces its fields,
+// and calling it with no fields removes them.
+func (l *Manager) SetEnvFields(env string, fields ...any) {
+	l.updateConfig(func(c *config) {
+		fields := c.resolveFields(pairs(fields))
+		if len(fields) == 0 {
+			delete(c.envFields, env)
+			return
+		}
+		if c.envFields == nil {
+			c.envFields = make(map[string][]any)
+		}
+		c.envFields[env] = append([]any(nil), fields...)
+	})
+}
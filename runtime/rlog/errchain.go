@@ -0,0 +1,106 @@
+package rlog
+
+import (
+	"errors"
+
+	"encore.dev/beta/errs"
+)
+
+// maxErrorChain is the maximum number of wrapped errors
+// logged for an error field.
+const maxErrorChain = 16
+
+// expandErrors returns fields with structured sub-fields added after
+// each error field, describing the error in more detail than its message:
+//
+//   - <key>_chain: the messages of the errors it wraps, as returned by
+//     repeatedly unwrapping it, if it wraps any errors
+//   - <key>_code, <key>_meta and <key>_details: the code, metadata
+//     and details of the first *errs.Error in the chain, if any,
+//     with sensitive metadata scrubbed (see errs.Metadata.Scrub)
+//
+// It returns fields itself if there are no error fields,
+// and never modifies fields.
+func expandErrors(fields []any) []any {
+	var expanded []any
+	for i := 1; i < len(fields); i += 2 {
+		var err error
+		switch v := fields[i].(type) {
+		case stacklessError:
+			err = v.error
+		case error:
+			err = v
+		}
+		extra := errorFields(fields[i-1].(string), err)
+		if expanded == nil {
+			if len(extra) == 0 {
+				continue
+			}
+			expanded = append(make([]any, 0, len(fields)+len(extra)), fields[:i-1]...)
+		}
+		expanded = append(expanded, fields[i-1], fields[i])
+		expanded = append(expanded, extra...)
+	}
+	if expanded == nil {
+		return fields
+	}
+	return expanded
+}
+
+// errorFields returns the sub-fields describing err,
+// as documented by expandErrors.
+func errorFields(key string, err error) []any {
+	if err == nil {
+		return nil
+	}
+
+	var fields []any
+	if chain := unwrapChain(err); len(chain) > 0 {
+		fields = append(fields, key+"_chain", chain)
+	}
+
+	var e *errs.Error
+	if errors.As(err, &e) {
+		fields = append(fields, key+"_code", e.Code.String())
+		if len(e.Meta) > 0 {
+			fields = append(fields, key+"_meta", e.Meta.Scrub())
+		}
+		if e.Details != nil {
+			fields = append(fields, key+"_details", e.Details)
+		}
+	}
+	return fields
+}
+
+// unwrapChain returns the messages of the errors wrapped by err, in the
+// order they are found by unwrapping it depth-first. Errors wrapping
+// multiple errors, such as those returned by errors.Join, are followed
+// into each of them. It returns at most maxErrorChain messages.
+func unwrapChain(err error) []string {
+	var chain []string
+	var walk func(err error)
+	walk = func(err error) {
+		for len(chain) < maxErrorChain {
+			switch u := err.(type) {
+			case interface{ Unwrap() error }:
+				err = u.Unwrap()
+				if err == nil {
+					return
+				}
+				chain = append(chain, err.Error())
+			case interface{ Unwrap() []error }:
+				for _, e := range u.Unwrap() {
+					if e != nil && len(chain) < maxErrorChain {
+						chain = append(chain, e.Error())
+						walk(e)
+					}
+				}
+				return
+			default:
+				return
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
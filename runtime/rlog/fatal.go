@@ -0,0 +1,102 @@
+package rlog
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// flushTimeout is the maximum time spent flushing
+// before the process terminates.
+const flushTimeout = 5 * time.Second
+
+// osExit is os.Exit, overridden in tests.
+var osExit = os.Exit
+
+// Fatal logs a message at fatal level, including a stack trace, and then
+// terminates the process with exit code 1.
+// The variadic key-value pairs are treated as they are in With.
+//
+// Before terminating, it flushes any log entries pending in sinks and the
+// traces recorded so far by all running requests, marked as crashed with
+// msg, so that the diagnostics leading up to the crash are not lost.
+// In traces the message is recorded at error level.
+func (l *Manager) Fatal(msg string, keysAndValues ...any) {
+	fields := l.checkPairs(keysAndValues)
+	l.doLog(LevelError, l.rt.Logger().WithLevel(zerolog.FatalLevel), msg, nil, fields, logOpts{stack: true})
+	l.flush(msg)
+	osExit(1)
+}
+
+// Panic logs a message at panic level, including a stack trace,
+// and then panics with msg.
+// The variadic key-value pairs are treated as they are in With.
+//
+// Before panicking, it flushes the log entries pending in sinks, in case
+// the panic terminates the process. The trace of the current request is
+// left to be sent when the request completes, as API handlers recover
+// from panics; if the panic terminates the process, the trace is sent
+// marked as crashed. In traces the message is recorded at error level.
+func (l *Manager) Panic(msg string, keysAndValues ...any) {
+	fields := l.checkPairs(keysAndValues)
+	l.doLog(LevelError, l.rt.Logger().WithLevel(zerolog.PanicLevel), msg, nil, fields, logOpts{stack: true})
+	l.flushLogs()
+	panic(msg)
+}
+
+// Fatal is like Manager.Fatal, but merges the context from ctx
+// with the additional context provided as key-value pairs.
+func (ctx Ctx) Fatal(msg string, keysAndValues ...any) {
+	l := ctx.ctx.Logger()
+	fields := ctx.mgr.checkPairs(keysAndValues)
+	ctx.mgr.doLog(LevelError, l.WithLevel(zerolog.FatalLevel), msg, ctx.fields, fields, logOpts{stack: true, spanID: ctx.span, name: ctx.name, detached: ctx.detached})
+	ctx.mgr.flush(msg)
+	osExit(1)
+}
+
+// Panic is like Manager.Panic, but merges the context from ctx
+// with the additional context provided as key-value pairs.
+func (ctx Ctx) Panic(msg string, keysAndValues ...any) {
+	l := ctx.ctx.Logger()
+	fields := ctx.mgr.checkPairs(keysAndValues)
+	ctx.mgr.doLog(LevelError, l.WithLevel(zerolog.PanicLevel), msg, ctx.fields, fields, logOpts{stack: true, spanID: ctx.span, name: ctx.name, detached: ctx.detached})
+	ctx.mgr.flushLogs()
+	panic(msg)
+}
+
+// flusher is implemented by sinks that buffer log entries.
+type flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// flush writes out pending log entries and, as the process is about to
+// terminate for the reason crash, the traces of all running requests,
+// marked as crashed. It waits at most flushTimeout.
+func (l *Manager) flush(crash string) {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	l.flushRepeats()
+	l.flushOutput(ctx)
+	l.flushSinks(ctx)
+	l.rt.FlushCrashedTraces(ctx, crash)
+}
+
+// flushLogs writes out pending log entries, waiting at most flushTimeout.
+func (l *Manager) flushLogs() {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	l.flushRepeats()
+	l.flushOutput(ctx)
+	l.flushSinks(ctx)
+}
+
+// flushSinks writes out the log entries pending in sinks.
+func (l *Manager) flushSinks(ctx context.Context) {
+	for _, s := range l.config().sinks {
+		if f, ok := s.(flusher); ok {
+			_ = f.Flush(ctx)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package rlog
+
+import (
+	"time"
+)
+
+// Field is a typed key-value pair for structured logging.
+//
+// Fields can be grouped into reusable slices ([]Field) and spread
+// into the *Fields family of logging functions, which makes it easy
+// to define standard field bundles once and reuse them:
+//
+//	dbFields := []rlog.Field{rlog.String("db", "users"), rlog.Int("shard", 3)}
+//	rlog.InfoFields("query executed", append(dbFields, rlog.Duration("took", dur))...)
+type Field struct {
+	Key   string
+	Value any
+}
+
+// String constructs a Field with a string value.
+func String(key, val string) Field { return Field{key, val} }
+
+// Bool constructs a Field with a bool value.
+func Bool(key string, val bool) Field { return Field{key, val} }
+
+// Int constructs a Field with an int value.
+func Int(key string, val int) Field { return Field{key, val} }
+
+// Int64 constructs a Field with an int64 value.
+func Int64(key string, val int64) Field { return Field{key, val} }
+
+// Uint64 constructs a Field with an uint64 value.
+func Uint64(key string, val uint64) Field { return Field{key, val} }
+
+// Float64 constructs a Field with a float64 value.
+func Float64(key string, val float64) Field { return Field{key, val} }
+
+// Time constructs a Field with a time.Time value.
+func Time(key string, val time.Time) Field { return Field{key, val} }
+
+// Duration constructs a Field with a time.Duration value.
+func Duration(key string, val time.Duration) Field { return Field{key, val} }
+
+// NamedErr constructs a Field with an error value.
+func NamedErr(key string, err error) Field { return Field{key, err} }
+
+// Any constructs a Field with an arbitrary value.
+// The value is encoded in the same way as values passed to With.
+func Any(key string, val any) Field { return Field{key, val} }
+
+// fieldPairs converts fields into the key-value pair representation
+// used by the variadic logging functions, so that both paths
+// encode fields identically.
+func fieldPairs(fields []Field) []any {
+	if len(fields) == 0 {
+		return nil
+	}
+	kv := make([]any, 0, len(fields)*2)
+	for _, f := range fields {
+		kv = append(kv, f.Key, f.Value)
+	}
+	return kv
+}
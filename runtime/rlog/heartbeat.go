@@ -0,0 +1,69 @@
+package rlog
+
+import (
+	"sync"
+	"time"
+)
+
+// heartbeatInterval is how often a Manager's heartbeat runs its tasks.
+const heartbeatInterval = time.Minute
+
+// heartbeat runs the periodic background tasks of a Manager, such as
+// logging the self-metrics summary, on a single shared ticker that
+// only runs while there are tasks.
+type heartbeat struct {
+	mu    sync.Mutex
+	tasks map[string]func()
+	stop  chan struct{} // closed to stop the ticker; nil if not running
+}
+
+// set sets the task with the given name to run on every beat,
+// replacing any task of that name, or removes it if fn is nil.
+func (h *heartbeat) set(name string, fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if fn == nil {
+		delete(h.tasks, name)
+	} else {
+		if h.tasks == nil {
+			h.tasks = make(map[string]func())
+		}
+		h.tasks[name] = fn
+	}
+
+	if len(h.tasks) > 0 && h.stop == nil {
+		h.stop = make(chan struct{})
+		go h.run(h.stop)
+	} else if len(h.tasks) == 0 && h.stop != nil {
+		close(h.stop)
+		h.stop = nil
+	}
+}
+
+// run beats on an interval until stop is closed.
+func (h *heartbeat) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			h.beat()
+		}
+	}
+}
+
+// beat runs every task once.
+func (h *heartbeat) beat() {
+	h.mu.Lock()
+	tasks := make([]func(), 0, len(h.tasks))
+	for _, fn := range h.tasks {
+		tasks = append(tasks, fn)
+	}
+	h.mu.Unlock()
+
+	for _, fn := range tasks {
+		fn()
+	}
+}
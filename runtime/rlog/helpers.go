@@ -0,0 +1,108 @@
+package rlog
+
+import (
+	"sort"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+// RateLimitDecision returns the canonical fields describing a rate-limit decision.
+//
+// The key identifies the rate-limited entity and is redacted,
+// as it is frequently a user id or an IP address. Redacted keys are
+// pseudonyms that are only stable within the running process.
+// The resetAt time is encoded using the configured time format.
+func RateLimitDecision(key string, allowed bool, remaining int, resetAt time.Time) []Field {
+	return []Field{
+		String("ratelimit_key", redact(key)),
+		Bool("ratelimit_allowed", allowed),
+		Int("ratelimit_remaining", remaining),
+		Time("ratelimit_reset_at", resetAt),
+	}
+}
+
+// Budget returns the canonical fields describing the consumption of a time budget,
+// such as the total time allowed for a request across all its downstream calls.
+//
+// The durations are encoded using the configured duration format.
+// The remaining budget is never negative; budget_exceeded reports
+// whether more than the total budget has been used.
+func Budget(total, used time.Duration) []Field {
+	remaining := total - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return []Field{
+		Duration("budget_total", total),
+		Duration("budget_used", used),
+		Duration("budget_remaining", remaining),
+		Bool("budget_exceeded", used > total),
+	}
+}
+
+// Idempotency returns the canonical fields describing the handling of
+// an idempotent request: the (redacted) idempotency key, and whether
+// the request was a replay of a previously executed request whose
+// result was returned instead of executing it again.
+func Idempotency(key string, replayed bool) []Field {
+	return []Field{
+		String("idempotency_key", redact(key)),
+		Bool("idempotent_replay", replayed),
+	}
+}
+
+// maxBatchFailures is the maximum number of failures
+// included in the fields returned by BatchResult.
+const maxBatchFailures = 20
+
+// batchFailure describes a failed item of a batch operation.
+type batchFailure struct {
+	ID    string   `json:"id"`
+	Error string   `json:"error"`
+	Stack []string `json:"stack,omitempty"`
+}
+
+// BatchResult returns the canonical fields describing the result of a batch
+// operation that can partially fail, such as a bulk endpoint: the number of
+// items in total, that succeeded and that failed, and a "failures" array
+// describing the failed items, keyed by item id in failures.
+//
+// Each failure is an object with the item "id", the "error" message and,
+// if the error carries one, the "stack" of the error. For large batches only
+// the first 20 failures, ordered by id, are included; batch_failures_truncated
+// reports the number of failures that were left out.
+func BatchResult(total, succeeded, failed int, failures map[string]error) []Field {
+	ids := make([]string, 0, len(failures))
+	for id := range failures {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	truncated := 0
+	if len(ids) > maxBatchFailures {
+		truncated = len(ids) - maxBatchFailures
+		ids = ids[:maxBatchFailures]
+	}
+	list := make([]batchFailure, 0, len(ids))
+	for _, id := range ids {
+		err := failures[id]
+		f := batchFailure{ID: id, Error: "unknown error"}
+		if err != nil {
+			f.Error = err.Error()
+			f.Stack = stackFrames(errs.Stack(err))
+		}
+		list = append(list, f)
+	}
+
+	fields := []Field{
+		Int("batch_total", total),
+		Int("batch_succeeded", succeeded),
+		Int("batch_failed", failed),
+		Any("failures", list),
+	}
+	if truncated > 0 {
+		fields = append(fields, Int("batch_failures_truncated", truncated))
+	}
+	return fields
+}
@@ -0,0 +1,40 @@
+package rlog
+
+// A Hook is called for every log entry before it is written,
+// with the entry's level, message and key-value pair fields,
+// not including those added with With.
+//
+// It returns the fields to log instead, which makes it possible to
+// enrich entries centrally, such as with a tenant id taken from the
+// current request. Hooks that do not change the fields return them
+// unchanged. If drop is true, the entry is discarded entirely,
+// both from the log output and from traces; critical entries are
+// never dropped.
+//
+// Hooks are called synchronously and must be safe for concurrent use.
+// They must not log using rlog.
+type Hook func(level Level, msg string, fields []any) (drop bool, newFields []any)
+
+// AddHook adds a hook that is called for every log entry before it is written.
+// Hooks are called in the order they were added, each receiving
+// the fields returned by the previous hook.
+func (l *Manager) AddHook(h Hook) {
+	l.updateConfig(func(c *config) {
+		c.hooks = append(c.hooks[:len(c.hooks):len(c.hooks)], h)
+	})
+}
+
+// runHooks runs the configured hooks for a log entry.
+// It reports the fields to log, and false if the entry is dropped.
+func (c *config) runHooks(level Level, msg string, fields []any, critical bool) ([]any, bool) {
+	for _, h := range c.hooks {
+		// Limit the capacity so that hooks appending fields
+		// never overwrite the caller's backing array.
+		drop, newFields := h(level, msg, fields[:len(fields):len(fields)])
+		if drop && !critical {
+			return nil, false
+		}
+		fields = pairs(newFields)
+	}
+	return fields, true
+}
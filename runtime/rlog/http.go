@@ -0,0 +1,74 @@
+package rlog
+
+import (
+	"net/http"
+)
+
+// headerValues are HTTP headers logged with Headers.
+type headerValues http.Header
+
+// Headers returns a "headers" field describing the given HTTP headers.
+//
+// The headers are logged as a map from header name to value, where headers
+// with multiple values are rendered as arrays. The values of sensitive headers
+// are redacted: by default Authorization, Proxy-Authorization, Cookie and Set-Cookie,
+// as well as any header configured with SetSensitiveHeaders.
+func Headers(h http.Header) Field {
+	return Field{"headers", headerValues(h)}
+}
+
+// defaultSensitiveHeaders are the headers that are always redacted by Headers.
+var defaultSensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// SetSensitiveHeaders configures additional headers, beyond the default set,
+// whose values are redacted when logged with Headers.
+// Header names are matched case-insensitively.
+func (l *Manager) SetSensitiveHeaders(names ...string) {
+	l.updateConfig(func(c *config) {
+		c.sensitiveHeaders = newKeyMatcher(append(defaultSensitiveHeaders, names...)...)
+	})
+}
+
+// IsSensitiveHeader reports whether the values of the named header
+// are redacted when logged with Headers.
+//
+//publicapigen:drop
+func (l *Manager) IsSensitiveHeader(name string) bool {
+	return l.config().sensitiveHeaders.match(name)
+}
+
+// redactHeaders renders h as a map, redacting the values of
+// the headers matched by sensitive.
+func redactHeaders(h headerValues, sensitive keyMatcher) map[string]any {
+	m := make(map[string]any, len(h))
+	for name, values := range h {
+		if sensitive.match(name) {
+			m[name] = redactedValue
+			continue
+		}
+		if len(values) == 1 {
+			m[name] = values[0]
+		} else {
+			m[name] = values
+		}
+	}
+	return m
+}
+
+// httpStatus is the structured representation of an HTTP status code.
+type httpStatus struct {
+	Code int    `json:"code"`
+	Text string `json:"text"`
+}
+
+// HTTPStatus returns an "http_status" field describing the given
+// HTTP status code as a nested {code, text} object,
+// where text is the standard status text (such as "Not Found").
+// The text is "unknown" for unknown status codes.
+func HTTPStatus(code int) Field {
+	text := http.StatusText(code)
+	if text == "" {
+		text = "unknown"
+	}
+	return Field{"http_status", httpStatus{Code: code, Text: text}}
+}
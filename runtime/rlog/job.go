@@ -0,0 +1,50 @@
+package rlog
+
+import (
+	"time"
+
+	"encore.dev/types/uuid"
+)
+
+// Job starts logging the lifecycle of a background job with the given name.
+//
+// It logs a "job started" entry and returns a logging context tagged with
+// the job name ("job") and a newly generated job id ("job_id"), which is used
+// to correlate all log entries belonging to the job, even outside of a request.
+//
+// The logging context is detached from the current request, if any:
+// its entries do not include the request's context, such as its trace id,
+// and are not recorded in the request's trace. This keeps the logs of jobs
+// consistent regardless of where they are started, including for jobs
+// started by a request that outlive it.
+//
+// The returned done function must be called when the job completes.
+// It logs a "job completed" entry, or a "job failed" error entry if err is non-nil,
+// including the job's duration.
+func (l *Manager) Job(name string) (jobLogger Ctx, done func(err error)) {
+	detached := Ctx{ctx: l.rt.RootLogger().With(), mgr: l, detached: true}
+	jobLogger = detached.With("job", name, "job_id", newOperationID())
+	jobLogger.Info("job started")
+
+	start := time.Now()
+	done = func(err error) {
+		dur := time.Since(start)
+		if err != nil {
+			jobLogger.Error("job failed", "duration", dur, "success", false, "error", err)
+		} else {
+			jobLogger.Info("job completed", "duration", dur, "success", true)
+		}
+	}
+	return jobLogger, done
+}
+
+// newOperationID generates a unique id for correlating
+// the log entries of a long-running operation.
+func newOperationID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		// Should never happen in practice; fall back to a time-based id.
+		return "op-" + time.Now().UTC().Format("20060102T150405.000000000")
+	}
+	return id.String()
+}
@@ -0,0 +1,38 @@
+package rlog
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonEncoder encodes a value as JSON.
+// A nil jsonEncoder uses encoding/json.
+type jsonEncoder func(v any) ([]byte, error)
+
+// marshal encodes v as JSON.
+func (enc jsonEncoder) marshal(v any) ([]byte, error) {
+	if enc == nil {
+		return json.Marshal(v)
+	}
+	return enc(v)
+}
+
+// SetJSONEncoder configures the function used to encode values that are
+// logged as JSON, such as structs, maps and slices, in both the log output
+// and traces. It replaces the standard encoding/json, and makes it possible
+// to use a faster encoder or one with custom settings, such as not escaping HTML.
+//
+// The function must be safe for concurrent use and must produce valid JSON,
+// as its output is included verbatim in the log output and in traces.
+// Passing nil restores the default encoder.
+func (l *Manager) SetJSONEncoder(fn func(v any) ([]byte, error)) {
+	l.updateConfig(func(c *config) {
+		c.jsonEncoder = fn
+	})
+}
+
+// marshalErrorString is the value logged in place of a value that
+// could not be encoded as JSON, matching the log output of zerolog.
+func marshalErrorString(err error) string {
+	return fmt.Sprintf("marshaling error: %v", err)
+}
@@ -0,0 +1,27 @@
+package rlog
+
+// Lazy is a log field value that is computed only when needed.
+//
+// Wrapping an expensive value, such as a serialized struct or a computed
+// summary, in Lazy defers computing it until the log entry is actually
+// written to the log output or recorded in a trace. If the entry is
+// discarded because of its level, the function is never called.
+//
+//	rlog.Debug("cache state", "summary", rlog.Lazy(func() any { return cache.Summary() }))
+//
+// The value returned by the function is logged like any other value.
+// For fields added to a logging context with With, the function is
+// called once, when the context is created.
+type Lazy func() any
+
+// resolveLazy calls fn and resolves the value it returns.
+func (c *config) resolveLazy(fn Lazy) any {
+	if fn == nil {
+		return nil
+	}
+	val := fn()
+	if resolved, ok := c.resolveValue(val); ok {
+		return resolved
+	}
+	return val
+}
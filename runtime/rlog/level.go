@@ -0,0 +1,158 @@
+package rlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/beta/errs"
+)
+
+// String returns the lowercase name of the level, such as "info".
+func (l Level) String() string {
+	switch l {
+	case LevelTrace:
+		return "trace"
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("Level(%d)", byte(l))
+	}
+}
+
+// ParseLevel parses a level name, as returned by Level.String.
+// The name is matched case-insensitively, and "warning" is accepted
+// as an alias for "warn".
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return LevelTrace, nil
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("rlog: unknown log level %q", s)
+	}
+}
+
+// SetLevel sets the minimum level of log entries written to the log output.
+// Entries below it are still recorded in traces.
+//
+// It takes effect immediately for all subsequent log entries,
+// which makes it possible to enable debug logging in a running
+// application without redeploying it.
+func (l *Manager) SetLevel(level Level) {
+	l.updateConfig(func(c *config) {
+		c.outputLevel = level
+	})
+}
+
+// CurrentLevel reports the minimum level of log entries
+// written to the log output, as configured with SetLevel.
+func (l *Manager) CurrentLevel() Level {
+	return l.config().outputLevel
+}
+
+// LevelHandler returns an HTTP handler for inspecting and changing
+// the log level at runtime.
+//
+// A GET request responds with the current level as {"level": "info"}.
+// A PUT or POST request with a body of the same form sets the level
+// and responds with the new level.
+//
+// The handler performs no authorization of its own; it is served by the
+// Encore runtime on an internal route that only accepts requests
+// authenticated by the Encore Platform.
+//
+//publicapigen:drop
+func (l *Manager) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var body struct {
+				Level string `json:"level"`
+			}
+			if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, 1<<10)).Decode(&body); err != nil {
+				errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msg("invalid request body").Err())
+				return
+			}
+			level, err := ParseLevel(body.Level)
+			if err != nil {
+				errs.HTTPError(w, errs.B().Code(errs.InvalidArgument).Msg(err.Error()).Err())
+				return
+			}
+			prev := l.CurrentLevel()
+			l.SetLevel(level)
+			l.Info("log level changed", "previous", prev.String(), "level", level.String())
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			errs.HTTPError(w, errs.B().Code(errs.Unimplemented).Msg("method not allowed").Err())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Level string `json:"level"`
+		}{l.CurrentLevel().String()})
+	})
+}
+
+// SetServiceLevel sets the minimum level of log entries written to the
+// log output by the given service, overriding the level set with SetLevel
+// for log entries emitted while handling requests to that service.
+func (l *Manager) SetServiceLevel(service string, level Level) {
+	l.updateConfig(func(c *config) {
+		if c.serviceLevels == nil {
+			c.serviceLevels = make(map[string]Level)
+		}
+		c.serviceLevels[service] = level
+	})
+}
+
+// ResetServiceLevel removes the level set with SetServiceLevel for the
+// given service, so that it again uses the level set with SetLevel.
+func (l *Manager) ResetServiceLevel(service string) {
+	l.updateConfig(func(c *config) {
+		delete(c.serviceLevels, service)
+	})
+}
+
+// levelFor reports the output level for log entries emitted by req,
+// which may be nil, with the logger with the given name, which may be empty.
+func (c *config) levelFor(req *model.Request, name string) Level {
+	level := c.configuredLevel(req, name)
+	if req != nil && req.DebugLogs.Load() && level > LevelDebug {
+		level = LevelDebug
+	}
+	return level
+}
+
+// configuredLevel reports the output level configured for log entries
+// emitted by req, which may be nil, with the logger with the given name.
+func (c *config) configuredLevel(req *model.Request, name string) Level {
+	if name != "" && len(c.namedLevels) > 0 {
+		if level, ok := c.namedLevel(name); ok {
+			return level
+		}
+	}
+	if req != nil && len(c.serviceLevels) > 0 {
+		if level, ok := c.serviceLevels[req.Service()]; ok {
+			return level
+		}
+	}
+	return c.outputLevel
+}
@@ -0,0 +1,233 @@
+// Package logfile writes log output to a file with size and time based
+// rotation, for self-hosted deployments without a log shipper.
+//
+// The file is an io.Writer; add it to rlog to write the log output to it:
+//
+//	f, err := logfile.Open(logfile.Config{
+//		Path:       "/var/log/my-app/app.log",
+//		MaxSize:    100 << 20,
+//		MaxBackups: 10,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	rlog.AddWriter(f)
+//
+// When the file is rotated, it is renamed by appending the time of
+// rotation to its name, such as "app.log.20240102T150405.000",
+// and a new file is created in its place.
+package logfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config configures a File.
+type Config struct {
+	// Path is the path of the file to write to.
+	// Its directory is created if it does not exist.
+	Path string
+
+	// MaxSize is the maximum size of the file in bytes before it is rotated.
+	// If zero, the file is not rotated based on its size.
+	MaxSize int64
+
+	// RotateInterval is the maximum time the file is written to
+	// before it is rotated, counted from when it was opened.
+	// If zero, the file is not rotated based on time.
+	RotateInterval time.Duration
+
+	// MaxBackups is the maximum number of rotated files to retain.
+	// If zero, rotated files are not removed based on their number.
+	MaxBackups int
+
+	// MaxAge is the maximum time to retain rotated files,
+	// based on when they were rotated.
+	// If zero, rotated files are not removed based on their age.
+	MaxAge time.Duration
+}
+
+// backupTimeFormat is the format of the rotation time
+// appended to the names of rotated files.
+const backupTimeFormat = "20060102T150405.000"
+
+// File is a log file that is rotated according to its Config.
+// It is safe for concurrent use.
+type File struct {
+	cfg Config
+	now func() time.Time
+
+	mu     sync.Mutex
+	f      *os.File
+	size   int64
+	opened time.Time
+}
+
+// Open opens the file configured by cfg for appending, creating it if needed.
+func Open(cfg Config) (*File, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("logfile: no path configured")
+	}
+	f := &File{cfg: cfg, now: time.Now}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0755); err != nil {
+		return nil, fmt.Errorf("logfile: %v", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes p to the file, rotating it first if needed.
+func (f *File) Write(p []byte) (n int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.f == nil {
+		return 0, os.ErrClosed
+	}
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err = f.f.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file, regardless of its size and age.
+func (f *File) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.f == nil {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+// Close closes the file.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.f == nil {
+		return nil
+	}
+	err := f.f.Close()
+	f.f = nil
+	return err
+}
+
+// shouldRotate reports whether the file must be rotated
+// before writing n bytes to it.
+func (f *File) shouldRotate(n int) bool {
+	if f.size == 0 {
+		// Never leave an empty file behind.
+		return false
+	}
+	if f.cfg.MaxSize > 0 && f.size+int64(n) > f.cfg.MaxSize {
+		return true
+	}
+	return f.cfg.RotateInterval > 0 && f.now().Sub(f.opened) >= f.cfg.RotateInterval
+}
+
+// open opens the file for appending. It must be called with f.mu held.
+func (f *File) open() error {
+	file, err := os.OpenFile(f.cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("logfile: %v", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("logfile: %v", err)
+	}
+	f.f, f.size, f.opened = file, info.Size(), f.now()
+	return nil
+}
+
+// rotate renames the file and opens a new one in its place,
+// and then removes the rotated files that are no longer retained.
+// It must be called with f.mu held.
+func (f *File) rotate() error {
+	if err := f.f.Close(); err != nil {
+		return fmt.Errorf("logfile: %v", err)
+	}
+	f.f = nil
+
+	// Ensure the name of the rotated file is unique,
+	// in case the file is rotated more than once a millisecond.
+	now := f.now()
+	backup := f.cfg.Path + "." + now.UTC().Format(backupTimeFormat)
+	for i := 1; fileExists(backup); i++ {
+		backup = f.cfg.Path + "." + now.Add(time.Duration(i)*time.Millisecond).UTC().Format(backupTimeFormat)
+	}
+	if err := os.Rename(f.cfg.Path, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("logfile: %v", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.removeBackups(now)
+	return nil
+}
+
+// removeBackups removes the rotated files that are no longer retained.
+// Errors are ignored, as they must not prevent logging.
+func (f *File) removeBackups(now time.Time) {
+	if f.cfg.MaxBackups <= 0 && f.cfg.MaxAge <= 0 {
+		return
+	}
+	backups := f.backups()
+	for i, b := range backups {
+		tooMany := f.cfg.MaxBackups > 0 && i >= f.cfg.MaxBackups
+		tooOld := f.cfg.MaxAge > 0 && now.Sub(b.rotated) > f.cfg.MaxAge
+		if tooMany || tooOld {
+			_ = os.Remove(b.path)
+		}
+	}
+}
+
+type backup struct {
+	path    string
+	rotated time.Time
+}
+
+// backups returns the rotated files, newest first.
+func (f *File) backups() []backup {
+	dir, base := filepath.Split(f.cfg.Path)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var backups []backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		rotated, err := time.Parse(backupTimeFormat, strings.TrimPrefix(name, base+"."))
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, name), rotated: rotated})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].rotated.After(backups[j].rotated)
+	})
+	return backups
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
@@ -0,0 +1,89 @@
+package logfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotateBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	f, err := Open(Config{Path: path, MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	f.now = func() time.Time { return now }
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Second)
+	}
+
+	// Each line exceeds the size limit together with the previous one,
+	// so every write but the first rotates the file. Only the two most
+	// recent rotated files are retained.
+	want := map[string]string{
+		"app.log":                     "fourth\n",
+		"app.log.20240102T150408.000": "third\n",
+		"app.log.20240102T150407.000": "second\n",
+	}
+	assertFiles(t, filepath.Dir(path), want)
+}
+
+func TestRotateByTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := Open(Config{Path: path, RotateInterval: time.Hour, MaxAge: 90 * time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	start := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	now := start
+	f.now = func() time.Time { return now }
+	f.opened = start
+
+	for i, line := range []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n"} {
+		now = start.Add(time.Duration(i) * 35 * time.Minute)
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The file is rotated at 16:10, 17:20 and 18:30; by then
+	// the file rotated at 16:10 is older than MaxAge.
+	want := map[string]string{
+		"app.log":                     "g\n",
+		"app.log.20240102T183000.000": "e\nf\n",
+		"app.log.20240102T172000.000": "c\nd\n",
+	}
+	assertFiles(t, filepath.Dir(path), want)
+}
+
+func assertFiles(t *testing.T, dir string, want map[string]string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[e.Name()] = string(data)
+	}
+	if len(got) != len(want) {
+		t.Errorf("got files %v, want %v", got, want)
+	}
+	for name, content := range want {
+		if got[name] != content {
+			t.Errorf("got %s = %q, want %q", name, got[name], content)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package rlog
+
+import (
+	"encore.dev/metrics"
+)
+
+type logsTotalLabels struct {
+	level string // Log level, such as "info" or "error".
+}
+
+// RegisterMetrics registers the built-in log volume metrics with reg.
+//
+// The "e_logs_total" counter counts the log entries written to the log output
+// by level. Like other built-in metrics it is labelled with the service
+// that logged the entry, and entries logged outside of a service,
+// such as during application startup, are not counted.
+// Entries discarded by the output level, hooks, sampling or repeat
+// limiting are not counted either, nor are rlog's own entries.
+//
+//publicapigen:drop
+func (l *Manager) RegisterMetrics(reg *metrics.Registry) {
+	logsTotal := metrics.NewCounterGroupInternal[logsTotalLabels, uint64](reg, "e_logs_total", metrics.CounterConfig{
+		EncoreInternal_LabelMapper: func(labels logsTotalLabels) []metrics.KeyValue {
+			return []metrics.KeyValue{
+				{Key: "level", Value: labels.level},
+			}
+		},
+	})
+
+	// Resolve the counter of each level up front,
+	// to keep label lookups off the logging hot path.
+	var counters [numLevels]*metrics.Counter[uint64]
+	for i := range counters {
+		counters[i] = logsTotal.With(logsTotalLabels{level: Level(i).String()})
+	}
+	l.updateConfig(func(c *config) {
+		c.logsTotal = &counters
+	})
+}
+
+// countLog increments the log volume metric of level, if registered.
+func (c *config) countLog(level Level) {
+	if c.logsTotal != nil && int(level) < len(c.logsTotal) {
+		c.logsTotal[level].Increment()
+	}
+}
@@ -0,0 +1,73 @@
+package rlog
+
+import (
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// Logr returns a logr.Logger that writes log entries through l,
+// so that libraries logging with logr are correlated with the
+// trace and span of the current request like any other rlog entry.
+//
+// Info messages at verbosity level 0 are logged at info level,
+// level 1 at debug level and higher levels at trace level.
+// Errors are logged at error level with the error as the "error" field.
+// Names added with WithName are joined with "/" and logged as
+// the "logger" field.
+func (l *Manager) Logr() logr.Logger {
+	return logr.New(&logrSink{mgr: l})
+}
+
+type logrSink struct {
+	mgr    *Manager
+	fields []any  // key-value pairs added with WithValues
+	name   string // the names added with WithName, joined with "/"
+}
+
+func (s *logrSink) Init(logr.RuntimeInfo) {}
+
+func (s *logrSink) Enabled(level int) bool {
+	return s.mgr.enabled(fromLogrLevel(level))
+}
+
+func (s *logrSink) Info(level int, msg string, keysAndValues ...any) {
+	s.mgr.logAdapted(fromLogrLevel(level), msg, s.contextFields(), s.mgr.checkPairs(keysAndValues))
+}
+
+func (s *logrSink) Error(err error, msg string, keysAndValues ...any) {
+	fields := append([]any{"error", err}, s.mgr.checkPairs(keysAndValues)...)
+	s.mgr.logAdapted(LevelError, msg, s.contextFields(), fields)
+}
+
+func (s *logrSink) WithValues(keysAndValues ...any) logr.LogSink {
+	fields := append(s.fields[:len(s.fields):len(s.fields)], s.mgr.checkPairs(keysAndValues)...)
+	return &logrSink{mgr: s.mgr, fields: fields, name: s.name}
+}
+
+func (s *logrSink) WithName(name string) logr.LogSink {
+	if s.name != "" {
+		name = strings.Join([]string{s.name, name}, "/")
+	}
+	return &logrSink{mgr: s.mgr, fields: s.fields, name: name}
+}
+
+// contextFields returns the fields to log as context for every entry.
+func (s *logrSink) contextFields() []any {
+	if s.name == "" {
+		return s.fields
+	}
+	return append([]any{"logger", s.name}, s.fields...)
+}
+
+// fromLogrLevel reports the Level corresponding to the logr verbosity level v.
+func fromLogrLevel(v int) Level {
+	switch {
+	case v <= 0:
+		return LevelInfo
+	case v == 1:
+		return LevelDebug
+	default:
+		return LevelTrace
+	}
+}
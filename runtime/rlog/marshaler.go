@@ -0,0 +1,63 @@
+package rlog
+
+import (
+	"bytes"
+
+	"github.com/rs/zerolog"
+)
+
+// FieldMarshaler is implemented by types that control their own structured
+// representation when logged as a field value, rather than being encoded
+// as JSON using their exported fields.
+//
+// The value is logged as a nested object holding the returned fields,
+// both in the log output and in traces. Field values are logged like
+// any other value, and may themselves implement FieldMarshaler.
+// Fields whose keys are redacted by SetRedactedKeys are redacted
+// within the object as well.
+//
+//	func (u User) MarshalLogFields() []rlog.Field {
+//		return []rlog.Field{rlog.String("id", u.ID), rlog.Bool("admin", u.Admin)}
+//	}
+//
+// Values implementing zerolog.LogObjectMarshaler are supported in the same way.
+type FieldMarshaler interface {
+	MarshalLogFields() []Field
+}
+
+// objectJSON is the JSON object representation of a value
+// implementing FieldMarshaler or zerolog.LogObjectMarshaler.
+type objectJSON []byte
+
+// MarshalJSON implements json.Marshaler, for sinks encoding
+// field values as JSON.
+func (o objectJSON) MarshalJSON() ([]byte, error) {
+	return o, nil
+}
+
+// marshalFields returns the JSON object representation of m.
+func (c *config) marshalFields(m FieldMarshaler) objectJSON {
+	fields := c.resolveFields(fieldPairs(m.MarshalLogFields()))
+	return c.marshalObject(func(ev *zerolog.Event) {
+		for i := 0; i < len(fields); i += 2 {
+			addEventEntry(ev, fields[i].(string), c.eventValue(fields[i+1]), c.jsonEncoder)
+		}
+	})
+}
+
+// marshalZerologObject returns the JSON object representation of m.
+func (c *config) marshalZerologObject(m zerolog.LogObjectMarshaler) objectJSON {
+	return c.marshalObject(func(ev *zerolog.Event) {
+		ev.EmbedObject(m)
+	})
+}
+
+// marshalObject returns the JSON object written by add.
+func (c *config) marshalObject(add func(ev *zerolog.Event)) objectJSON {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	ev := logger.Log()
+	add(ev)
+	ev.Send()
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
@@ -0,0 +1,95 @@
+package rlog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// moneyValue is a monetary amount, logged with Money.
+type moneyValue struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+
+	// InvalidCurrency is set if Currency is not a valid currency code.
+	InvalidCurrency bool `json:"invalid_currency,omitempty"`
+
+	// Display is the amount formatted for display,
+	// set only when logging to the console.
+	Display string `json:"display,omitempty"`
+}
+
+// Money constructs a Field for a monetary amount, rendered as an object
+// with the fields "amount" and "currency".
+//
+// The amount is given in the minor unit of the currency (such as cents),
+// and is logged as an exact integer to avoid the rounding errors of
+// floating-point amounts. The currency is an ISO 4217 code such as "USD";
+// codes that are not three uppercase letters are logged as given,
+// with an additional "invalid_currency" marker.
+// When logging to the console, the object also includes the amount
+// formatted for display (such as "12.34 USD").
+func Money(key string, amountMinor int64, currency string) Field {
+	return Field{key, moneyValue{
+		Amount:          amountMinor,
+		Currency:        currency,
+		InvalidCurrency: !validCurrencyCode(currency),
+	}}
+}
+
+// validCurrencyCode reports whether code is formatted
+// as an ISO 4217 alphabetic currency code.
+func validCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < 'A' || code[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// currencyExponents are the number of minor unit digits of the
+// currencies that don't use the common exponent of 2.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// formatMoney renders m for display, such as "12.34 USD".
+func formatMoney(m moneyValue) string {
+	exp, ok := currencyExponents[m.Currency]
+	if !ok {
+		exp = 2
+	}
+
+	var b strings.Builder
+	amount := m.Amount
+	if amount < 0 {
+		b.WriteByte('-')
+	}
+	digits := strconv.FormatUint(absInt64(amount), 10)
+	if exp > 0 {
+		if len(digits) <= exp {
+			digits = strings.Repeat("0", exp-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+	}
+	b.WriteString(digits)
+	b.WriteByte(' ')
+	b.WriteString(m.Currency)
+	return b.String()
+}
+
+// absInt64 returns the absolute value of x as an uint64,
+// which unlike int64 can represent the absolute value of math.MinInt64.
+func absInt64(x int64) uint64 {
+	if x < 0 {
+		return uint64(-(x + 1)) + 1
+	}
+	return uint64(x)
+}
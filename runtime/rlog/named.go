@@ -0,0 +1,66 @@
+package rlog
+
+import (
+	"strings"
+)
+
+// Named returns a logging context for the component with the given name,
+// such as "billing.invoicer". Entries logged with it include the name as
+// the "logger" field, and are written to the log output according to the
+// level set for the name with SetNamedLevel, if any.
+//
+// Names are hierarchical, with the parts separated by dots.
+// Use Ctx.Named to derive the name of a sub-component.
+func (l *Manager) Named(name string) Ctx {
+	ctx := l.With()
+	ctx.name = name
+	return ctx
+}
+
+// Named returns a logging context for the sub-component with the given name,
+// which is joined to the name of ctx with a dot. If ctx is not named,
+// it is equivalent to Manager.Named. The original ctx is not affected.
+func (ctx Ctx) Named(name string) Ctx {
+	if ctx.name != "" {
+		name = ctx.name + "." + name
+	}
+	ctx.name = name
+	return ctx
+}
+
+// SetNamedLevel sets the minimum level of log entries written to the log
+// output by the logger with the given name and its descendants, overriding
+// the levels set with SetLevel and SetServiceLevel. If levels are set for
+// several ancestors of a logger, the level of the closest ancestor applies.
+//
+//	mgr.SetNamedLevel("billing", rlog.LevelWarn)
+//	mgr.SetNamedLevel("billing.invoicer", rlog.LevelDebug)
+func (l *Manager) SetNamedLevel(name string, level Level) {
+	l.updateConfig(func(c *config) {
+		if c.namedLevels == nil {
+			c.namedLevels = make(map[string]Level)
+		}
+		c.namedLevels[name] = level
+	})
+}
+
+// ResetNamedLevel removes the level set with SetNamedLevel for the given name.
+func (l *Manager) ResetNamedLevel(name string) {
+	l.updateConfig(func(c *config) {
+		delete(c.namedLevels, name)
+	})
+}
+
+// namedLevel reports the level set for name or its closest ancestor.
+func (c *config) namedLevel(name string) (Level, bool) {
+	for {
+		if level, ok := c.namedLevels[name]; ok {
+			return level, true
+		}
+		idx := strings.LastIndexByte(name, '.')
+		if idx < 0 {
+			return 0, false
+		}
+		name = name[:idx]
+	}
+}
@@ -0,0 +1,53 @@
+package rlog
+
+import (
+	"time"
+)
+
+// Observe calls fn and logs the outcome of the operation op.
+//
+// If fn succeeds, it logs an info-level entry with the "op" and "duration"
+// fields. If fn fails, it logs an error-level entry that additionally
+// includes the error and the stack trace of the caller of Observe.
+// In both cases it returns the results of fn unchanged.
+//
+//	user, err := rlog.Observe(mgr, "fetch user", func() (*User, error) {
+//		return db.GetUser(ctx, id)
+//	})
+func Observe[T any](l *Manager, op string, fn func() (T, error)) (T, error) {
+	start := time.Now()
+	val, err := fn()
+	l.observed(op, time.Since(start), err)
+	return val, err
+}
+
+// observed logs the outcome of an operation run by Observe.
+// It must be called directly by Observe for the stack trace to be correct.
+func (l *Manager) observed(op string, dur time.Duration, err error) {
+	fields := []any{"op", op, "duration", dur}
+	if err == nil {
+		l.doLog(LevelInfo, l.rt.Logger().Info(), op+" succeeded", nil, fields, logOpts{callerSkip: 2})
+		return
+	}
+	fields = append(fields, "error", err)
+	l.doLog(LevelError, l.rt.Logger().Error(), op+" failed", nil, fields, logOpts{stack: true, callerSkip: 2})
+}
+
+// WarnIfSlow starts timing an operation and returns a function to call
+// when it completes. The returned function logs a warning with the
+// "op", "duration" and "slow" fields if the operation took longer than
+// threshold, and logs nothing otherwise.
+//
+//	done := mgr.WarnIfSlow(100 * time.Millisecond)
+//	defer done("render page")
+func (l *Manager) WarnIfSlow(threshold time.Duration) func(op string) {
+	start := time.Now()
+	return func(op string) {
+		dur := time.Since(start)
+		if dur <= threshold {
+			return
+		}
+		fields := []any{"op", op, "duration", dur, "slow", true}
+		l.doLog(LevelWarn, l.rt.Logger().Warn(), op+" was slow", nil, fields, logOpts{callerSkip: 1})
+	}
+}
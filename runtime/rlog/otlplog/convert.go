@@ -0,0 +1,122 @@
+package otlplog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+
+	"encore.dev/rlog"
+)
+
+// convertRecord converts a log entry to an OTLP log record.
+func convertRecord(rec rlog.Record) *logspb.LogRecord {
+	ts := uint64(rec.Time.UnixNano())
+	lr := &logspb.LogRecord{
+		TimeUnixNano:         ts,
+		ObservedTimeUnixNano: ts,
+		SeverityNumber:       severityNumber(rec.Level),
+		SeverityText:         severityText(rec.Level),
+		Body:                 stringValue(rec.Message),
+		Attributes:           make([]*commonpb.KeyValue, 0, len(rec.Fields)),
+	}
+	if rec.TraceID != ([16]byte{}) {
+		lr.TraceId = append([]byte(nil), rec.TraceID[:]...)
+	}
+	if rec.SpanID != ([8]byte{}) {
+		lr.SpanId = append([]byte(nil), rec.SpanID[:]...)
+	}
+	for _, f := range rec.Fields {
+		lr.Attributes = append(lr.Attributes, &commonpb.KeyValue{Key: f.Key, Value: anyValue(f.Value)})
+	}
+	return lr
+}
+
+// severityNumber maps a log level to the corresponding OpenTelemetry severity number.
+func severityNumber(level rlog.Level) logspb.SeverityNumber {
+	switch level {
+	case rlog.LevelTrace:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_TRACE
+	case rlog.LevelDebug:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG
+	case rlog.LevelInfo:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case rlog.LevelWarn:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case rlog.LevelError:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
+
+// severityText returns the name of a log level as used in the log output.
+func severityText(level rlog.Level) string {
+	switch level {
+	case rlog.LevelTrace:
+		return "trace"
+	case rlog.LevelDebug:
+		return "debug"
+	case rlog.LevelInfo:
+		return "info"
+	case rlog.LevelWarn:
+		return "warn"
+	case rlog.LevelError:
+		return "error"
+	default:
+		return ""
+	}
+}
+
+// anyValue converts a field value to an OTLP attribute value.
+func anyValue(val any) *commonpb.AnyValue {
+	switch v := val.(type) {
+	case nil:
+		return &commonpb.AnyValue{}
+	case string:
+		return stringValue(v)
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case error:
+		return stringValue(v.Error())
+	case time.Time:
+		return stringValue(v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return intValue(int64(v))
+	case []byte:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v}}
+	}
+
+	// Handle named types by their underlying kind.
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.String:
+		return stringValue(rv.String())
+	case reflect.Bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: rv.Bool()}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intValue(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return intValue(int64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: rv.Float()}}
+	}
+
+	// Other values are exported as JSON, like in the log output.
+	data, err := json.Marshal(val)
+	if err != nil {
+		return stringValue(fmt.Sprintf("marshaling error: %v", err))
+	}
+	return stringValue(string(data))
+}
+
+func stringValue(s string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: s}}
+}
+
+func intValue(i int64) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: i}}
+}
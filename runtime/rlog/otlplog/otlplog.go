@@ -0,0 +1,339 @@
+// Package otlplog exports log entries to an OpenTelemetry collector
+// using the OTLP/gRPC protocol.
+//
+// The exporter is an rlog sink; add it to rlog to start exporting:
+//
+//	exp, err := otlplog.New(otlplog.Config{
+//		Endpoint:    "otel-collector:4317",
+//		Insecure:    true,
+//		ServiceName: "my-app",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	rlog.AddSink(exp)
+//
+// Log entries are exported in batches in the background. Fields are
+// exported as log record attributes, levels as severity numbers, and the
+// trace and span ids of the request an entry was logged in as the
+// record's trace context.
+package otlplog
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"encore.dev/rlog"
+)
+
+// Config configures an Exporter.
+type Config struct {
+	// Endpoint is the address of the OTLP/gRPC collector, such as "localhost:4317".
+	Endpoint string
+
+	// Insecure disables transport security for the connection to the collector.
+	Insecure bool
+
+	// Headers are additional gRPC metadata sent with each export,
+	// typically used for authentication.
+	Headers map[string]string
+
+	// ServiceName is reported as the "service.name" resource attribute.
+	ServiceName string
+
+	// ResourceAttributes are additional attributes of the resource
+	// the log entries are reported for, such as "deployment.environment".
+	ResourceAttributes map[string]string
+
+	// BatchSize is the maximum number of log entries exported at once.
+	// It defaults to 512.
+	BatchSize int
+
+	// FlushInterval is the maximum time a log entry is buffered
+	// before it is exported. It defaults to one second.
+	FlushInterval time.Duration
+
+	// QueueSize is the maximum number of log entries buffered for export.
+	// When the queue is full, for example because the collector is
+	// unavailable, further log entries are dropped rather than blocking
+	// the application. It defaults to 2048.
+	QueueSize int
+
+	// MaxRetries is the maximum number of times a failed export is retried,
+	// with exponential backoff, before its log entries are dropped.
+	// Only errors the collector reports as temporary are retried.
+	// It defaults to 5; a negative value disables retries.
+	MaxRetries int
+
+	// ExportTimeout is the timeout of a single export attempt.
+	// It defaults to 10 seconds.
+	ExportTimeout time.Duration
+
+	// ErrorHandler, if non-nil, is called when log entries are dropped
+	// because they could not be exported. It must not log using rlog.
+	ErrorHandler func(err error)
+
+	// DialOptions are additional options for connecting to the collector.
+	DialOptions []grpc.DialOption
+}
+
+const (
+	defaultBatchSize     = 512
+	defaultFlushInterval = time.Second
+	defaultQueueSize     = 2048
+	defaultMaxRetries    = 5
+	defaultExportTimeout = 10 * time.Second
+
+	initialBackoff = 100 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// Exporter exports log entries to an OpenTelemetry collector.
+// It implements rlog.Sink.
+type Exporter struct {
+	cfg      Config
+	conn     *grpc.ClientConn
+	client   collogspb.LogsServiceClient
+	md       metadata.MD
+	resource *resourcepb.Resource
+
+	queue    chan rlog.Record
+	flushReq chan chan struct{}
+	done     chan struct{} // closed by Shutdown
+	stopped  chan struct{} // closed when the export loop exits
+	stopOnce sync.Once
+	dropped  uint64 // accessed atomically
+}
+
+var _ rlog.Sink = (*Exporter)(nil)
+
+// New creates an Exporter that exports to the collector configured by cfg.
+// It connects to the collector in the background; log entries are buffered
+// until the connection has been established.
+func New(cfg Config) (*Exporter, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("otlplog: no endpoint configured")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultFlushInterval
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.ExportTimeout <= 0 {
+		cfg.ExportTimeout = defaultExportTimeout
+	}
+
+	creds := insecure.NewCredentials()
+	if !cfg.Insecure {
+		creds = credentials.NewClientTLSFromCert(nil, "")
+	}
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, cfg.DialOptions...)
+	conn, err := grpc.Dial(cfg.Endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Exporter{
+		cfg:      cfg,
+		conn:     conn,
+		client:   collogspb.NewLogsServiceClient(conn),
+		md:       metadata.New(cfg.Headers),
+		resource: newResource(cfg.ServiceName, cfg.ResourceAttributes),
+		queue:    make(chan rlog.Record, cfg.QueueSize),
+		flushReq: make(chan chan struct{}),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go e.run()
+	return e, nil
+}
+
+// Emit queues the log entry for export. It never blocks;
+// if the queue is full, the entry is dropped.
+func (e *Exporter) Emit(rec rlog.Record) {
+	select {
+	case <-e.done:
+		atomic.AddUint64(&e.dropped, 1)
+		return
+	default:
+	}
+
+	select {
+	case e.queue <- rec:
+	default:
+		atomic.AddUint64(&e.dropped, 1)
+	}
+}
+
+// Dropped reports the number of log entries that have been dropped,
+// either because the queue was full or because they could not be exported.
+func (e *Exporter) Dropped() uint64 {
+	return atomic.LoadUint64(&e.dropped)
+}
+
+// Flush exports all queued log entries, waiting until they have been
+// exported or ctx is done.
+func (e *Exporter) Flush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case e.flushReq <- ack:
+	case <-e.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown exports all queued log entries and closes the connection
+// to the collector, waiting until done or ctx is done. Log entries
+// emitted after Shutdown has been called are dropped.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.stopOnce.Do(func() { close(e.done) })
+	select {
+	case <-e.stopped:
+		return e.conn.Close()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run is the export loop, which batches queued log entries
+// and exports them until the exporter is shut down.
+func (e *Exporter) run() {
+	defer close(e.stopped)
+	ticker := time.NewTicker(e.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*logspb.LogRecord, 0, e.cfg.BatchSize)
+	add := func(rec rlog.Record) {
+		batch = append(batch, convertRecord(rec))
+		if len(batch) >= e.cfg.BatchSize {
+			e.export(batch)
+			batch = make([]*logspb.LogRecord, 0, e.cfg.BatchSize)
+		}
+	}
+	flush := func() {
+		// Export everything queued so far.
+		for n := len(e.queue); n > 0; n-- {
+			add(<-e.queue)
+		}
+		if len(batch) > 0 {
+			e.export(batch)
+			batch = make([]*logspb.LogRecord, 0, e.cfg.BatchSize)
+		}
+	}
+
+	for {
+		select {
+		case rec := <-e.queue:
+			add(rec)
+		case <-ticker.C:
+			flush()
+		case ack := <-e.flushReq:
+			flush()
+			close(ack)
+		case <-e.done:
+			flush()
+			return
+		}
+	}
+}
+
+// export exports records, retrying temporary failures.
+// The records are dropped if they cannot be exported.
+func (e *Exporter) export(records []*logspb.LogRecord) {
+	req := &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: e.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: "encore.dev/rlog"},
+				LogRecords: records,
+			}},
+		}},
+	}
+
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), e.cfg.ExportTimeout)
+		ctx = metadata.NewOutgoingContext(ctx, e.md)
+		_, err := e.client.Export(ctx, req)
+		cancel()
+		if err == nil {
+			return
+		} else if !retryable(err) || attempt >= e.cfg.MaxRetries {
+			atomic.AddUint64(&e.dropped, uint64(len(records)))
+			if e.cfg.ErrorHandler != nil {
+				e.cfg.ErrorHandler(err)
+			}
+			return
+		}
+
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// retryable reports whether a failed export should be retried,
+// as defined by the OTLP specification.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// newResource returns the resource describing the exporting service.
+func newResource(serviceName string, attrs map[string]string) *resourcepb.Resource {
+	res := &resourcepb.Resource{}
+	add := func(key, val string) {
+		res.Attributes = append(res.Attributes, &commonpb.KeyValue{
+			Key:   key,
+			Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: val}},
+		})
+	}
+	if serviceName != "" {
+		add("service.name", serviceName)
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		add(k, attrs[k])
+	}
+	return res
+}
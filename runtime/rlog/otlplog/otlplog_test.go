@@ -0,0 +1,145 @@
+package otlplog
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"encore.dev/rlog"
+)
+
+type fakeCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	mu       sync.Mutex
+	failures int // number of requests to fail with Unavailable
+	requests []*collogspb.ExportLogsServiceRequest
+}
+
+func (c *fakeCollector) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	c.requests = append(c.requests, req)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func (c *fakeCollector) records() []*logspb.LogRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var recs []*logspb.LogRecord
+	for _, req := range c.requests {
+		recs = append(recs, req.ResourceLogs[0].ScopeLogs[0].LogRecords...)
+	}
+	return recs
+}
+
+func newTestExporter(t *testing.T, collector *fakeCollector, cfg Config) *Exporter {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(srv, collector)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	cfg.Endpoint = "bufconn"
+	cfg.Insecure = true
+	cfg.DialOptions = append(cfg.DialOptions, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	exp, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return exp
+}
+
+func TestExport(t *testing.T) {
+	collector := &fakeCollector{failures: 1}
+	exp := newTestExporter(t, collector, Config{BatchSize: 2, FlushInterval: time.Hour})
+
+	traceID := [16]byte{1, 2, 3}
+	spanID := [8]byte{4, 5, 6}
+	exp.Emit(rlog.Record{
+		Time:    time.Unix(10, 0),
+		Level:   rlog.LevelWarn,
+		Message: "disk almost full",
+		Fields:  []rlog.Field{rlog.Int("percent", 95), rlog.String("disk", "sda")},
+		TraceID: traceID,
+		SpanID:  spanID,
+	})
+	exp.Emit(rlog.Record{Time: time.Unix(11, 0), Level: rlog.LevelError, Message: "disk full"})
+	exp.Emit(rlog.Record{Time: time.Unix(12, 0), Level: rlog.LevelInfo, Message: "disk freed"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := exp.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	recs := collector.records()
+	if len(recs) != 3 {
+		t.Fatalf("got %d exported records, want 3", len(recs))
+	}
+	collector.mu.Lock()
+	numRequests := len(collector.requests)
+	collector.mu.Unlock()
+	if numRequests != 2 {
+		t.Errorf("got %d export requests, want 2", numRequests)
+	}
+	r := recs[0]
+	if r.SeverityNumber != logspb.SeverityNumber_SEVERITY_NUMBER_WARN || r.Body.GetStringValue() != "disk almost full" ||
+		r.TimeUnixNano != uint64(10*time.Second) {
+		t.Errorf("got record %v", r)
+	}
+	if string(r.TraceId) != string(traceID[:]) || string(r.SpanId) != string(spanID[:]) {
+		t.Errorf("got trace id %x, span id %x, want %x, %x", r.TraceId, r.SpanId, traceID, spanID)
+	}
+	if len(r.Attributes) != 2 || r.Attributes[0].Key != "percent" || r.Attributes[0].Value.GetIntValue() != 95 ||
+		r.Attributes[1].Value.GetStringValue() != "sda" {
+		t.Errorf("got attributes %v", r.Attributes)
+	}
+	if recs[1].SeverityNumber != logspb.SeverityNumber_SEVERITY_NUMBER_ERROR || recs[1].TraceId != nil {
+		t.Errorf("got record %v", recs[1])
+	}
+	if exp.Dropped() != 0 {
+		t.Errorf("got %d dropped records, want 0", exp.Dropped())
+	}
+}
+
+func TestEmitQueueFull(t *testing.T) {
+	// Don't start the export loop, so that the queue fills up.
+	exp := &Exporter{queue: make(chan rlog.Record, 2), done: make(chan struct{})}
+	for i := 0; i < 5; i++ {
+		exp.Emit(rlog.Record{Message: "msg"})
+	}
+	if got := exp.Dropped(); got != 3 {
+		t.Errorf("got %d dropped records, want 3", got)
+	}
+}
+
+func TestNewResource(t *testing.T) {
+	res := newResource("my-app", map[string]string{"service.version": "v1", "deployment.environment": "prod"})
+	var got []string
+	for _, kv := range res.Attributes {
+		got = append(got, kv.Key+"="+kv.Value.GetStringValue())
+	}
+	want := []string{"service.name=my-app", "deployment.environment=prod", "service.version=v1"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got resource attributes %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package rlog
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// Output is the destination of the log output. It writes to the primary
+// destination, such as stderr, and to any additional writers added
+// with Manager.AddWriter.
+//
+//publicapigen:drop
+type Output struct {
+	primary io.Writer
+	console io.Writer // formats the log output for the console and writes it to primary
+
+	consoleMode uint32       // 1 if writing to console; accessed atomically
+	mu          sync.Mutex   // serializes updates to writers and async
+	writers     atomic.Value // []io.Writer
+	async       atomic.Value // *asyncQueue, or nil if writing synchronously
+	dropped     uint64       // queued entries dropped; accessed atomically
+	written     uint64       // bytes of log entries written; accessed atomically
+}
+
+// NewOutput returns an Output that writes to primary.
+//
+//publicapigen:drop
+func NewOutput(primary io.Writer) *Output {
+	return &Output{
+		primary: primary,
+		console: zerolog.NewConsoleWriter(func(w *zerolog.ConsoleWriter) {
+			w.Out = primary
+		}),
+	}
+}
+
+// Write writes p to the primary destination and to every additional writer,
+// after any entries queued for writing asynchronously.
+func (o *Output) Write(p []byte) (n int, err error) {
+	return o.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel writes the log entry p at the given level.
+// It implements zerolog.LevelWriter.
+//
+// If asynchronous writing is enabled with Manager.SetAsyncOutput, entries
+// below error level are queued to be written in the background. Other
+// entries are written synchronously after the queued entries,
+// so that they are never dropped.
+func (o *Output) WriteLevel(level zerolog.Level, p []byte) (n int, err error) {
+	atomic.AddUint64(&o.written, uint64(len(p)))
+	return o.write(level, p)
+}
+
+// write writes the log entry p at the given level,
+// without counting its bytes as written.
+func (o *Output) write(level zerolog.Level, p []byte) (n int, err error) {
+	if q, _ := o.async.Load().(*asyncQueue); q != nil {
+		if level < zerolog.ErrorLevel {
+			// zerolog reuses p once we return.
+			q.enqueue(append([]byte(nil), p...))
+			return len(p), nil
+		}
+		q.flush(context.Background())
+	}
+	return o.writeNow(p)
+}
+
+// writeNow writes p to the primary destination and to every additional writer.
+// Errors from additional writers are ignored, so that a failing
+// secondary destination never affects the primary log output.
+func (o *Output) writeNow(p []byte) (n int, err error) {
+	if atomic.LoadUint32(&o.consoleMode) == 1 {
+		n, err = o.console.Write(p)
+	} else {
+		n, err = o.primary.Write(p)
+	}
+	ws, _ := o.writers.Load().([]io.Writer)
+	for _, w := range ws {
+		_, _ = w.Write(p)
+	}
+	return n, err
+}
+
+// uncountedWriter writes to an Output without counting the bytes
+// written, for rlog's own log entries.
+type uncountedWriter struct {
+	o *Output
+}
+
+func (w uncountedWriter) Write(p []byte) (n int, err error) {
+	return w.o.write(zerolog.NoLevel, p)
+}
+
+func (w uncountedWriter) WriteLevel(level zerolog.Level, p []byte) (n int, err error) {
+	return w.o.write(level, p)
+}
+
+// add adds w as an additional writer.
+func (o *Output) add(w io.Writer) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	ws, _ := o.writers.Load().([]io.Writer)
+	o.writers.Store(append(ws[:len(ws):len(ws)], zerolog.SyncWriter(w)))
+}
+
+// setConsole configures whether the primary destination receives
+// human-readable console output rather than JSON.
+func (o *Output) setConsole(console bool) {
+	var mode uint32
+	if console {
+		mode = 1
+	}
+	atomic.StoreUint32(&o.consoleMode, mode)
+}
+
+// SetOutput configures the Output the log output is written to,
+// which enables adding writers with AddWriter and switching between
+// console and JSON output with SetConsoleOutput.
+//
+//publicapigen:drop
+func (l *Manager) SetOutput(out *Output) {
+	l.updateConfig(func(c *config) {
+		c.output = out
+		if out != nil {
+			out.setConsole(c.console)
+		}
+	})
+}
+
+// AddWriter adds a writer that receives a copy of the log output,
+// in order to write it to a secondary destination such as a file,
+// syslog or an HTTP collector, in addition to the primary log output.
+//
+// The writer receives each log entry as a separate call to Write,
+// in the JSON format, even when the primary log output is written
+// to a human-readable console. Calls to Write are serialized, and
+// errors returned by it are ignored.
+// AddWriter does nothing if the Manager has no Output configured.
+func (l *Manager) AddWriter(w io.Writer) {
+	if out := l.config().output; out != nil {
+		out.add(w)
+	}
+}
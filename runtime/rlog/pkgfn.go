@@ -2,9 +2,30 @@
 
 package rlog
 
+import (
+	"context"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+
+	"encore.dev/types/uuid"
+)
+
 //publicapigen:drop
 var Singleton *Manager
 
+// Trace logs a trace-level message, for very verbose diagnostics.
+// The variadic key-value pairs are treated as they are in With.
+//
+// Trace-level messages are not written to the log output by default,
+// but are recorded in the trace of the current request, if it is traced.
+func Trace(msg string, keysAndValues ...any) {
+	Singleton.Trace(msg, keysAndValues...)
+}
+
 // Debug logs a debug-level message.
 // The variadic key-value pairs are treated as they are in With.
 func Debug(msg string, keysAndValues ...any) {
@@ -34,3 +55,447 @@ func Error(msg string, keysAndValues ...any) {
 func With(keysAndValues ...any) Ctx {
 	return Singleton.With(keysAndValues...)
 }
+
+// DebugFields is like Debug but takes typed fields instead of key-value pairs.
+func DebugFields(msg string, fields ...Field) {
+	Singleton.DebugFields(msg, fields...)
+}
+
+// InfoFields is like Info but takes typed fields instead of key-value pairs.
+func InfoFields(msg string, fields ...Field) {
+	Singleton.InfoFields(msg, fields...)
+}
+
+// WarnFields is like Warn but takes typed fields instead of key-value pairs.
+func WarnFields(msg string, fields ...Field) {
+	Singleton.WarnFields(msg, fields...)
+}
+
+// ErrorFields is like Error but takes typed fields instead of key-value pairs.
+func ErrorFields(msg string, fields ...Field) {
+	Singleton.ErrorFields(msg, fields...)
+}
+
+// WithFieldSet is like With but takes a precomputed set of typed fields.
+func WithFieldSet(fields ...Field) Ctx {
+	return Singleton.WithFieldSet(fields...)
+}
+
+// W3CTraceParent renders the current request's trace and span ids
+// as a W3C Trace Context "traceparent" header value.
+// It reports false if there is no current request.
+func W3CTraceParent() (string, bool) {
+	return Singleton.W3CTraceParent()
+}
+
+// SetIncludeTraceParent configures whether log entries emitted within
+// a request include the W3C "traceparent" value as a field.
+func SetIncludeTraceParent(include bool) {
+	Singleton.SetIncludeTraceParent(include)
+}
+
+// SetLogBaggageKeys configures the W3C Baggage members of incoming requests
+// that are attached as fields to every log entry emitted within the request.
+// By default none are. See Manager.SetLogBaggageKeys.
+func SetLogBaggageKeys(keys ...string) {
+	Singleton.SetLogBaggageKeys(keys...)
+}
+
+// SetIncludeSpanID configures whether warnings and errors logged within
+// a traced request include the id of the span they were logged in as the
+// "span_id" field. Applications enable it unless the runtime config
+// disables it.
+func SetIncludeSpanID(include bool) {
+	Singleton.SetIncludeSpanID(include)
+}
+
+// NewCollector returns a new Collector for summarizing
+// the outcomes of many sub-operations in a single log entry.
+func NewCollector() *Collector {
+	return Singleton.Collector()
+}
+
+// Critical logs a message that must never be dropped, such as an audit or security event.
+// It is never subject to sampling, rate limiting or buffering, always includes a stack trace,
+// and is guaranteed to have been written by the time Critical returns.
+// It is considerably more expensive than Error; use it sparingly.
+// The variadic key-value pairs are treated as they are in With.
+func Critical(msg string, keysAndValues ...any) {
+	Singleton.Critical(msg, keysAndValues...)
+}
+
+// SetByteUnits configures the units used to render byte counts
+// logged with Bytes in human-readable form. It defaults to SIUnits.
+func SetByteUnits(units ByteUnits) {
+	Singleton.SetByteUnits(units)
+}
+
+// Job starts logging the lifecycle of a background job with the given name.
+// It returns a logging context tagged with the job name and a generated job id,
+// detached from the current request, and a done function that must be called
+// with the job's result when it completes.
+func Job(name string) (jobLogger Ctx, done func(err error)) {
+	return Singleton.Job(name)
+}
+
+// SetSensitiveHeaders configures additional headers, beyond the default set,
+// whose values are redacted when logged with Headers.
+func SetSensitiveHeaders(names ...string) {
+	Singleton.SetSensitiveHeaders(names...)
+}
+
+// SnapshotConfig returns a snapshot of the current logging configuration,
+// for later use with RestoreConfig.
+func SnapshotConfig() Config {
+	return Singleton.SnapshotConfig()
+}
+
+// RestoreConfig restores the logging configuration to that of the given snapshot.
+func RestoreConfig(cfg Config) {
+	Singleton.RestoreConfig(cfg)
+}
+
+// SetIncludePprofLabels configures whether log entries include the
+// runtime/pprof labels of the context passed to FromContext
+// as "pprof_<key>" fields.
+// If keys are given, only labels with those keys are included.
+func SetIncludePprofLabels(include bool, keys ...string) {
+	Singleton.SetIncludePprofLabels(include, keys...)
+}
+
+// SetRequestTraceOnly configures whether the log entries of the current request
+// are recorded in the request's trace only, and not written to the log output.
+func SetRequestTraceOnly(traceOnly bool) {
+	Singleton.SetRequestTraceOnly(traceOnly)
+}
+
+// SetGlobalFields configures fields that are attached to every log entry,
+// such as the build version, region or pod name. It is intended to be
+// called once during service initialization. Calling it again replaces
+// the fields, and calling it with no fields removes them.
+//
+//	rlog.SetGlobalFields("region", os.Getenv("REGION"), "pod", os.Getenv("POD_NAME"))
+func SetGlobalFields(keysAndValues ...any) {
+	Singleton.SetGlobalFields(keysAndValues...)
+}
+
+// SetRequestDebug configures whether debug-level log entries of the current
+// request are written to the log output, regardless of the configured levels.
+func SetRequestDebug(debug bool) {
+	Singleton.SetRequestDebug(debug)
+}
+
+// SetTraceOnlyKeepErrors configures whether error-level log entries are still
+// written to the log output for requests whose logs are recorded in the trace only.
+func SetTraceOnlyKeepErrors(keep bool) {
+	Singleton.SetTraceOnlyKeepErrors(keep)
+}
+
+// SetSelfMetrics configures whether statistics about the logging itself
+// are collected and logged as a summary every minute.
+func SetSelfMetrics(enabled bool) {
+	Singleton.SetSelfMetrics(enabled)
+}
+
+// SetMaxFields configures the maximum number of fields of a log entry.
+// If a log entry has more fields, the lowest-priority fields are dropped.
+// See Priority. A value of zero (the default) means there is no limit.
+func SetMaxFields(n int) {
+	Singleton.SetMaxFields(n)
+}
+
+// SetWorkflowExtractor configures a function that is called for every
+// log entry to attach the "workflow_id" and "run_id" fields.
+// The fields are omitted when it reports false.
+func SetWorkflowExtractor(fn func() (workflowID, runID string, ok bool)) {
+	Singleton.SetWorkflowExtractor(fn)
+}
+
+// WarnIfSlow starts timing an operation and returns a function to call
+// when it completes, which logs a warning if the operation took longer
+// than threshold.
+//
+//	done := rlog.WarnIfSlow(100 * time.Millisecond)
+//	defer done("render page")
+func WarnIfSlow(threshold time.Duration) func(op string) {
+	return Singleton.WarnIfSlow(threshold)
+}
+
+// SetEnvFields configures fields that are attached to every log entry,
+// but only when running in the environment with the given name.
+// The fields are given as key-value pairs, as with With.
+//
+//	rlog.SetEnvFields("staging", "debug_build", true)
+func SetEnvFields(env string, fields ...any) {
+	Singleton.SetEnvFields(env, fields...)
+}
+
+// StartSpan starts an application-defined span with the given name,
+// as a child of the current request's span, which appears in the
+// request's trace. The variadic key-value pairs are treated as they
+// are in With, and are recorded on the span.
+//
+// It returns a logger whose log entries are associated with the span,
+// and a function that ends the span, with optional key-value pairs
+// describing the outcome.
+//
+//	spanLog, end := rlog.StartSpan("resize image", "width", w)
+//	defer end()
+//	spanLog.Info("fetched original")
+func StartSpan(name string, kv ...any) (spanLogger Ctx, end func(kv ...any)) {
+	return Singleton.StartSpan(name, kv...)
+}
+
+// SetStacklessErrors configures errors that are expected during normal
+// operation, such as sql.ErrNoRows, for which no stack trace is captured
+// when they are logged. Errors are matched using errors.Is.
+func SetStacklessErrors(errs ...error) {
+	Singleton.SetStacklessErrors(errs...)
+}
+
+// Progress starts logging the progress of a long-running operation
+// with the given total amount of work. It returns a function to call
+// with the amount of work completed so far, which logs progress
+// at a throttled rate.
+//
+//	report := rlog.Progress(len(items))
+//	for i, item := range items {
+//		process(item)
+//		report(i + 1)
+//	}
+func Progress(total int) func(current int) {
+	return Singleton.Progress(total)
+}
+
+// LogWithSpan logs a message at the given level, associating it in the
+// trace with the span identified by spanID rather than the current request.
+// It is intended for tooling that imports or replays logs from other systems.
+//
+// The span id is given in the first 8 bytes of spanID, and the remaining
+// bytes must be zero. If spanID is not a valid span id, the message is
+// still written to the log output but not recorded in the trace.
+func LogWithSpan(spanID uuid.UUID, level Level, msg string, kv ...any) {
+	Singleton.LogWithSpan(spanID, level, msg, kv...)
+}
+
+// SetJSONEncoder configures the function used to encode values that are
+// logged as JSON, such as structs, maps and slices, in place of the
+// standard encoding/json. The function must be safe for concurrent use
+// and must produce valid JSON. Passing nil restores the default encoder.
+func SetJSONEncoder(fn func(v any) ([]byte, error)) {
+	Singleton.SetJSONEncoder(fn)
+}
+
+// AddSink adds a sink that receives all log entries written to the log
+// output, in order to forward them to another destination.
+func AddSink(s Sink) {
+	Singleton.AddSink(s)
+}
+
+// Fatal logs a message at fatal level, including a stack trace, and then
+// terminates the process with exit code 1. Before terminating, it flushes
+// the traces recorded so far by running requests and any pending log entries.
+// The variadic key-value pairs are treated as they are in With.
+func Fatal(msg string, keysAndValues ...any) {
+	Singleton.Fatal(msg, keysAndValues...)
+}
+
+// Panic logs a message at panic level, including a stack trace, and then
+// panics with msg. Before panicking, it flushes any pending log entries.
+// The variadic key-value pairs are treated as they are in With.
+func Panic(msg string, keysAndValues ...any) {
+	Singleton.Panic(msg, keysAndValues...)
+}
+
+// SetLevel sets the minimum level of log entries written to the log output.
+// Entries below it are still recorded in traces.
+//
+//	rlog.SetLevel(rlog.LevelInfo)
+func SetLevel(level Level) {
+	Singleton.SetLevel(level)
+}
+
+// CurrentLevel reports the minimum level of log entries
+// written to the log output, as configured with SetLevel.
+func CurrentLevel() Level {
+	return Singleton.CurrentLevel()
+}
+
+// SetServiceLevel sets the minimum level of log entries written to the
+// log output by the given service, overriding the level set with SetLevel.
+//
+//	rlog.SetServiceLevel("usersvc", rlog.LevelDebug)
+func SetServiceLevel(service string, level Level) {
+	Singleton.SetServiceLevel(service, level)
+}
+
+// ResetServiceLevel removes the level set with SetServiceLevel for the
+// given service, so that it again uses the level set with SetLevel.
+func ResetServiceLevel(service string) {
+	Singleton.ResetServiceLevel(service)
+}
+
+// Logr returns a logr.Logger that writes log entries through rlog,
+// so that libraries logging with logr are correlated with the
+// trace and span of the current request.
+func Logr() logr.Logger {
+	return Singleton.Logr()
+}
+
+// ZapCore returns a zapcore.Core that writes log entries through rlog,
+// so that code logging with zap is correlated with the trace and
+// span of the current request.
+//
+//	logger := zap.New(rlog.ZapCore())
+func ZapCore() zapcore.Core {
+	return Singleton.ZapCore()
+}
+
+// FromContext returns the logging context carried by ctx, as added with
+// NewContext. If ctx carries no logging context it returns an empty
+// logging context, which logs like the package-level functions.
+//
+//	func helper(ctx context.Context) {
+//		rlog.FromContext(ctx).Info("doing work")
+//	}
+func FromContext(ctx context.Context) Ctx {
+	return Singleton.FromContext(ctx)
+}
+
+// SetRedactedKeys configures the keys of fields whose values are redacted
+// from log entries, such as "email" or "ssn". Keys are matched
+// case-insensitively, and calling it again replaces the previous keys.
+func SetRedactedKeys(keys ...string) {
+	Singleton.SetRedactedKeys(keys...)
+}
+
+// AddRedactionPattern configures a pattern whose matches are redacted
+// from the string values of all fields, regardless of their key.
+//
+//	rlog.AddRedactionPattern(regexp.MustCompile(`\b(?:\d[ -]?){12,15}\d\b`))
+func AddRedactionPattern(re *regexp.Regexp) {
+	Singleton.AddRedactionPattern(re)
+}
+
+// SetSampling configures rlog to keep only 1 in n log entries at the
+// given level, discarding the rest from both the log output and traces.
+// A value of n less than or equal to 1 keeps all entries, which is the default.
+//
+//	rlog.SetSampling(rlog.LevelDebug, 100)
+func SetSampling(level Level, n int) {
+	Singleton.SetSampling(level, n)
+}
+
+// SetRepeatLimit configures rlog to write at most n identical log entries
+// per interval, where entries are identical if they have the same level,
+// message, field keys and primitive field values. Suppressed entries are summarized by an entry with
+// a "repeated" field holding their number.
+// A value of n less than or equal to zero disables the limit, which is the default.
+//
+//	rlog.SetRepeatLimit(10, time.Second)
+func SetRepeatLimit(n int, interval time.Duration) {
+	Singleton.SetRepeatLimit(n, interval)
+}
+
+// AddWriter adds a writer that receives a copy of the log output,
+// in order to write it to a secondary destination such as a file,
+// syslog or an HTTP collector, in addition to the primary log output.
+// The writer receives each log entry in the JSON format.
+func AddWriter(w io.Writer) {
+	Singleton.AddWriter(w)
+}
+
+// SetConsoleOutput configures whether the log output is written
+// in a human-friendly console format, as opposed to structured JSON.
+func SetConsoleOutput(console bool) {
+	Singleton.SetConsoleOutput(console)
+}
+
+// AddHook adds a hook that is called for every log entry before it is
+// written, to enrich its fields or drop it. See Hook for details.
+//
+//	rlog.AddHook(func(level rlog.Level, msg string, fields []any) (bool, []any) {
+//		return false, append(fields, "tenant_id", currentTenant())
+//	})
+func AddHook(h Hook) {
+	Singleton.AddHook(h)
+}
+
+// SetIncludeCaller configures whether log entries include the file and
+// line of the logging call as the "caller" field, such as "svc/user.go:42".
+func SetIncludeCaller(include bool) {
+	Singleton.SetIncludeCaller(include)
+}
+
+// Err logs an error-level message about err, which is logged as the
+// "error" field. If err carries a stack trace, as errors created with
+// the errs package do, it is included in the log output as "error_stack".
+// The variadic key-value pairs are treated as they are in With.
+//
+//	if err != nil {
+//		rlog.Err(err, "could not charge card", "order_id", id)
+//	}
+func Err(err error, msg string, keysAndValues ...any) {
+	Singleton.Err(err, msg, keysAndValues...)
+}
+
+// SetDedupWindow configures rlog to collapse identical log entries written
+// within the given window of each other, where entries are identical if they
+// have the same level, message, field keys and primitive field values. Collapsed entries are summarized
+// by an entry with a "repeated" field holding their number.
+// A window of zero disables deduplication, which is the default.
+//
+//	rlog.SetDedupWindow(5 * time.Second)
+func SetDedupWindow(window time.Duration) {
+	Singleton.SetDedupWindow(window)
+}
+
+// SetAsyncOutput configures the log output to be written asynchronously
+// by a background goroutine, using a queue holding up to queueSize entries.
+// A queueSize less than or equal to zero writes the log output synchronously,
+// which is the default. The policy determines what happens when the queue is full.
+//
+// Entries at error level and above are never dropped, and queued entries
+// are flushed when the application shuts down gracefully.
+//
+//	rlog.SetAsyncOutput(10000, rlog.DropNewest)
+func SetAsyncOutput(queueSize int, policy DropPolicy) {
+	Singleton.SetAsyncOutput(queueSize, policy)
+}
+
+// DroppedOutput reports the number of log entries dropped
+// because the queue of asynchronously written entries was full.
+func DroppedOutput() uint64 {
+	return Singleton.DroppedOutput()
+}
+
+// SetStrictPairs configures whether malformed key-value pairs, such as a key
+// without a value or a key that is not a string, are reported by an
+// error-level log entry and by failing the current test, if any.
+// Malformed pairs are logged leniently either way.
+// Strict mode is enabled by default when running tests.
+func SetStrictPairs(strict bool) {
+	Singleton.SetStrictPairs(strict)
+}
+
+// Named returns a logging context for the component with the given name,
+// such as "billing.invoicer". Entries logged with it include the name as
+// the "logger" field, and are written to the log output according to the
+// level set for the name with SetNamedLevel, if any.
+//
+//	var log = rlog.Named("billing.invoicer")
+func Named(name string) Ctx {
+	return Singleton.Named(name)
+}
+
+// SetNamedLevel sets the minimum level of log entries written to the log
+// output by the logger with the given name and its descendants, overriding
+// the levels set with SetLevel and SetServiceLevel.
+func SetNamedLevel(name string, level Level) {
+	Singleton.SetNamedLevel(name, level)
+}
+
+// ResetNamedLevel removes the level set with SetNamedLevel for the given name.
+func ResetNamedLevel(name string) {
+	Singleton.ResetNamedLevel(name)
+}
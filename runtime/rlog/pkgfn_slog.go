@@ -0,0 +1,16 @@
+//go:build encore_app && go1.21
+
+package rlog
+
+import (
+	"log/slog"
+)
+
+// SlogHandler returns a slog.Handler that writes log records through rlog,
+// so that libraries logging with log/slog are correlated with the
+// trace and span of the current request.
+//
+//	slog.SetDefault(slog.New(rlog.SlogHandler()))
+func SlogHandler() slog.Handler {
+	return Singleton.SlogHandler()
+}
@@ -0,0 +1,47 @@
+package rlog
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// SetIncludePprofLabels configures whether log entries include the
+// runtime/pprof labels of the context they are logged with as fields,
+// which makes it possible to correlate profiles with log entries.
+// The labels are read from the context passed to FromContext,
+// and to the slog.Handler returned by SlogHandler.
+//
+// If keys are given, only labels with those keys are included.
+// Each label is logged as a field named "pprof_<key>".
+//
+// Reading the labels adds a small cost to every such call,
+// so it is disabled by default.
+func (l *Manager) SetIncludePprofLabels(include bool, keys ...string) {
+	var filter map[string]bool
+	if len(keys) > 0 {
+		filter = make(map[string]bool, len(keys))
+		for _, k := range keys {
+			filter[k] = true
+		}
+	}
+	l.updateConfig(func(c *config) {
+		c.includePprofLabels = include
+		c.pprofLabelKeys = filter
+	})
+}
+
+// pprofLabelFields returns the pprof labels of ctx as key-value pairs,
+// restricted to the configured keys, or nil if they are not included.
+func (c *config) pprofLabelFields(ctx context.Context) []any {
+	if !c.includePprofLabels {
+		return nil
+	}
+	var fields []any
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		if c.pprofLabelKeys == nil || c.pprofLabelKeys[key] {
+			fields = append(fields, "pprof_"+key, value)
+		}
+		return true
+	})
+	return fields
+}
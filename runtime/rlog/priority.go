@@ -0,0 +1,84 @@
+package rlog
+
+import (
+	"sort"
+)
+
+// Field priorities, used to decide which fields to keep when a log entry
+// has more fields than allowed by SetMaxFields.
+const (
+	PriorityLow    = 0
+	PriorityMedium = 50 // the priority of fields not given an explicit priority
+	PriorityHigh   = 100
+)
+
+// prioritized is a field value with an explicit priority.
+type prioritized struct {
+	val      any
+	priority int
+}
+
+// Priority constructs a Field with an explicit priority.
+//
+// When a log entry has more fields than allowed by SetMaxFields, the fields
+// with the highest priority are kept. Fields without an explicit priority
+// have priority PriorityMedium. Use a high priority for fields that must
+// survive truncation (such as ids) and a low priority for verbose,
+// nice-to-have fields.
+func Priority(key string, val any, priority int) Field {
+	return Field{key, prioritized{val: val, priority: priority}}
+}
+
+// fieldPriority returns the priority of the field value val.
+func fieldPriority(val any) int {
+	if p, ok := val.(prioritized); ok {
+		return p.priority
+	}
+	return PriorityMedium
+}
+
+// SetMaxFields configures the maximum number of fields of a log entry,
+// including the fields of the logging context. If a log entry has more
+// fields, the lowest-priority fields given to the logging call are dropped
+// and the number of dropped fields is recorded in a "fields_truncated" field.
+// Fields of the logging context are never dropped.
+//
+// A value of zero (the default) means there is no limit.
+func (l *Manager) SetMaxFields(n int) {
+	l.updateConfig(func(c *config) {
+		c.maxFields = n
+	})
+}
+
+// truncateFields drops the lowest-priority fields so that no more than
+// the configured maximum number of fields remain, given that numCtx fields
+// are already present from the logging context. It returns the remaining
+// fields, in their original order, and the number of fields dropped.
+// It never modifies fields.
+func (c *config) truncateFields(fields []any, numCtx int) (kept []any, truncated int) {
+	num := len(fields) / 2
+	budget := c.maxFields - numCtx
+	if c.maxFields <= 0 || num <= budget {
+		return fields, 0
+	} else if budget < 0 {
+		budget = 0
+	}
+
+	// Sort the field indices by priority, keeping the original order
+	// between fields of equal priority, and keep the first ones.
+	idx := make([]int, num)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return fieldPriority(fields[2*idx[a]+1]) > fieldPriority(fields[2*idx[b]+1])
+	})
+	keep := idx[:budget]
+	sort.Ints(keep)
+
+	kept = make([]any, 0, 2*budget)
+	for _, i := range keep {
+		kept = append(kept, fields[2*i], fields[2*i+1])
+	}
+	return kept, num - budget
+}
@@ -0,0 +1,95 @@
+package rlog
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+const (
+	// progressStep is the percentage of completion between
+	// progress log entries.
+	progressStep = 10
+
+	// progressInterval is the maximum time between progress log
+	// entries while progress is being reported.
+	progressInterval = 10 * time.Second
+)
+
+// progressValue is the value of the "progress" field.
+type progressValue struct {
+	Current int `json:"current"`
+	Total   int `json:"total"`
+	Percent int `json:"percent"`
+}
+
+// Progress starts logging the progress of a long-running operation
+// with the given total amount of work, such as a streaming handler.
+//
+// It returns a function to call with the amount of work completed so far.
+// Rather than logging every update, progress is logged whenever another
+// 10% of the work has been completed, at least every 10 seconds while
+// updates are being reported, and when the work is complete.
+// Each entry has a "progress" field with the current and total amount
+// of work and the percentage completed, and an "operation_id" field
+// that is the same for all entries of the operation.
+func (l *Manager) Progress(total int) func(current int) {
+	opID := newOperationID()
+	key := progressKey(opID)
+	var (
+		mu        sync.Mutex
+		lastStep  = -1
+		completed bool
+	)
+	return func(current int) {
+		percent := 100
+		if total > 0 {
+			percent = current * 100 / total
+		}
+
+		mu.Lock()
+		step := percent / progressStep
+		done := current >= total
+		log := !completed && l.repeats.allowProgress(key, step > lastStep || done, done, time.Now())
+		if log {
+			lastStep, completed = step, done
+		}
+		mu.Unlock()
+
+		if log {
+			fields := []any{
+				"progress", progressValue{Current: current, Total: total, Percent: percent},
+				"operation_id", opID,
+			}
+			l.doLog(LevelInfo, l.rt.Logger().Info(), "progress", nil, fields, logOpts{callerSkip: 1})
+		}
+	}
+}
+
+// progressKey returns the repeat limiter key of
+// the progress entries of the given operation.
+func progressKey(opID string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte("progress\x00" + opID))
+	return h.Sum64()
+}
+
+// allowProgress reports whether to write a progress entry of the operation
+// with the given key. The operation's token bucket holds a single token
+// refilled every progressInterval, so updates are written at most once per
+// interval, except that milestones are always written. Every entry written
+// takes the token. The bucket is removed once the operation is done.
+func (r *repeatLimiter) allowProgress(key uint64, milestone, done bool, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st := r.state(key, LevelInfo, "progress", 1, now)
+	ok := st.take(1, progressInterval, now)
+	if !ok && milestone {
+		// Milestones use up the token even when it has not been refilled yet.
+		st.tokens, ok = 0, true
+	}
+	if done {
+		delete(r.states, key)
+	}
+	return ok
+}
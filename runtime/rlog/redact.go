@@ -0,0 +1,105 @@
+package rlog
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// redactedValue replaces the values of sensitive fields.
+const redactedValue = "[redacted]"
+
+// keyMatcher matches keys (such as header or field names) case-insensitively
+// against a set of sensitive names.
+type keyMatcher map[string]bool
+
+func newKeyMatcher(names ...string) keyMatcher {
+	m := make(keyMatcher, len(names))
+	for _, name := range names {
+		m[strings.ToLower(name)] = true
+	}
+	return m
+}
+
+// match reports whether key is one of the sensitive names.
+func (m keyMatcher) match(key string) bool {
+	return m[strings.ToLower(key)]
+}
+
+// redactKey is the random key with which redact pseudonymizes values.
+// It is generated for each process, so that pseudonyms cannot be reversed
+// by hashing candidate values, such as every IP address or user id.
+var redactKey = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic("rlog: could not generate redaction key: " + err.Error())
+	}
+	return key
+}()
+
+// redact returns a pseudonymized representation of s.
+//
+// The result does not reveal the original value but is stable across calls
+// within the process, so that log entries concerning the same value (such as
+// a user id or an IP address) can still be correlated with each other.
+func redact(s string) string {
+	if s == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, redactKey)
+	mac.Write([]byte(s))
+	return "redacted:" + hex.EncodeToString(mac.Sum(nil)[:6])
+}
+
+// SetRedactedKeys configures the keys of fields whose values are redacted
+// from log entries, such as "email" or "ssn". Keys are matched
+// case-insensitively, and calling it again replaces the previous keys.
+//
+// Redaction applies to fields passed to the logging functions and to
+// fields added with With, and happens before the entry is written
+// to the log output, traces or sinks.
+func (l *Manager) SetRedactedKeys(keys ...string) {
+	l.updateConfig(func(c *config) {
+		c.redactedKeys = newKeyMatcher(keys...)
+	})
+}
+
+// AddRedactionPattern configures a pattern whose matches are redacted
+// from the string values of all fields, regardless of their key.
+// It is useful for values that can appear anywhere, such as credit card numbers:
+//
+//	mgr.AddRedactionPattern(regexp.MustCompile(`\b(?:\d[ -]?){12,15}\d\b`))
+//
+// Only string values are matched; the values of other types,
+// including structs and maps, are logged unchanged.
+func (l *Manager) AddRedactionPattern(re *regexp.Regexp) {
+	l.updateConfig(func(c *config) {
+		c.redactionPatterns = append(c.redactionPatterns[:len(c.redactionPatterns):len(c.redactionPatterns)], re)
+	})
+}
+
+// redactField returns the redacted representation of the field
+// with the given key and value. It reports false if the field
+// does not need redacting.
+func (c *config) redactField(key, val any) (any, bool) {
+	if len(c.redactedKeys) > 0 {
+		if k, ok := key.(string); ok && c.redactedKeys.match(k) {
+			return redactedValue, true
+		}
+	}
+	if len(c.redactionPatterns) > 0 {
+		if s, ok := val.(string); ok {
+			r := s
+			for _, re := range c.redactionPatterns {
+				r = re.ReplaceAllLiteralString(r, redactedValue)
+			}
+			if r != s {
+				return r, true
+			}
+		}
+	}
+	return nil, false
+}
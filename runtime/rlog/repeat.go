@@ -0,0 +1,217 @@
+package rlog
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRepeatKeys is the maximum number of distinct entries
+// tracked for rate limiting repeated entries.
+const maxRepeatKeys = 10000
+
+// SetRepeatLimit configures the Manager to write at most n identical
+// log entries per interval, where entries are identical if they have
+// the same level, message, field keys and primitive field values such as
+// strings and numbers. Other field values, such as errors and structs, are
+// not compared. A value of n less than or equal to zero disables the limit,
+// which is the default.
+//
+// Each distinct entry has a token bucket that holds up to n tokens and is
+// refilled at a rate of n per interval. Entries logged when the bucket is
+// empty are suppressed, and an interval after the first suppressed entry
+// a summary entry is written with the same level and message and a
+// "repeated" field holding the number of suppressed entries.
+// This keeps retry loops and similar hot paths from flooding the logs.
+// Critical entries are never suppressed.
+func (l *Manager) SetRepeatLimit(n int, interval time.Duration) {
+	l.updateConfig(func(c *config) {
+		c.repeatLimit = n
+		c.repeatInterval = interval
+	})
+}
+
+// SetDedupWindow configures the Manager to collapse identical log entries
+// written within the given window of each other, where entries are identical
+// as described for SetRepeatLimit. Only the first entry
+// is written, followed by a summary entry a window later with a "repeated"
+// field holding the number of collapsed entries. A window of zero disables
+// deduplication, which is the default.
+//
+// This is useful for health check spam and tight reconnect loops.
+// It is shorthand for SetRepeatLimit(1, window), and replaces any
+// limit configured with SetRepeatLimit.
+func (l *Manager) SetDedupWindow(window time.Duration) {
+	l.SetRepeatLimit(1, window)
+}
+
+// repeatLimiter tracks the token buckets of repeated log entries.
+type repeatLimiter struct {
+	mu     sync.Mutex
+	states map[uint64]*repeatState // keyed by repeatKey
+}
+
+// repeatState is the token bucket of a distinct log entry.
+type repeatState struct {
+	level      Level
+	msg        string
+	tokens     float64
+	last       time.Time // when tokens was last refilled
+	suppressed int       // entries suppressed since the last summary
+}
+
+// allowRepeat reports whether to write the entry with the given level,
+// message and fields, or suppress it as a repeat of an earlier entry.
+func (l *Manager) allowRepeat(cfg *config, level Level, msg string, ctxFields, logFields []any) bool {
+	if cfg.repeatLimit <= 0 || cfg.repeatInterval <= 0 {
+		return true
+	}
+	key := repeatKey(level, msg, ctxFields, logFields)
+	now := time.Now()
+
+	r := &l.repeats
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st := r.state(key, level, msg, cfg.repeatLimit, now)
+	if st.take(cfg.repeatLimit, cfg.repeatInterval, now) {
+		return true
+	}
+
+	st.suppressed++
+	if st.suppressed == 1 {
+		time.AfterFunc(cfg.repeatInterval, func() {
+			l.writeRepeatSummary(st, r.takeSuppressed(st))
+		})
+	}
+	return false
+}
+
+// state returns the token bucket for key, creating it with n tokens
+// for entries with the given level and message if there is none.
+// r.mu must be held.
+func (r *repeatLimiter) state(key uint64, level Level, msg string, n int, now time.Time) *repeatState {
+	st := r.states[key]
+	if st == nil {
+		if r.states == nil || len(r.states) >= maxRepeatKeys {
+			// Start over rather than tracking an unbounded number of entries.
+			// Summaries of entries already suppressed are still written.
+			r.states = make(map[uint64]*repeatState)
+		}
+		st = &repeatState{level: level, msg: msg, tokens: float64(n), last: now}
+		r.states[key] = st
+	}
+	return st
+}
+
+// take refills st at a rate of n tokens per interval, up to n tokens,
+// and takes a token if there is one, reporting whether it did.
+func (st *repeatState) take(n int, interval time.Duration, now time.Time) bool {
+	rate := float64(n) / float64(interval)
+	st.tokens += rate * float64(now.Sub(st.last))
+	if max := float64(n); st.tokens > max {
+		st.tokens = max
+	}
+	st.last = now
+	if st.tokens >= 1 {
+		st.tokens--
+		return true
+	}
+	return false
+}
+
+// repeatKey returns a hash identifying entries with the given level,
+// message and fields. Only field keys and primitive values are hashed,
+// as formatting other values is costly and may call user-defined
+// String and Error methods on every log call.
+func repeatKey(level Level, msg string, ctxFields, logFields []any) uint64 {
+	h := fnv.New64a()
+	var buf [64]byte
+	b := append(buf[:0], byte(level))
+	b = append(b, msg...)
+	h.Write(b)
+	for _, fields := range [...][]any{ctxFields, logFields} {
+		for _, f := range fields {
+			h.Write(appendPrimitive(buf[:0], f))
+		}
+	}
+	return h.Sum64()
+}
+
+// appendPrimitive appends a type tag and the encoding of v to b
+// if v is a primitive value, and just a placeholder tag otherwise.
+func appendPrimitive(b []byte, v any) []byte {
+	switch v := v.(type) {
+	case string:
+		b = append(b, 's')
+		b = strconv.AppendInt(b, int64(len(v)), 10)
+		return append(b, v...)
+	case bool:
+		return strconv.AppendBool(append(b, 'b'), v)
+	case int:
+		return strconv.AppendInt(append(b, 'i'), int64(v), 10)
+	case int8:
+		return strconv.AppendInt(append(b, 'i'), int64(v), 10)
+	case int16:
+		return strconv.AppendInt(append(b, 'i'), int64(v), 10)
+	case int32:
+		return strconv.AppendInt(append(b, 'i'), int64(v), 10)
+	case int64:
+		return strconv.AppendInt(append(b, 'i'), v, 10)
+	case uint:
+		return strconv.AppendUint(append(b, 'u'), uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(append(b, 'u'), uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(append(b, 'u'), uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(append(b, 'u'), uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(append(b, 'u'), v, 10)
+	case float32:
+		return strconv.AppendFloat(append(b, 'f'), float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(append(b, 'f'), v, 'g', -1, 64)
+	default:
+		return append(b, '?')
+	}
+}
+
+// takeSuppressed returns and resets the number of entries suppressed for st.
+func (r *repeatLimiter) takeSuppressed(st *repeatState) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := st.suppressed
+	st.suppressed = 0
+	return n
+}
+
+// writeRepeatSummary writes the summary entry for n suppressed repeats of st.
+func (l *Manager) writeRepeatSummary(st *repeatState, n int) {
+	if n == 0 {
+		return
+	}
+	logger := l.internalLogger()
+	l.doLog(st.level, event(logger, st.level), st.msg, nil, []any{"repeated", n}, logOpts{internal: true, unlimited: true})
+}
+
+// flushRepeats writes the summary entries of all suppressed repeats
+// without waiting for their interval to pass.
+func (l *Manager) flushRepeats() {
+	r := &l.repeats
+	r.mu.Lock()
+	var pending []*repeatState
+	var counts []int
+	for _, st := range r.states {
+		if st.suppressed > 0 {
+			pending = append(pending, st)
+			counts = append(counts, st.suppressed)
+			st.suppressed = 0
+		}
+	}
+	r.mu.Unlock()
+
+	for i, st := range pending {
+		l.writeRepeatSummary(st, counts[i])
+	}
+}
@@ -0,0 +1,40 @@
+package rlog
+
+import (
+	"encore.dev/appruntime/model"
+	"encore.dev/types/uuid"
+)
+
+// LogWithSpan logs a message at the given level, associating it in the
+// trace with the span identified by spanID rather than the current
+// request's span. The variadic key-value pairs are treated as they are in With.
+//
+// It is intended for tooling that imports or replays logs from other
+// systems into Encore traces, and bypasses the normal association of
+// log entries with the request that logged them. The span id is given
+// in the first 8 bytes of spanID, and the remaining bytes must be zero.
+// The message is recorded in the trace of the current request, if any,
+// along with the span id of that request, so that it is kept even if the
+// span is not part of the trace. If spanID is not a valid span id,
+// the message is still written to the log output but not recorded
+// in the trace.
+func (l *Manager) LogWithSpan(spanID uuid.UUID, level Level, msg string, kv ...any) {
+	opts := logOpts{skipTrace: true}
+	if id, ok := spanIDFromUUID(spanID); ok {
+		opts = logOpts{spanID: id, external: true}
+	}
+	l.doLog(level, event(l.rt.Logger(), level), msg, nil, l.checkPairs(kv), opts)
+}
+
+// spanIDFromUUID converts id to a span id, reporting false
+// if id does not represent a valid span id.
+func spanIDFromUUID(id uuid.UUID) (model.SpanID, bool) {
+	var span model.SpanID
+	copy(span[:], id[:len(span)])
+	for _, b := range id[len(span):] {
+		if b != 0 {
+			return model.SpanID{}, false
+		}
+	}
+	return span, !span.IsZero()
+}
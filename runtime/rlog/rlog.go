@@ -5,12 +5,18 @@
 package rlog
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
 
+	"encore.dev/appruntime/model"
 	"encore.dev/appruntime/reqtrack"
 	"encore.dev/appruntime/trace"
 	"encore.dev/beta/errs"
@@ -18,16 +24,33 @@ import (
 	"encore.dev/types/uuid"
 )
 
-type logLevel byte
+// Level is the severity level of a log message.
+type Level byte
 
 const (
-	levelTrace logLevel = 0 // unused; reserve for future use
-	levelDebug logLevel = 1
-	levelInfo  logLevel = 2
-	levelWarn  logLevel = 3
-	levelError logLevel = 4
+	LevelTrace Level = 0
+	LevelDebug Level = 1
+	LevelInfo  Level = 2
+	LevelWarn  Level = 3
+	LevelError Level = 4
 )
 
+// event returns a new zerolog event for the given level.
+func event(logger *zerolog.Logger, level Level) *zerolog.Event {
+	switch level {
+	case LevelTrace:
+		return logger.Trace()
+	case LevelDebug:
+		return logger.Debug()
+	case LevelWarn:
+		return logger.Warn()
+	case LevelError:
+		return logger.Error()
+	default:
+		return logger.Info()
+	}
+}
+
 // InternalKeyPrefix is the prefix of log field keys that are reserved for
 // internal use only. Log fields starting with this value have an additional "x_"
 // prefix prepended to avoid interference with reserved names.
@@ -38,11 +61,28 @@ const InternalKeyPrefix = "encore_"
 //publicapigen:drop
 type Manager struct {
 	rt *reqtrack.RequestTracker
+
+	cfgMu sync.Mutex // serializes config updates
+	cfg   atomic.Pointer[config]
+
+	stats selfStats
+	beat  heartbeat
+
+	// sampleCounts are the number of entries seen at each level,
+	// for sampling. They are accessed atomically.
+	sampleCounts [numLevels]uint64
+
+	repeats repeatLimiter
 }
 
 //publicapigen:drop
 func NewManager(rt *reqtrack.RequestTracker) *Manager {
-	return &Manager{rt}
+	l := &Manager{rt: rt}
+	l.cfg.Store(&config{
+		outputLevel:      LevelDebug,
+		sensitiveHeaders: newKeyMatcher(defaultSensitiveHeaders...),
+	})
+	return l
 }
 
 // Ctx holds additional logging context for use with the Infoc and family
@@ -51,46 +91,106 @@ type Ctx struct {
 	ctx    zerolog.Context
 	mgr    *Manager
 	fields []any
+	span   model.SpanID // span to associate entries with; zero means the request's span
+	name   string       // logger name set with Named, or "" if unnamed
+
+	// detached reports whether entries are logged independently
+	// of the current request, as they are for Job.
+	detached bool
+}
+
+// Trace logs a trace-level message, for very verbose diagnostics.
+//
+// Trace-level messages are not written to the log output by default,
+// but are recorded in the trace of the current request, if it is traced.
+func (l *Manager) Trace(msg string, keysAndValues ...any) {
+	fields := l.checkPairs(keysAndValues)
+	l.doLog(LevelTrace, l.rt.Logger().Trace(), msg, nil, fields, logOpts{})
 }
 
 func (l *Manager) Debug(msg string, keysAndValues ...any) {
-	fields := pairs(keysAndValues)
-	l.doLog(levelDebug, l.rt.Logger().Debug(), msg, nil, fields)
+	fields := l.checkPairs(keysAndValues)
+	l.doLog(LevelDebug, l.rt.Logger().Debug(), msg, nil, fields, logOpts{})
 }
 
 func (l *Manager) Info(msg string, keysAndValues ...any) {
-	fields := pairs(keysAndValues)
-	l.doLog(levelInfo, l.rt.Logger().Info(), msg, nil, fields)
+	fields := l.checkPairs(keysAndValues)
+	l.doLog(LevelInfo, l.rt.Logger().Info(), msg, nil, fields, logOpts{})
 }
 
 func (l *Manager) Warn(msg string, keysAndValues ...any) {
-	fields := pairs(keysAndValues)
-	l.doLog(levelWarn, l.rt.Logger().Warn(), msg, nil, fields)
+	fields := l.checkPairs(keysAndValues)
+	l.doLog(LevelWarn, l.rt.Logger().Warn(), msg, nil, fields, logOpts{})
 }
 
 func (l *Manager) Error(msg string, keysAndValues ...any) {
-	fields := pairs(keysAndValues)
-	l.doLog(levelError, l.rt.Logger().Error(), msg, nil, fields)
+	fields := l.checkPairs(keysAndValues)
+	l.doLog(LevelError, l.rt.Logger().Error(), msg, nil, fields, logOpts{})
 }
 
 func (l *Manager) With(keysAndValues ...any) Ctx {
 	ctx := l.rt.Logger().With()
-	fields := pairs(keysAndValues)
+	cfg := l.config()
+	fields := expandErrors(cfg.resolveFields(l.checkPairs(keysAndValues)))
 	for i := 0; i < len(fields); i += 2 {
 		key := fields[i].(string)
 		val := fields[i+1]
-		ctx = addContext(ctx, key, val)
+		ctx = addContext(ctx, key, cfg.eventValue(val), cfg.jsonEncoder)
 	}
 	return Ctx{ctx: ctx, mgr: l, fields: fields}
 }
 
+// DebugFields is like Debug but takes typed fields instead of key-value pairs.
+func (l *Manager) DebugFields(msg string, fields ...Field) {
+	l.doLog(LevelDebug, l.rt.Logger().Debug(), msg, nil, fieldPairs(fields), logOpts{})
+}
+
+// InfoFields is like Info but takes typed fields instead of key-value pairs.
+func (l *Manager) InfoFields(msg string, fields ...Field) {
+	l.doLog(LevelInfo, l.rt.Logger().Info(), msg, nil, fieldPairs(fields), logOpts{})
+}
+
+// WarnFields is like Warn but takes typed fields instead of key-value pairs.
+func (l *Manager) WarnFields(msg string, fields ...Field) {
+	l.doLog(LevelWarn, l.rt.Logger().Warn(), msg, nil, fieldPairs(fields), logOpts{})
+}
+
+// ErrorFields is like Error but takes typed fields instead of key-value pairs.
+func (l *Manager) ErrorFields(msg string, fields ...Field) {
+	l.doLog(LevelError, l.rt.Logger().Error(), msg, nil, fieldPairs(fields), logOpts{})
+}
+
+// WithFieldSet is like With but takes a precomputed set of typed fields.
+func (l *Manager) WithFieldSet(fields ...Field) Ctx {
+	return l.With(fieldPairs(fields)...)
+}
+
+// Err logs an error-level message about err, which is logged as the
+// "error" field. If err carries a stack trace, as errors created with
+// the errs package do, it is included in the log output as "error_stack".
+// The variadic key-value pairs are treated as they are in With.
+func (l *Manager) Err(err error, msg string, keysAndValues ...any) {
+	fields, opts := l.errFields(err, keysAndValues)
+	l.doLog(LevelError, l.rt.Logger().Error(), msg, nil, fields, opts)
+}
+
+// Trace logs a trace-level message, merging the context from ctx
+// with the additional context provided as key-value pairs.
+// The variadic key-value pairs are treated as they are in With.
+// See Manager.Trace for how trace-level messages are handled.
+func (ctx Ctx) Trace(msg string, keysAndValues ...any) {
+	l := ctx.ctx.Logger()
+	fields := ctx.mgr.checkPairs(keysAndValues)
+	ctx.mgr.doLog(LevelTrace, l.Trace(), msg, ctx.fields, fields, logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
+}
+
 // Debug logs a debug-level message, merging the context from ctx
 // with the additional context provided as key-value pairs.
 // The variadic key-value pairs are treated as they are in With.
 func (ctx Ctx) Debug(msg string, keysAndValues ...any) {
 	l := ctx.ctx.Logger()
-	fields := pairs(keysAndValues)
-	ctx.mgr.doLog(levelDebug, l.Debug(), msg, ctx.fields, fields)
+	fields := ctx.mgr.checkPairs(keysAndValues)
+	ctx.mgr.doLog(LevelDebug, l.Debug(), msg, ctx.fields, fields, logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
 }
 
 // Info logs an info-level message, merging the context from ctx
@@ -98,8 +198,8 @@ func (ctx Ctx) Debug(msg string, keysAndValues ...any) {
 // The variadic key-value pairs are treated as they are in With.
 func (ctx Ctx) Info(msg string, keysAndValues ...any) {
 	l := ctx.ctx.Logger()
-	fields := pairs(keysAndValues)
-	ctx.mgr.doLog(levelInfo, l.Info(), msg, ctx.fields, fields)
+	fields := ctx.mgr.checkPairs(keysAndValues)
+	ctx.mgr.doLog(LevelInfo, l.Info(), msg, ctx.fields, fields, logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
 }
 
 // Warn logs a warn-level message, merging the context from ctx
@@ -107,8 +207,8 @@ func (ctx Ctx) Info(msg string, keysAndValues ...any) {
 // The variadic key-value pairs are treated as they are in With.
 func (ctx Ctx) Warn(msg string, keysAndValues ...any) {
 	l := ctx.ctx.Logger()
-	fields := pairs(keysAndValues)
-	ctx.mgr.doLog(levelWarn, l.Warn(), msg, ctx.fields, fields)
+	fields := ctx.mgr.checkPairs(keysAndValues)
+	ctx.mgr.doLog(LevelWarn, l.Warn(), msg, ctx.fields, fields, logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
 }
 
 // Error logs an error-level message, merging the context from ctx
@@ -116,8 +216,8 @@ func (ctx Ctx) Warn(msg string, keysAndValues ...any) {
 // The variadic key-value pairs are treated as they are in With.
 func (ctx Ctx) Error(msg string, keysAndValues ...any) {
 	l := ctx.ctx.Logger()
-	fields := pairs(keysAndValues)
-	ctx.mgr.doLog(levelError, l.Error(), msg, ctx.fields, fields)
+	fields := ctx.mgr.checkPairs(keysAndValues)
+	ctx.mgr.doLog(LevelError, l.Error(), msg, ctx.fields, fields, logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
 }
 
 // With creates a new logging context that inherits the context
@@ -125,59 +225,365 @@ func (ctx Ctx) Error(msg string, keysAndValues ...any) {
 // The original ctx is not affected.
 func (ctx Ctx) With(keysAndValues ...any) Ctx {
 	c := ctx.ctx
-	fields := pairs(keysAndValues)
+	cfg := ctx.mgr.config()
+	fields := expandErrors(cfg.resolveFields(ctx.mgr.checkPairs(keysAndValues)))
 	for i := 0; i < len(fields); i += 2 {
 		key := fields[i].(string)
 		val := fields[i+1]
-		c = addContext(c, key, val)
+		c = addContext(c, key, cfg.eventValue(val), cfg.jsonEncoder)
 	}
 	fields = append(ctx.fields, fields...)
-	return Ctx{ctx: c, mgr: ctx.mgr, fields: fields}
+	return Ctx{ctx: c, mgr: ctx.mgr, fields: fields, span: ctx.span, name: ctx.name, detached: ctx.detached}
+}
+
+// DebugFields is like Debug but takes typed fields instead of key-value pairs.
+func (ctx Ctx) DebugFields(msg string, fields ...Field) {
+	l := ctx.ctx.Logger()
+	ctx.mgr.doLog(LevelDebug, l.Debug(), msg, ctx.fields, fieldPairs(fields), logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
+}
+
+// InfoFields is like Info but takes typed fields instead of key-value pairs.
+func (ctx Ctx) InfoFields(msg string, fields ...Field) {
+	l := ctx.ctx.Logger()
+	ctx.mgr.doLog(LevelInfo, l.Info(), msg, ctx.fields, fieldPairs(fields), logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
+}
+
+// WarnFields is like Warn but takes typed fields instead of key-value pairs.
+func (ctx Ctx) WarnFields(msg string, fields ...Field) {
+	l := ctx.ctx.Logger()
+	ctx.mgr.doLog(LevelWarn, l.Warn(), msg, ctx.fields, fieldPairs(fields), logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
+}
+
+// ErrorFields is like Error but takes typed fields instead of key-value pairs.
+func (ctx Ctx) ErrorFields(msg string, fields ...Field) {
+	l := ctx.ctx.Logger()
+	ctx.mgr.doLog(LevelError, l.Error(), msg, ctx.fields, fieldPairs(fields), logOpts{spanID: ctx.span, name: ctx.name, detached: ctx.detached})
+}
+
+// Err is like Manager.Err, but merges the context from ctx
+// with the additional context provided as key-value pairs.
+func (ctx Ctx) Err(err error, msg string, keysAndValues ...any) {
+	l := ctx.ctx.Logger()
+	fields, opts := ctx.mgr.errFields(err, keysAndValues)
+	opts.spanID = ctx.span
+	opts.name = ctx.name
+	opts.detached = ctx.detached
+	ctx.mgr.doLog(LevelError, l.Error(), msg, ctx.fields, fields, opts)
+}
+
+// errFields returns the fields and options for logging err with Err.
+func (l *Manager) errFields(err error, keysAndValues []any) ([]any, logOpts) {
+	if err == nil {
+		return l.checkPairs(keysAndValues), logOpts{}
+	}
+	fields := append([]any{"error", err}, l.checkPairs(keysAndValues)...)
+	var opts logOpts
+	var e *errs.Error
+	if errors.As(err, &e) {
+		opts.errStack = errs.Stack(e)
+	}
+	return fields, opts
+}
+
+// WithFieldSet is like With but takes a precomputed set of typed fields,
+// making them part of the persistent logging context.
+// The original ctx is not affected.
+func (ctx Ctx) WithFieldSet(fields ...Field) Ctx {
+	return ctx.With(fieldPairs(fields)...)
+}
+
+// Critical logs a message that must never be dropped,
+// such as an audit or security event.
+//
+// Critical messages are logged at error level with a "critical" marker.
+// They are never subject to sampling, rate limiting or buffering,
+// always include a stack trace, and are guaranteed to have been written
+// by the time Critical returns. This makes Critical considerably more
+// expensive than Error; use it sparingly.
+//
+// The variadic key-value pairs are treated as they are in With.
+func (l *Manager) Critical(msg string, keysAndValues ...any) {
+	fields := l.checkPairs(keysAndValues)
+	l.doLog(LevelError, l.rt.Logger().Error(), msg, nil, fields, logOpts{critical: true})
+}
+
+// Critical is like Manager.Critical, but merges the context from ctx
+// with the additional context provided as key-value pairs.
+func (ctx Ctx) Critical(msg string, keysAndValues ...any) {
+	l := ctx.ctx.Logger()
+	fields := ctx.mgr.checkPairs(keysAndValues)
+	ctx.mgr.doLog(LevelError, l.Error(), msg, ctx.fields, fields, logOpts{critical: true, spanID: ctx.span, name: ctx.name, detached: ctx.detached})
+}
+
+// logOpts are options that modify how a single log entry is emitted.
+type logOpts struct {
+	// critical marks the entry as critical, meaning it must never be
+	// dropped and always includes a stack trace. See Manager.Critical.
+	critical bool
+
+	// internal marks the entry as emitted by rlog itself,
+	// excluding it from the self-metrics statistics. Such entries
+	// are logged with internalLogger to exclude their output bytes.
+	internal bool
+
+	// stack includes the stack trace in the log output,
+	// as is always done for critical entries.
+	stack bool
+
+	// spanID is the span to associate the entry with in the trace.
+	// If zero, the entry is associated with the current request's span.
+	spanID model.SpanID
+
+	// external records the entry as an ExternalLogMessage event,
+	// as spanID need not be part of the trace.
+	external bool
+
+	// skipTrace excludes the entry from the trace.
+	skipTrace bool
+
+	// errStack is the stack trace of the error the entry is about,
+	// included in the log output as "error_stack".
+	errStack stack.Stack
+
+	// unlimited excludes the entry from sampling and repeat limiting,
+	// for entries that summarize other entries.
+	unlimited bool
+
+	// callerSkip is the number of stack frames to skip beyond doLog's
+	// caller when capturing the stack trace, for entries logged by
+	// rlog helpers on behalf of their caller.
+	callerSkip int
+
+	// name is the name of the logger the entry is logged with,
+	// logged as the "logger" field and used for per-name levels.
+	name string
+
+	// detached logs the entry independently of the current request,
+	// without recording it in the request's trace or using its level.
+	detached bool
+}
+
+// enabled reports whether entries at the given level are recorded,
+// either in the log output or in the trace of the current request.
+func (l *Manager) enabled(level Level) bool {
+	curr := l.rt.Current()
+	if level >= l.config().levelFor(curr.Req, "") {
+		return true
+	}
+	return curr.Req != nil && curr.Trace != nil
+}
+
+// logAdapted logs an entry on behalf of an adapter for another logging API,
+// such as SlogHandler. Since adapters are typically created once and used
+// across requests, the context fields are given as key-value pairs and
+// resolved against the logger of the current request at the time of logging.
+func (l *Manager) logAdapted(level Level, msg string, ctxFields, fields []any) {
+	ctx := l.With(ctxFields...)
+	logger := ctx.ctx.Logger()
+	l.doLog(level, event(&logger, level), msg, ctx.fields, fields, logOpts{callerSkip: 1})
 }
 
-func (l *Manager) doLog(level logLevel, ev *zerolog.Event, msg string, ctxFields, logFields []any) {
+func (l *Manager) doLog(level Level, ev *zerolog.Event, msg string, ctxFields, logFields []any, opts logOpts) {
 	var tb *trace.Buffer
 	curr := l.rt.Current()
-	numFields := len(ctxFields)/2 + len(logFields)/2
+	if opts.detached {
+		curr = reqtrack.Current{}
+	}
+	cfg := l.config()
+	measure := cfg.selfMetrics && !opts.internal
+
+	// Entries below the output level are only recorded in traces.
+	if level < cfg.levelFor(curr.Req, opts.name) {
+		if curr.Req == nil || curr.Trace == nil {
+			if measure {
+				l.stats.recordDiscarded(false)
+			}
+			return
+		}
+		ev = ev.Discard()
+	}
+
+	if len(cfg.hooks) > 0 {
+		var keep bool
+		if logFields, keep = cfg.runHooks(level, msg, logFields, opts.critical); !keep {
+			if measure {
+				l.stats.recordDiscarded(false)
+			}
+			return
+		}
+	}
+
+	// Sampled-out entries and suppressed repeats are discarded entirely,
+	// including from the trace.
+	keep, sampleRate := true, 0
+	if !opts.critical && !opts.unlimited {
+		keep, sampleRate = l.sample(cfg, level)
+		keep = keep && l.allowRepeat(cfg, level, msg, ctxFields, logFields)
+	}
+	if !keep {
+		if measure {
+			l.stats.recordDiscarded(true)
+		}
+		return
+	}
+
+	var start time.Time
+	if measure {
+		start = time.Now()
+	}
+	logFields, truncated := cfg.truncateFields(logFields, len(ctxFields)/2)
+	logFields = expandErrors(cfg.resolveFields(logFields))
+	mgrFields := cfg.managerFields(curr.Req)
+	if opts.name != "" {
+		mgrFields = append(mgrFields, "logger", opts.name)
+	}
+	if opts.critical {
+		mgrFields = append(mgrFields, "critical", true)
+	}
+	if truncated > 0 {
+		mgrFields = append(mgrFields, "fields_truncated", truncated)
+	}
+	if sampleRate > 0 {
+		mgrFields = append(mgrFields, "sample_rate", sampleRate)
+	}
+	numFields := len(ctxFields)/2 + len(logFields)/2 + len(mgrFields)/2
 
-	if curr.Req != nil && curr.Trace != nil {
-		t := trace.NewBuffer(16 + 8 + len(msg) + 4 + numFields*50)
-		tb = &t
-		tb.Bytes(curr.Req.SpanID[:])
+	if curr.Req != nil && curr.Trace != nil && !opts.skipTrace {
+		tb = trace.GetBuffer(16 + 8 + len(msg) + 4 + numFields*50)
+		spanID := curr.Req.SpanID
+		if opts.external {
+			tb.Bytes(spanID[:])
+		}
+		if !opts.spanID.IsZero() {
+			spanID = opts.spanID
+		}
+		tb.Bytes(spanID[:])
 		tb.UVarint(uint64(curr.Goctr))
 		tb.Byte(byte(level))
 		tb.String(msg)
 		tb.UVarint(uint64(numFields))
 	}
 
+	// Skip the log output if the request's logs go to the trace only.
+	if tb != nil && curr.Req.TraceOnlyLogs.Load() && !opts.critical && !(level >= LevelError && cfg.traceOnlyKeepErrors) {
+		ev = ev.Discard()
+	}
+
 	// Add context fields to the trace only, not to the zerolog event,
 	// as they're already part of the zerolog event.
 	if tb != nil {
 		for i := 0; i < len(ctxFields); i += 2 {
 			key := ctxFields[i].(string)
 			val := ctxFields[i+1]
-			addTraceBufEntry(tb, key, val)
+			addTraceBufEntry(tb, key, val, cfg.jsonEncoder)
 		}
 	}
 
 	for i := 0; i < len(logFields); i += 2 {
 		key := logFields[i].(string)
 		val := logFields[i+1]
-		addEventEntry(ev, key, val)
+		addEventEntry(ev, key, cfg.eventValue(val), cfg.jsonEncoder)
+		if tb != nil {
+			addTraceBufEntry(tb, key, val, cfg.jsonEncoder)
+		}
+	}
+
+	for i := 0; i < len(mgrFields); i += 2 {
+		key := mgrFields[i].(string)
+		val := mgrFields[i+1]
+		addEventEntry(ev, key, cfg.eventValue(val), cfg.jsonEncoder)
 		if tb != nil {
-			addTraceBufEntry(tb, key, val)
+			addTraceBufEntry(tb, key, val, cfg.jsonEncoder)
 		}
 	}
 
+	// Link warnings and errors to the span they were logged in,
+	// so that log-based dashboards can link to the exact span.
+	// The trace id is already part of the request's logger context.
+	if cfg.includeSpanID && level >= LevelWarn && tb != nil {
+		spanID := curr.Req.SpanID
+		if !opts.spanID.IsZero() {
+			spanID = opts.spanID
+		}
+		ev.Str("span_id", spanID.String())
+	}
+
+	// Skip the stack trace for entries about expected errors.
+	stackless := !opts.critical && len(cfg.stacklessErrors) > 0 &&
+		(hasStacklessError(logFields) || hasStacklessError(ctxFields))
+
+	includeCaller := cfg.includeCaller && ev != nil
+	var st stack.Stack
+	if includeCaller || (!stackless && (tb != nil || opts.critical || opts.stack)) {
+		st = stack.Build(3 + opts.callerSkip)
+	}
+	if includeCaller {
+		if caller := callerOf(st); caller != "" {
+			ev.Str("caller", caller)
+		}
+	}
+	if stackless {
+		// The stack was only built for the caller.
+		st = stack.Stack{}
+	}
+	if !stackless && (opts.critical || opts.stack) {
+		ev.Strs("stack", stackFrames(st))
+	}
+	if !stackless && len(opts.errStack.Frames) > 0 {
+		ev.Strs("error_stack", stackFrames(opts.errStack))
+	}
+
+	dropped := ev == nil
+	if !dropped && len(cfg.sinks) > 0 {
+		rec := Record{Time: time.Now(), Level: level, Message: msg}
+		if curr.Req != nil {
+			rec.TraceID, rec.SpanID = curr.Req.TraceID, curr.Req.SpanID
+			if !opts.spanID.IsZero() {
+				rec.SpanID = opts.spanID
+			}
+		}
+		cfg.emitToSinks(rec, ctxFields, logFields, mgrFields)
+	}
+	if !dropped && !opts.internal {
+		cfg.countLog(level)
+	}
 	ev.Msg(msg)
 
-	if curr.Trace != nil {
-		tb.Stack(stack.Build(3))
-		curr.Trace.Add(trace.LogMessage, tb.Buf())
+	traceBytes := 0
+	if tb != nil {
+		tb.Stack(st)
+		traceBytes = len(tb.Buf())
+		if opts.external {
+			curr.Trace.Add(trace.ExternalLogMessage, tb.Buf())
+		} else {
+			curr.Trace.Add(trace.LogMessage, tb.Buf())
+		}
+		trace.PutBuffer(tb)
+	}
+
+	if !start.IsZero() {
+		l.stats.record(dropped, traceBytes, time.Since(start))
 	}
 }
 
-func addEventEntry(ev *zerolog.Event, key string, val any) {
+// stackFrames renders s as a list of "function (file:line)" strings.
+func stackFrames(s stack.Stack) []string {
+	if len(s.Frames) == 0 {
+		return nil
+	}
+	frames := make([]string, 0, len(s.Frames))
+	cf := runtime.CallersFrames(s.Frames)
+	for {
+		f, more := cf.Next()
+		frames = append(frames, f.Function+" ("+f.File+":"+strconv.Itoa(f.Line)+")")
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+func addEventEntry(ev *zerolog.Event, key string, val any, enc jsonEncoder) {
 	if reserved(key) {
 		key = "x_" + key
 	}
@@ -196,9 +602,21 @@ func addEventEntry(ev *zerolog.Event, key string, val any) {
 		ev.Dur(key, val)
 	case uuid.UUID:
 		ev.Str(key, val.String())
+	case []string:
+		ev.Strs(key, val)
+	case map[string]string:
+		ev.Dict(key, stringMapDict(val))
+	case objectJSON:
+		ev.RawJSON(key, val)
 
 	default:
-		ev.Interface(key, val)
+		if enc == nil {
+			ev.Interface(key, val)
+		} else if data, err := enc(val); err != nil {
+			ev.Str(key, marshalErrorString(err))
+		} else {
+			ev.RawJSON(key, data)
+		}
 
 	case int8:
 		ev.Int8(key, val)
@@ -226,10 +644,15 @@ func addEventEntry(ev *zerolog.Event, key string, val any) {
 		ev.Float32(key, val)
 	case float64:
 		ev.Float64(key, val)
+
+	// This must come after all concrete types,
+	// as some of them implement fmt.Stringer.
+	case fmt.Stringer:
+		ev.Str(key, stringerValue(val))
 	}
 }
 
-func addContext(ctx zerolog.Context, key string, val any) zerolog.Context {
+func addContext(ctx zerolog.Context, key string, val any, enc jsonEncoder) zerolog.Context {
 	if reserved(key) {
 		key = "x_" + key
 	}
@@ -248,9 +671,21 @@ func addContext(ctx zerolog.Context, key string, val any) zerolog.Context {
 		return ctx.Dur(key, val)
 	case uuid.UUID:
 		return ctx.Str(key, val.String())
+	case []string:
+		return ctx.Strs(key, val)
+	case map[string]string:
+		return ctx.Dict(key, stringMapDict(val))
+	case objectJSON:
+		return ctx.RawJSON(key, val)
 
 	default:
-		return ctx.Interface(key, val)
+		if enc == nil {
+			return ctx.Interface(key, val)
+		} else if data, err := enc(val); err != nil {
+			return ctx.Str(key, marshalErrorString(err))
+		} else {
+			return ctx.RawJSON(key, data)
+		}
 
 	case int8:
 		return ctx.Int8(key, val)
@@ -278,6 +713,11 @@ func addContext(ctx zerolog.Context, key string, val any) zerolog.Context {
 		return ctx.Float32(key, val)
 	case float64:
 		return ctx.Float64(key, val)
+
+	// This must come after all concrete types,
+	// as some of them implement fmt.Stringer.
+	case fmt.Stringer:
+		return ctx.Str(key, stringerValue(val))
 	}
 }
 
@@ -299,8 +739,13 @@ const (
 	float64Type byte = 11
 )
 
-func addTraceBufEntry(tb *trace.Buffer, key string, val any) {
+func addTraceBufEntry(tb *trace.Buffer, key string, val any, enc jsonEncoder) {
 	switch val := val.(type) {
+	case stacklessError:
+		tb.Byte(errType)
+		tb.String(key)
+		tb.Err(val.error)
+		tb.Stack(stack.Stack{})
 	case error:
 		tb.Byte(errType)
 		tb.String(key)
@@ -326,11 +771,26 @@ func addTraceBufEntry(tb *trace.Buffer, key string, val any) {
 		tb.Byte(uuidType)
 		tb.String(key)
 		tb.Bytes(val[:])
+	case []string:
+		tb.Byte(jsonType)
+		tb.String(key)
+		tb.ByteString(appendJSONStrings(nil, val))
+		tb.Err(nil)
+	case map[string]string:
+		tb.Byte(jsonType)
+		tb.String(key)
+		tb.ByteString(appendJSONStringMap(nil, val))
+		tb.Err(nil)
+	case objectJSON:
+		tb.Byte(jsonType)
+		tb.String(key)
+		tb.ByteString(val)
+		tb.Err(nil)
 
 	default:
 		tb.Byte(jsonType)
 		tb.String(key)
-		data, err := json.Marshal(val)
+		data, err := enc.marshal(val)
 		if err != nil {
 			tb.ByteString(nil)
 			tb.Err(err)
@@ -355,6 +815,10 @@ func addTraceBufEntry(tb *trace.Buffer, key string, val any) {
 		tb.Byte(intType)
 		tb.String(key)
 		tb.Varint(int64(val))
+	case byteCount:
+		tb.Byte(intType)
+		tb.String(key)
+		tb.Varint(int64(val))
 	case int:
 		tb.Byte(intType)
 		tb.String(key)
@@ -389,11 +853,19 @@ func addTraceBufEntry(tb *trace.Buffer, key string, val any) {
 		tb.Byte(float64Type)
 		tb.String(key)
 		tb.Float64(val)
+
+	// This must come after all concrete types,
+	// as some of them implement fmt.Stringer.
+	case fmt.Stringer:
+		tb.Byte(strType)
+		tb.String(key)
+		tb.String(stringerValue(val))
 	}
 }
 
-// pairs ensures the key-values are in pairs.
-// It drops the last entry if there's an odd number of entries.
+// pairs ensures the key-values are in pairs with string keys.
+// It drops the last entry if there's an odd number of entries,
+// and converts keys that are not strings using fmt.Sprint.
 func pairs(keysAndValues []any) []any {
 	fields := keysAndValues
 	num := len(fields)
@@ -402,5 +874,17 @@ func pairs(keysAndValues []any) []any {
 		num--
 		fields = fields[:num]
 	}
+	for i := 0; i < num; i += 2 {
+		if _, ok := fields[i].(string); !ok {
+			// Copy the fields rather than modifying the caller's slice.
+			fields = append([]any(nil), fields...)
+			for j := i; j < num; j += 2 {
+				if _, ok := fields[j].(string); !ok {
+					fields[j] = fmt.Sprint(fields[j])
+				}
+			}
+			break
+		}
+	}
 	return fields
 }
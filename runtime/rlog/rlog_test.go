@@ -2,9 +2,40 @@ package rlog
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/reqtrack"
+	"encore.dev/appruntime/trace"
+	"encore.dev/beta/errs"
+	"encore.dev/internal/stack"
+	"encore.dev/metrics"
+	"encore.dev/types/uuid"
 )
 
 func TestReserveEncoreKey(t *testing.T) {
@@ -36,7 +67,7 @@ func TestReserveEncoreKey(t *testing.T) {
 			var buf bytes.Buffer
 			logger := zerolog.New(&buf)
 			ev := logger.Info()
-			addEventEntry(ev, testCase.Key, "value")
+			addEventEntry(ev, testCase.Key, "value", nil)
 			ev.Send()
 			actual := buf.String()
 			if actual != testCase.Want {
@@ -48,7 +79,7 @@ func TestReserveEncoreKey(t *testing.T) {
 
 			var buf bytes.Buffer
 			logger := zerolog.New(&buf)
-			logger = addContext(logger.With(), testCase.Key, "value").Logger()
+			logger = addContext(logger.With(), testCase.Key, "value", nil).Logger()
 			logger.Info().Send()
 			actual := buf.String()
 			if actual != testCase.Want {
@@ -57,3 +88,1998 @@ func TestReserveEncoreKey(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldsMatchVarargs(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+
+	mgr.Info("msg", "str", "val", "int", 5, "bool", true)
+	base := []Field{String("str", "val"), Int("int", 5)}
+	mgr.InfoFields("msg", append(base, Bool("bool", true))...)
+	mgr.With("str", "val").WithFieldSet(Int("int", 5)).InfoFields("msg", Bool("bool", true))
+	mgr.WithFieldSet(base...).InfoFields("msg", Bool("bool", true))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 4 {
+		t.Fatalf("got %d log lines, want 4", len(lines))
+	}
+	for _, line := range lines[1:] {
+		if !bytes.Equal(line, lines[0]) {
+			t.Errorf("log line mismatch:\nwant:\t%s\ngot:\t%s", lines[0], line)
+		}
+	}
+
+	msgs := traceLog()
+	if len(msgs) != 4 {
+		t.Fatalf("got %d trace messages, want 4", len(msgs))
+	}
+	for _, msg := range msgs[1:] {
+		if diff := cmp.Diff(msgs[0], msg); diff != "" {
+			t.Errorf("trace message mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+// newTestManager creates a Manager with an active, traced request.
+// It returns the manager, the buffer the log output is written to,
+// and a function that decodes the log messages written to the trace.
+func newTestManager(t *testing.T) (mgr *Manager, buf *bytes.Buffer, traceLog func() []traceMsg) {
+	t.Helper()
+	buf = &bytes.Buffer{}
+	rt := reqtrack.New(zerolog.New(buf), nil, trace.DefaultFactory)
+	rt.BeginRequest(&model.Request{Traced: true, SpanID: model.SpanID{1, 2, 3}})
+	t.Cleanup(rt.FinishRequest)
+
+	tr := rt.Current().Trace
+	return NewManager(rt), buf, func() []traceMsg {
+		return decodeTraceLog(t, tr.GetAndClear())
+	}
+}
+
+type traceMsg struct {
+	ReqSpanID model.SpanID // for ExternalLogMessage events
+	SpanID    model.SpanID
+	Level     Level
+	Msg       string
+	Fields    []traceField
+}
+
+type traceField struct {
+	Type  byte
+	Key   string
+	Value any
+}
+
+// decodeTraceLog decodes the LogMessage and ExternalLogMessage events
+// in the trace data, ignoring all other events and any stack traces.
+func decodeTraceLog(t *testing.T, data []byte) []traceMsg {
+	t.Helper()
+	var msgs []traceMsg
+	for len(data) > 0 {
+		typ := trace.EventType(data[0])
+		n := binary.LittleEndian.Uint32(data[9:13])
+		ev := data[13 : 13+n]
+		data = data[13+n:]
+		switch typ {
+		case trace.LogMessage:
+			msgs = append(msgs, (&traceReader{t: t, buf: ev}).logMessage())
+		case trace.ExternalLogMessage:
+			r := &traceReader{t: t, buf: ev}
+			var reqSpanID model.SpanID
+			copy(reqSpanID[:], r.bytes(len(reqSpanID)))
+			m := r.logMessage()
+			m.ReqSpanID = reqSpanID
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs
+}
+
+// traceLogStacks decodes the function names of the stack trace
+// of each LogMessage event in the trace data.
+func traceLogStacks(t *testing.T, data []byte) [][]string {
+	t.Helper()
+	off := stack.Build(0).Off
+	var stacks [][]string
+	for len(data) > 0 {
+		typ := trace.EventType(data[0])
+		n := binary.LittleEndian.Uint32(data[9:13])
+		ev := data[13 : 13+n]
+		data = data[13+n:]
+		if typ != trace.LogMessage {
+			continue
+		}
+		r := &traceReader{t: t, buf: ev}
+		r.logMessage()
+		var funcs []string
+		var pc int64
+		for i, n := 0, int(r.byte()); i < n; i++ {
+			diff, k := binary.Varint(r.buf)
+			r.buf = r.buf[k:]
+			pc += diff
+			if fn := runtime.FuncForPC(uintptr(pc) + off - 1); fn != nil {
+				funcs = append(funcs, fn.Name())
+			}
+		}
+		stacks = append(stacks, funcs)
+	}
+	return stacks
+}
+
+type traceReader struct {
+	t      *testing.T
+	buf    []byte
+	stacks []int // number of frames of each stack read
+}
+
+func (r *traceReader) logMessage() traceMsg {
+	var m traceMsg
+	copy(m.SpanID[:], r.bytes(len(m.SpanID)))
+	r.uvarint() // goctr
+	m.Level = Level(r.byte())
+	m.Msg = r.string()
+	num := int(r.uvarint())
+	for i := 0; i < num; i++ {
+		m.Fields = append(m.Fields, r.field())
+	}
+	return m
+}
+
+func (r *traceReader) field() traceField {
+	f := traceField{Type: r.byte(), Key: r.string()}
+	switch f.Type {
+	case errType:
+		f.Value = r.string()
+		r.stack()
+	case strType:
+		f.Value = r.string()
+	case boolType:
+		f.Value = r.byte() != 0
+	case timeType:
+		f.Value = [2]uint64{r.uint64(), uint64(binary.LittleEndian.Uint32(r.bytes(4)))}
+	case durType:
+		f.Value = r.uint64()
+	case uuidType:
+		f.Value = string(r.bytes(16))
+	case jsonType:
+		f.Value = string(r.bytes(int(r.uvarint())))
+		if errMsg := r.string(); errMsg != "" {
+			f.Value = errMsg
+		}
+	case intType:
+		u := r.uvarint()
+		x := int64(u >> 1)
+		if u&1 != 0 {
+			x = ^x
+		}
+		f.Value = x
+	case uintType:
+		f.Value = r.uvarint()
+	case float32Type:
+		f.Value = math.Float32frombits(binary.LittleEndian.Uint32(r.bytes(4)))
+	case float64Type:
+		f.Value = math.Float64frombits(r.uint64())
+	default:
+		r.t.Fatalf("unknown field type %d", f.Type)
+	}
+	return f
+}
+
+func (r *traceReader) stack() {
+	n := int(r.byte())
+	for i := 0; i < n; i++ {
+		r.uvarint()
+	}
+	r.stacks = append(r.stacks, n)
+}
+
+func (r *traceReader) bytes(n int) []byte {
+	if len(r.buf) < n {
+		r.t.Fatalf("trace data too short: want %d bytes, have %d", n, len(r.buf))
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *traceReader) byte() byte     { return r.bytes(1)[0] }
+func (r *traceReader) uint64() uint64 { return binary.LittleEndian.Uint64(r.bytes(8)) }
+func (r *traceReader) string() string { return string(r.bytes(int(r.uvarint()))) }
+func (r *traceReader) uvarint() uint64 {
+	x, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.t.Fatalf("invalid uvarint")
+	}
+	r.buf = r.buf[n:]
+	return x
+}
+
+func TestReleaseID(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetReleaseID("deploy-123")
+	mgr.Info("msg")
+
+	if got, want := buf.String(), `{"level":"info","release":"deploy-123","message":"msg"}`+"\n"; got != want {
+		t.Errorf("got log line %q, want %q", got, want)
+	}
+	msgs := traceLog()
+	if len(msgs) != 1 {
+		t.Fatalf("got %d trace messages, want 1", len(msgs))
+	}
+	want := []traceField{{Type: strType, Key: "release", Value: "deploy-123"}}
+	if diff := cmp.Diff(want, msgs[0].Fields); diff != "" {
+		t.Errorf("trace fields mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTraceParent(t *testing.T) {
+	req := &model.Request{
+		TraceID: model.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  model.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Traced:  true,
+	}
+	got, ok := traceParent(req)
+	if want := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"; !ok || got != want {
+		t.Errorf("got %q, %v, want %q, true", got, ok, want)
+	}
+
+	req.Traced = false
+	got, ok = traceParent(req)
+	if want := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"; !ok || got != want {
+		t.Errorf("got %q, %v, want %q, true", got, ok, want)
+	}
+
+	req.TraceID = model.TraceID{}
+	if got, ok := traceParent(req); ok {
+		t.Errorf("got %q, true for zero trace id, want false", got)
+	}
+	if got, ok := traceParent(nil); ok {
+		t.Errorf("got %q, true for nil request, want false", got)
+	}
+}
+
+func TestBaggageFields(t *testing.T) {
+	long := strings.Repeat("x", maxBaggageValueLen+1)
+	req := &model.Request{Baggage: []model.BaggageMember{
+		{Key: "tenant_id", Value: "t1"},
+		{Key: "bucket", Value: "b"},
+		{Key: "service", Value: "spoofed"},
+		{Key: "region", Value: "spoofed"},
+		{Key: "note", Value: long},
+	}}
+	allowed := newKeyMatcher("Tenant_ID", "service", "region", "note")
+
+	// Only allowed members are attached, never replacing
+	// runtime fields or fields already attached.
+	got := baggageFields(req, allowed, []any{"region", "eu"})
+	if want := []any{"tenant_id", "t1", "note", long[:maxBaggageValueLen]}; !cmp.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := baggageFields(req, nil, nil); got != nil {
+		t.Errorf("got %v without allowed keys, want nil", got)
+	}
+	if got := baggageFields(nil, allowed, nil); got != nil {
+		t.Errorf("got %v for nil request, want nil", got)
+	}
+
+	// The number of members attached is bounded.
+	req.Baggage = nil
+	for i := 0; i < 2*maxBaggageFields; i++ {
+		req.Baggage = append(req.Baggage, model.BaggageMember{Key: "k", Value: strconv.Itoa(i)})
+		req.Baggage = append(req.Baggage, model.BaggageMember{Key: "k" + strconv.Itoa(i), Value: "v"})
+	}
+	keys := []string{"k"}
+	for i := 0; i < 2*maxBaggageFields; i++ {
+		keys = append(keys, "k"+strconv.Itoa(i))
+	}
+	if got := baggageFields(req, newKeyMatcher(keys...), nil); len(got) != 2*maxBaggageFields {
+		t.Errorf("got %d fields, want %d", len(got)/2, maxBaggageFields)
+	}
+}
+
+func TestIncludeSpanID(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetIncludeSpanID(true)
+	mgr.Info("info")
+	mgr.Warn("warn")
+
+	spanID := model.SpanID{1, 2, 3}.String()
+	want := `{"level":"info","message":"info"}` + "\n" +
+		`{"level":"warn","span_id":"` + spanID + `","message":"warn"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log lines %q, want %q", got, want)
+	}
+}
+
+func TestCollector(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	c := mgr.Collector()
+	c.Add("a", true, 1500*time.Microsecond)
+	c.Add("b", false, 2*time.Millisecond)
+	c.Log(LevelWarn, "fan-out done")
+
+	want := `{"level":"warn","children_total":2,"children_failed":1,"children":[{"name":"a","ok":true,"duration":1.5},{"name":"b","ok":false,"duration":2}],"message":"fan-out done"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log line %q, want %q", got, want)
+	}
+
+	msgs := traceLog()
+	if len(msgs) != 1 {
+		t.Fatalf("got %d trace messages, want 1", len(msgs))
+	}
+	children := msgs[0].Fields[2]
+	if children.Type != jsonType || children.Value != `[{"name":"a","ok":true,"duration":1.5},{"name":"b","ok":false,"duration":2}]` {
+		t.Errorf("got children field %+v", children)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		N     int64
+		Units ByteUnits
+		Want  string
+	}{
+		{0, SIUnits, "0 B"},
+		{999, SIUnits, "999 B"},
+		{1500, SIUnits, "1.5 kB"},
+		{1_500_000, SIUnits, "1.5 MB"},
+		{-2_000_000_000, SIUnits, "-2.0 GB"},
+		{1023, BinaryUnits, "1023 B"},
+		{1536, BinaryUnits, "1.5 KiB"},
+		{3 << 20, BinaryUnits, "3.0 MiB"},
+	}
+	for _, test := range tests {
+		if got := formatBytes(test.N, test.Units); got != test.Want {
+			t.Errorf("formatBytes(%d, %v) = %q, want %q", test.N, test.Units, got, test.Want)
+		}
+	}
+}
+
+func TestContextEventValues(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetConsoleOutput(true)
+	mgr.WithFieldSet(Bytes("size", 1500), Money("total", 1234, "USD")).Info("msg")
+
+	got := buf.String()
+	if !strings.Contains(got, `"size":"1.5 kB"`) {
+		t.Errorf("got log line %q, want it to render the byte count", got)
+	}
+	if !strings.Contains(got, `"display":"12.34 USD"`) {
+		t.Errorf("got log line %q, want it to include the display amount", got)
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetSensitiveHeaders("X-Api-Key")
+	h := http.Header{
+		"Authorization": {"Bearer secret"},
+		"X-Api-Key":     {"secret"},
+		"Accept":        {"text/html", "application/json"},
+		"Content-Type":  {"application/json"},
+	}
+	mgr.InfoFields("request", Headers(h))
+
+	want := `{"level":"info","headers":{"Accept":["text/html","application/json"],"Authorization":"[redacted]","Content-Type":"application/json","X-Api-Key":"[redacted]"},"message":"request"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log line %q, want %q", got, want)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.InfoFields("response", HTTPStatus(http.StatusNotFound))
+	mgr.InfoFields("response", HTTPStatus(599))
+
+	want := `{"level":"info","http_status":{"code":404,"text":"Not Found"},"message":"response"}` + "\n" +
+		`{"level":"info","http_status":{"code":599,"text":"unknown"},"message":"response"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	if f := traceLog()[0].Fields[0]; f.Type != jsonType || f.Value != `{"code":404,"text":"Not Found"}` {
+		t.Errorf("got trace field %+v", f)
+	}
+}
+
+func TestSnapshotConfig(t *testing.T) {
+	mgr, _, _ := newTestManager(t)
+	mgr.SetReleaseID("v1")
+	snap := mgr.SnapshotConfig()
+
+	mgr.SetReleaseID("v2")
+	mgr.SetSensitiveHeaders("X-Secret")
+	mgr.RestoreConfig(snap)
+
+	cfg := mgr.config()
+	if cfg.releaseID != "v1" {
+		t.Errorf("got release id %q, want %q", cfg.releaseID, "v1")
+	}
+	if cfg.sensitiveHeaders.match("X-Secret") {
+		t.Errorf("sensitive headers not restored")
+	}
+}
+
+func TestSnapshotConfig_DeepCopy(t *testing.T) {
+	mgr, _, _ := newTestManager(t)
+	errA, errB := errors.New("a"), errors.New("b")
+	mgr.SetServiceLevel("svc", LevelInfo)
+	mgr.SetNamedLevel("db", LevelWarn)
+	mgr.SetLogBaggageKeys("tenant")
+	mgr.SetSensitiveHeaders("X-Secret")
+	mgr.SetRedactedKeys("password")
+	mgr.AddRedactionPattern(regexp.MustCompile("secret"))
+	mgr.SetIncludePprofLabels(true, "endpoint")
+	mgr.SetGlobalFields("region", "eu")
+	mgr.SetEnvFields("prod", "tier", "gold")
+	mgr.SetStacklessErrors(errA)
+	mgr.AddSink(sinkFunc(func(Record) {}))
+	mgr.AddHook(func(Level, string, []any) (bool, []any) { return false, nil })
+	snap := mgr.SnapshotConfig()
+
+	// Modify every slice and map of the live config in place.
+	mgr.updateConfig(func(c *config) {
+		c.serviceLevels["svc"] = LevelError
+		c.namedLevels["db"] = LevelError
+		c.baggageKeys["other"] = true
+		c.sensitiveHeaders["x-other"] = true
+		c.redactedKeys["token"] = true
+		c.redactionPatterns[0] = regexp.MustCompile("other")
+		c.pprofLabelKeys["other"] = true
+		c.globalFields[1] = "us"
+		c.envFields["prod"][1] = "silver"
+		c.stacklessErrors[0] = errB
+		c.sinks[0] = nil
+		c.hooks[0] = nil
+	})
+
+	check := func(name string, c *config) {
+		t.Helper()
+		switch {
+		case c.serviceLevels["svc"] != LevelInfo:
+			t.Errorf("%s: service levels modified", name)
+		case c.namedLevels["db"] != LevelWarn:
+			t.Errorf("%s: named levels modified", name)
+		case c.baggageKeys["other"]:
+			t.Errorf("%s: baggage keys modified", name)
+		case c.sensitiveHeaders["x-other"]:
+			t.Errorf("%s: sensitive headers modified", name)
+		case c.redactedKeys["token"]:
+			t.Errorf("%s: redacted keys modified", name)
+		case c.redactionPatterns[0].String() != "secret":
+			t.Errorf("%s: redaction patterns modified", name)
+		case c.pprofLabelKeys["other"]:
+			t.Errorf("%s: pprof label keys modified", name)
+		case c.globalFields[1] != "eu":
+			t.Errorf("%s: global fields modified", name)
+		case c.envFields["prod"][1] != "gold":
+			t.Errorf("%s: environment fields modified", name)
+		case c.stacklessErrors[0] != errA:
+			t.Errorf("%s: stackless errors modified", name)
+		case c.sinks[0] == nil:
+			t.Errorf("%s: sinks modified", name)
+		case c.hooks[0] == nil:
+			t.Errorf("%s: hooks modified", name)
+		}
+	}
+	check("snapshot", snap.cfg)
+	mgr.RestoreConfig(snap)
+	check("restored", mgr.config())
+}
+
+func TestPprofLabels(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetIncludePprofLabels(true, "endpoint")
+	pprof.Do(context.Background(), pprof.Labels("endpoint", "users.Get", "other", "x"), func(ctx context.Context) {
+		mgr.FromContext(ctx).Info("msg")
+		mgr.Info("no context")
+	})
+	mgr.FromContext(context.Background()).Info("no labels")
+
+	want := `{"level":"info","pprof_endpoint":"users.Get","message":"msg"}` + "\n" +
+		`{"level":"info","message":"no context"}` + "\n" +
+		`{"level":"info","message":"no labels"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log lines %q, want %q", got, want)
+	}
+}
+
+func TestGlobalFields(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetGlobalFields("region", "eu-west1", "pod", "web-1")
+	mgr.With("a", 1).Info("global")
+	mgr.SetGlobalFields()
+	mgr.Info("none")
+
+	want := `{"level":"info","a":1,"region":"eu-west1","pod":"web-1","message":"global"}` + "\n" +
+		`{"level":"info","message":"none"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	if msgs := traceLog(); len(msgs[0].Fields) != 3 {
+		t.Errorf("got trace fields %+v, want 3 fields", msgs[0].Fields)
+	}
+}
+
+func TestRequestDebug(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetLevel(LevelWarn)
+	mgr.Debug("hidden")
+	mgr.SetRequestDebug(true)
+	mgr.Trace("hidden")
+	mgr.Debug("debug")
+
+	if got, want := buf.String(), `{"level":"debug","message":"debug"}`+"\n"; got != want {
+		t.Errorf("got log lines %q, want %q", got, want)
+	}
+}
+
+func TestRequestTraceOnly(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetRequestTraceOnly(true)
+	mgr.SetTraceOnlyKeepErrors(true)
+	mgr.Info("info")
+	mgr.Error("error")
+
+	if got, want := buf.String(), `{"level":"error","message":"error"}`+"\n"; got != want {
+		t.Errorf("got log lines %q, want %q", got, want)
+	}
+	if msgs := traceLog(); len(msgs) != 2 {
+		t.Errorf("got %d trace messages, want 2", len(msgs))
+	}
+}
+
+func TestMaxFields(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetMaxFields(3)
+	mgr.With("ctx", 1).InfoFields("msg",
+		Priority("debug", "verbose", PriorityLow),
+		String("a", "x"),
+		Priority("id", 42, PriorityHigh),
+		String("b", "y"),
+	)
+
+	want := `{"level":"info","ctx":1,"a":"x","id":42,"fields_truncated":2,"message":"msg"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log line %q, want %q", got, want)
+	}
+}
+
+func TestWorkflowExtractor(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.Info("none")
+
+	inWorkflow := true
+	mgr.SetWorkflowExtractor(func() (string, string, bool) {
+		return "wf-1", "run-2", inWorkflow
+	})
+	mgr.Info("in workflow")
+	inWorkflow = false
+	mgr.Info("outside workflow")
+
+	want := `{"level":"info","message":"none"}` + "\n" +
+		`{"level":"info","workflow_id":"wf-1","run_id":"run-2","message":"in workflow"}` + "\n" +
+		`{"level":"info","message":"outside workflow"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log lines %q, want %q", got, want)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	tr := mgr.rt.Current().Trace
+	val, err := Observe(mgr, "compute", func() (int, error) { return 42, nil })
+	if val != 42 || err != nil {
+		t.Fatalf("got %v, %v, want 42, nil", val, err)
+	}
+	// The trace stack must start at the caller of Observe.
+	if stacks := traceLogStacks(t, tr.GetAndClear()); len(stacks) != 1 || len(stacks[0]) == 0 ||
+		!strings.HasPrefix(stacks[0][0], "encore.dev/rlog.TestObserve") {
+		t.Errorf("got trace stacks %v, want them to start at TestObserve", stacks)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["level"] != "info" || entry["op"] != "compute" || entry["duration"] == nil || entry["stack"] != nil {
+		t.Errorf("got success entry %v", entry)
+	}
+
+	buf.Reset()
+	wantErr := errors.New("boom")
+	if _, err := Observe(mgr, "compute", func() (string, error) { return "", wantErr }); err != wantErr {
+		t.Fatalf("got err %v, want %v", err, wantErr)
+	}
+	entry = nil
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["level"] != "error" || entry["error"] != "boom" {
+		t.Errorf("got failure entry %v", entry)
+	}
+	// The stack must start at the caller of Observe.
+	frames, _ := entry["stack"].([]any)
+	if len(frames) == 0 || !strings.HasPrefix(frames[0].(string), "encore.dev/rlog.TestObserve") {
+		t.Errorf("got stack %v, want it to start at TestObserve", frames)
+	}
+}
+
+func TestWarnIfSlow(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.WarnIfSlow(time.Hour)("fast op")
+	if buf.Len() != 0 {
+		t.Errorf("got log output %q for fast operation, want none", buf.String())
+	}
+
+	tr := mgr.rt.Current().Trace
+	tr.GetAndClear()
+	mgr.WarnIfSlow(-1)("slow op")
+	// The trace stack must start at the caller of the returned function.
+	if stacks := traceLogStacks(t, tr.GetAndClear()); len(stacks) != 1 || len(stacks[0]) == 0 ||
+		!strings.HasPrefix(stacks[0][0], "encore.dev/rlog.TestWarnIfSlow") {
+		t.Errorf("got trace stacks %v, want them to start at TestWarnIfSlow", stacks)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["level"] != "warn" || entry["op"] != "slow op" || entry["slow"] != true || entry["duration"] == nil {
+		t.Errorf("got entry %v", entry)
+	}
+}
+
+func TestEnvFields(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetEnvFields("staging", "debug_build", true)
+	mgr.SetEnvironment("prod")
+	mgr.Info("prod")
+	mgr.SetEnvironment("staging")
+	mgr.Info("staging")
+
+	want := `{"level":"info","message":"prod"}` + "\n" +
+		`{"level":"info","debug_build":true,"message":"staging"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log lines %q, want %q", got, want)
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	mgr, _, _ := newTestManager(t)
+	spanLog, end := mgr.StartSpan("resize", "width", 100)
+	spanLog.Info("in span")
+	end("ok", true)
+	end("ok", false) // no effect
+
+	data := mgr.rt.Current().Trace.GetAndClear()
+	var (
+		spanID  model.SpanID
+		events  []trace.EventType
+		endArgs []traceField
+	)
+	for len(data) > 0 {
+		typ := trace.EventType(data[0])
+		n := binary.LittleEndian.Uint32(data[9:13])
+		r := &traceReader{t: t, buf: data[13 : 13+n]}
+		data = data[13+n:]
+		events = append(events, typ)
+
+		switch typ {
+		case trace.UserSpanStart:
+			copy(spanID[:], r.bytes(8))
+			if parent := r.bytes(8); !bytes.Equal(parent, []byte{1, 2, 3, 0, 0, 0, 0, 0}) {
+				t.Errorf("got parent span %v, want the request span", parent)
+			}
+			r.uvarint() // goctr
+			if name := r.string(); name != "resize" {
+				t.Errorf("got span name %q, want %q", name, "resize")
+			}
+			if num := r.uvarint(); num != 1 {
+				t.Errorf("got %d span fields, want 1", num)
+			}
+		case trace.LogMessage:
+			if msg := r.logMessage(); msg.SpanID != spanID {
+				t.Errorf("got log span %v, want %v", msg.SpanID, spanID)
+			}
+		case trace.UserSpanEnd:
+			if id := r.bytes(8); !bytes.Equal(id, spanID[:]) {
+				t.Errorf("got end span %v, want %v", id, spanID)
+			}
+			r.uint64() // duration
+			for num := r.uvarint(); num > 0; num-- {
+				endArgs = append(endArgs, r.field())
+			}
+		}
+	}
+
+	wantEvents := []trace.EventType{trace.UserSpanStart, trace.LogMessage, trace.UserSpanEnd}
+	if diff := cmp.Diff(wantEvents, events); diff != "" {
+		t.Errorf("trace events mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]traceField{{Type: boolType, Key: "ok", Value: true}}, endArgs); diff != "" {
+		t.Errorf("end fields mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStacklessErrors(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetStacklessErrors(sql.ErrNoRows, context.Canceled)
+
+	// traceStacks reports the frame counts of the stacks of the
+	// error field and of the log message, respectively.
+	traceStacks := func() []int {
+		t.Helper()
+		data := mgr.rt.Current().Trace.GetAndClear()
+		r := &traceReader{t: t, buf: data[13:]}
+		r.logMessage()
+		r.stack()
+		return r.stacks
+	}
+
+	tests := []struct {
+		err       error
+		stackless bool
+	}{
+		{errs.Wrap(sql.ErrNoRows, "get user"), true},
+		{fmt.Errorf("fetch: %w", context.Canceled), true},
+		{errs.Wrap(errors.New("boom"), "get user"), false},
+	}
+	for _, test := range tests {
+		buf.Reset()
+		_, _ = Observe(mgr, "op", func() (int, error) { return 0, test.err })
+
+		stacks := traceStacks()
+		if got := stacks[0] == 0 && stacks[1] == 0; got != test.stackless {
+			t.Errorf("%v: got trace stack frames %v, want stackless=%v", test.err, stacks, test.stackless)
+		}
+		if got := !strings.Contains(buf.String(), `"stack"`); got != test.stackless {
+			t.Errorf("%v: got log line %s, want stackless=%v", test.err, buf.String(), test.stackless)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	report := mgr.Progress(40)
+	for i := 1; i <= 40; i++ {
+		report(i)
+	}
+	report(40) // already complete; not logged again
+
+	var percents []float64
+	opIDs := make(map[any]bool)
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var entry map[string]any
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+		progress := entry["progress"].(map[string]any)
+		percents = append(percents, progress["percent"].(float64))
+		opIDs[entry["operation_id"]] = true
+	}
+
+	want := []float64{2, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+	if diff := cmp.Diff(want, percents); diff != "" {
+		t.Errorf("logged percentages mismatch (-want +got):\n%s", diff)
+	}
+	if len(opIDs) != 1 {
+		t.Errorf("got operation ids %v, want a single id", opIDs)
+	}
+	if n := len(mgr.repeats.states); n != 0 {
+		t.Errorf("got %d repeat limiter states after completion, want 0", n)
+	}
+}
+
+func TestProgress_Interval(t *testing.T) {
+	var r repeatLimiter
+	key := progressKey("op")
+	now := time.Now()
+	steps := []struct {
+		after     time.Duration
+		milestone bool
+		want      bool
+	}{
+		{0, true, true},
+		{time.Second, false, false},
+		{progressInterval / 2, true, true},
+		{progressInterval - time.Second, false, false},
+		{progressInterval, false, true},
+		{time.Second, false, false},
+	}
+	for i, s := range steps {
+		now = now.Add(s.after)
+		if got := r.allowProgress(key, s.milestone, false, now); got != s.want {
+			t.Errorf("step %d: got allowed %v, want %v", i, got, s.want)
+		}
+	}
+	r.allowProgress(key, true, true, now)
+	if _, ok := r.states[key]; ok {
+		t.Errorf("progress state kept after completion")
+	}
+}
+
+func TestLogWithSpan(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.LogWithSpan(uuid.UUID{9, 8, 7}, LevelWarn, "imported", "source", "legacy")
+	mgr.LogWithSpan(uuid.UUID{9, 8, 7, 0, 0, 0, 0, 0, 1}, LevelWarn, "invalid")
+
+	want := `{"level":"warn","source":"legacy","message":"imported"}` + "\n" +
+		`{"level":"warn","message":"invalid"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log lines %q, want %q", got, want)
+	}
+	msgs := traceLog()
+	if len(msgs) != 1 {
+		t.Fatalf("got %d trace messages, want 1", len(msgs))
+	}
+	if want := (model.SpanID{9, 8, 7}); msgs[0].SpanID != want || msgs[0].Level != LevelWarn {
+		t.Errorf("got span %v, level %v, want %v, %v", msgs[0].SpanID, msgs[0].Level, want, LevelWarn)
+	}
+	if want := (model.SpanID{1, 2, 3}); msgs[0].ReqSpanID != want {
+		t.Errorf("got request span %v, want %v", msgs[0].ReqSpanID, want)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		Amount   int64
+		Currency string
+		Want     string
+	}{
+		{1234, "USD", "12.34 USD"},
+		{5, "EUR", "0.05 EUR"},
+		{-1999, "GBP", "-19.99 GBP"},
+		{500, "JPY", "500 JPY"},
+		{1234, "KWD", "1.234 KWD"},
+		{math.MinInt64, "USD", "-92233720368547758.08 USD"},
+	}
+	for _, test := range tests {
+		if got := formatMoney(moneyValue{Amount: test.Amount, Currency: test.Currency}); got != test.Want {
+			t.Errorf("formatMoney(%d, %s) = %q, want %q", test.Amount, test.Currency, got, test.Want)
+		}
+	}
+
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.InfoFields("paid", Money("total", 1234, "USD"), Money("fee", 5, "usd"))
+	want := `{"level":"info","total":{"amount":1234,"currency":"USD"},"fee":{"amount":5,"currency":"usd","invalid_currency":true},"message":"paid"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log line %q, want %q", got, want)
+	}
+	if f := traceLog()[0].Fields[0]; f.Type != jsonType || f.Value != `{"amount":1234,"currency":"USD"}` {
+		t.Errorf("got trace field %+v", f)
+	}
+
+	buf.Reset()
+	mgr.SetConsoleOutput(true)
+	mgr.InfoFields("paid", Money("total", 1234, "USD"))
+	if got := buf.String(); !strings.Contains(got, `"display":"12.34 USD"`) {
+		t.Errorf("got log line %q, want it to include the display amount", got)
+	}
+}
+
+func TestJSONEncoder(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	type payload struct {
+		URL string `json:"url"`
+	}
+	mgr.SetJSONEncoder(func(v any) ([]byte, error) {
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		err := enc.Encode(v)
+		return bytes.TrimSuffix(b.Bytes(), []byte("\n")), err
+	})
+	mgr.Info("msg", "payload", payload{URL: "/?a=1&b=2"})
+
+	want := `{"level":"info","payload":{"url":"/?a=1&b=2"},"message":"msg"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log line %q, want %q", got, want)
+	}
+	if f := traceLog()[0].Fields[0]; f.Value != `{"url":"/?a=1&b=2"}` {
+		t.Errorf("got trace field %+v", f)
+	}
+}
+
+func TestBatchResult(t *testing.T) {
+	failures := make(map[string]error)
+	for i := 0; i < maxBatchFailures+5; i++ {
+		failures[fmt.Sprintf("item-%02d", i)] = errs.B().Code(errs.InvalidArgument).Msg("bad item").Err()
+	}
+	fields := BatchResult(100, 75, 25, failures)
+
+	mgr, buf, _ := newTestManager(t)
+	mgr.InfoFields("batch done", fields...)
+	var entry struct {
+		Total     int `json:"batch_total"`
+		Failed    int `json:"batch_failed"`
+		Truncated int `json:"batch_failures_truncated"`
+		Failures  []struct {
+			ID    string   `json:"id"`
+			Error string   `json:"error"`
+			Stack []string `json:"stack"`
+		} `json:"failures"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Total != 100 || entry.Failed != 25 || entry.Truncated != 5 || len(entry.Failures) != maxBatchFailures {
+		t.Fatalf("got entry %+v", entry)
+	}
+	if f := entry.Failures[0]; f.ID != "item-00" || f.Error != "invalid_argument: bad item" || len(f.Stack) == 0 {
+		t.Errorf("got first failure %+v", f)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	a, b := redact("user-1"), redact("user-2")
+	if a != redact("user-1") {
+		t.Errorf("redact is not stable: %q != %q", a, redact("user-1"))
+	}
+	if a == b || !strings.HasPrefix(a, "redacted:") || strings.Contains(a, "user-1") {
+		t.Errorf("got redacted values %q and %q", a, b)
+	}
+	// The pseudonym must not be an unkeyed hash of the value,
+	// which could be reversed by hashing candidate values.
+	h := sha256.Sum256([]byte("user-1"))
+	if a == "redacted:"+hex.EncodeToString(h[:6]) {
+		t.Errorf("redact(%q) = %q is an unkeyed hash", "user-1", a)
+	}
+	if got := redact(""); got != "" {
+		t.Errorf("redact(\"\") = %q, want \"\"", got)
+	}
+}
+
+func TestRateLimitDecision(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	resetAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mgr.InfoFields("rate limited", RateLimitDecision("ip:10.0.0.1", false, 0, resetAt)...)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"level":               "info",
+		"ratelimit_key":       redact("ip:10.0.0.1"),
+		"ratelimit_allowed":   false,
+		"ratelimit_remaining": 0.0,
+		"ratelimit_reset_at":  resetAt.Format(time.RFC3339),
+		"message":             "rate limited",
+	}
+	if diff := cmp.Diff(want, entry); diff != "" {
+		t.Errorf("log entry mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.InfoFields("within", Budget(2*time.Second, 500*time.Millisecond)...)
+	mgr.InfoFields("exceeded", Budget(time.Second, 1500*time.Millisecond)...)
+
+	want := `{"level":"info","budget_total":2000,"budget_used":500,"budget_remaining":1500,"budget_exceeded":false,"message":"within"}` + "\n" +
+		`{"level":"info","budget_total":1000,"budget_used":1500,"budget_remaining":0,"budget_exceeded":true,"message":"exceeded"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.InfoFields("replayed", Idempotency("key-123", true)...)
+	mgr.InfoFields("executed", Idempotency("", false)...)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		`{"level":"info","idempotency_key":"` + redact("key-123") + `","idempotent_replay":true,"message":"replayed"}`,
+		`{"level":"info","idempotency_key":"","idempotent_replay":false,"message":"executed"}`,
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("log output mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJob(t *testing.T) {
+	var buf bytes.Buffer
+	rt := reqtrack.New(zerolog.New(&buf), nil, trace.DefaultFactory)
+	reqLogger := zerolog.New(&buf).With().Str("trace_id", "abc").Logger()
+	rt.BeginRequest(&model.Request{Traced: true, SpanID: model.SpanID{1, 2, 3}, Logger: &reqLogger})
+	t.Cleanup(rt.FinishRequest)
+	tr := rt.Current().Trace
+	mgr := NewManager(rt)
+
+	jobLogger, done := mgr.Job("reindex")
+	jobLogger.Info("progress", "n", 1)
+	done(errors.New("boom"))
+	_, done2 := mgr.Job("reindex")
+	done2(nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d log entries, want 5: %q", len(lines), buf.String())
+	}
+	var entries []map[string]any
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		// Job entries are detached from the current request.
+		if entry["trace_id"] != nil || entry["job"] != "reindex" || entry["job_id"] == nil {
+			t.Errorf("got log entry %v", entry)
+		}
+		entries = append(entries, entry)
+	}
+	if entries[0]["job_id"] != entries[2]["job_id"] || entries[0]["job_id"] == entries[3]["job_id"] {
+		t.Errorf("got job ids %v, %v, %v", entries[0]["job_id"], entries[2]["job_id"], entries[3]["job_id"])
+	}
+	msgs := []string{"job started", "progress", "job failed", "job started", "job completed"}
+	for i, msg := range msgs {
+		if entries[i]["message"] != msg {
+			t.Errorf("entry %d: got message %v, want %q", i, entries[i]["message"], msg)
+		}
+	}
+	if e := entries[2]; e["level"] != "error" || e["success"] != false || e["error"] != "boom" || e["duration"] == nil {
+		t.Errorf("got failure entry %v", e)
+	}
+	if e := entries[4]; e["level"] != "info" || e["success"] != true || e["duration"] == nil {
+		t.Errorf("got completion entry %v", e)
+	}
+	if msgs := decodeTraceLog(t, tr.GetAndClear()); len(msgs) != 0 {
+		t.Errorf("got %d trace messages from the job, want 0", len(msgs))
+	}
+
+	// Entries logged with the manager still belong to the request.
+	buf.Reset()
+	mgr.Info("request")
+	if got := buf.String(); !strings.Contains(got, `"trace_id":"abc"`) {
+		t.Errorf("got log output %q, want it to include the request's context", got)
+	}
+}
+
+type sinkFunc func(rec Record)
+
+func (fn sinkFunc) Emit(rec Record) { fn(rec) }
+
+func TestSink(t *testing.T) {
+	mgr, _, _ := newTestManager(t)
+	var recs []Record
+	mgr.AddSink(sinkFunc(func(rec Record) { recs = append(recs, rec) }))
+	mgr.SetReleaseID("v1")
+	mgr.With("ctx", 1).Warn("msg", "key", "val")
+
+	if len(recs) != 1 {
+		t.Fatalf("got %d records, want 1", len(recs))
+	}
+	rec := recs[0]
+	if rec.Level != LevelWarn || rec.Message != "msg" || rec.SpanID != [8]byte{1, 2, 3} {
+		t.Errorf("got record %+v", rec)
+	}
+	want := []Field{Any("ctx", 1), String("key", "val"), String("release", "v1")}
+	if diff := cmp.Diff(want, rec.Fields); diff != "" {
+		t.Errorf("fields mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTraceLevel(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.Trace("verbose", "key", "val")
+	mgr.With("ctx", 1).Trace("verbose ctx")
+
+	if buf.Len() != 0 {
+		t.Errorf("got log output %q, want none", buf.String())
+	}
+	msgs := traceLog()
+	if len(msgs) != 2 || msgs[0].Level != LevelTrace || msgs[0].Msg != "verbose" || msgs[1].Msg != "verbose ctx" {
+		t.Errorf("got trace messages %+v", msgs)
+	}
+}
+
+func TestFatalAndPanic(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	var exitCode int
+	osExit = func(code int) { exitCode = code }
+	t.Cleanup(func() { osExit = os.Exit })
+
+	mgr.Fatal("fatal", "key", "val")
+	if exitCode != 1 {
+		t.Errorf("got exit code %d, want 1", exitCode)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != "panic" {
+				t.Errorf("got panic %v, want %q", r, "panic")
+			}
+		}()
+		mgr.Panic("panic")
+	}()
+
+	var levels []any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var entry map[string]any
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry["stack"] == nil {
+			t.Errorf("got entry %v without stack", entry)
+		}
+		levels = append(levels, entry["level"])
+	}
+	if diff := cmp.Diff([]any{"fatal", "panic"}, levels); diff != "" {
+		t.Errorf("levels mismatch (-want +got):\n%s", diff)
+	}
+	if msgs := traceLog(); len(msgs) != 2 || msgs[0].Level != LevelError {
+		t.Errorf("got trace messages %+v", msgs)
+	}
+}
+
+type countingExporter struct{ n int32 }
+
+func (e *countingExporter) ExportTrace(ctx context.Context, data []byte) error {
+	atomic.AddInt32(&e.n, 1)
+	return nil
+}
+
+func TestPanicKeepsTrace(t *testing.T) {
+	mgr, _, traceLog := newTestManager(t)
+	exp := &countingExporter{}
+	mgr.rt.AddTraceExporter(exp)
+
+	func() {
+		defer func() { _ = recover() }()
+		mgr.Panic("panic")
+	}()
+	// The trace is left to be sent when the request completes.
+	if n := atomic.LoadInt32(&exp.n); n != 0 {
+		t.Errorf("got %d exported traces, want 0", n)
+	}
+	if msgs := traceLog(); len(msgs) != 1 || msgs[0].Msg != "panic" {
+		t.Errorf("got trace messages %+v", msgs)
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetLevel(LevelWarn)
+	mgr.Info("hidden")
+	mgr.Warn("shown")
+
+	if got, want := buf.String(), `{"level":"warn","message":"shown"}`+"\n"; got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	if msgs := traceLog(); len(msgs) != 2 {
+		t.Errorf("got %d trace messages, want 2", len(msgs))
+	}
+
+	h := mgr.LevelHandler()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("PUT", "/loglevel", strings.NewReader(`{"level":"DEBUG"}`)))
+	if got, want := w.Body.String(), `{"level":"debug"}`+"\n"; got != want {
+		t.Errorf("got response %q, want %q", got, want)
+	}
+	if got := mgr.CurrentLevel(); got != LevelDebug {
+		t.Errorf("got level %v, want %v", got, LevelDebug)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("PUT", "/loglevel", strings.NewReader(`{"level":"verbose"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("got status %d for invalid level, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestSetServiceLevel(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetLevel(LevelInfo)
+	mgr.SetServiceLevel("usersvc", LevelDebug)
+	mgr.SetServiceLevel("othersvc", LevelError)
+
+	mgr.Debug("no service")
+	mgr.rt.Current().Req.Test = &model.TestData{Service: "usersvc"}
+	mgr.Debug("usersvc")
+	mgr.rt.Current().Req.Test.Service = "othersvc"
+	mgr.Warn("othersvc")
+	mgr.ResetServiceLevel("othersvc")
+	mgr.Warn("othersvc reset")
+
+	want := `{"level":"debug","message":"usersvc"}` + "\n" +
+		`{"level":"warn","message":"othersvc reset"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+}
+
+func TestLogr(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetLevel(LevelInfo)
+	logger := mgr.Logr().WithName("ctrl").WithName("sub").WithValues("a", 1)
+	logger.Info("hello", "b", 2)
+	logger.V(1).Info("hidden")
+	logger.Error(errors.New("boom"), "failed")
+
+	want := `{"level":"info","logger":"ctrl/sub","a":1,"b":2,"message":"hello"}` + "\n" +
+		`{"level":"error","logger":"ctrl/sub","a":1,"error":"boom","message":"failed"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	msgs := traceLog()
+	if len(msgs) != 3 || msgs[1].Level != LevelDebug || msgs[1].SpanID != (model.SpanID{1, 2, 3}) {
+		t.Errorf("got trace messages %+v", msgs)
+	}
+}
+
+func TestZapCore(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetLevel(LevelInfo)
+	logger := zap.New(mgr.ZapCore()).Named("db").With(zap.Int("a", 1))
+	logger.Info("hello", zap.Duration("took", time.Second), zap.Namespace("ns"), zap.String("b", "x"))
+	logger.Debug("hidden")
+	logger.Error("failed", zap.Error(errors.New("boom")))
+
+	want := `{"level":"info","logger":"db","a":1,"took":1000,"ns.b":"x","message":"hello"}` + "\n" +
+		`{"level":"error","logger":"db","a":1,"error":"boom","message":"failed"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	msgs := traceLog()
+	if len(msgs) != 3 || msgs[1].Level != LevelDebug || msgs[1].SpanID != (model.SpanID{1, 2, 3}) {
+		t.Errorf("got trace messages %+v", msgs)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	ctx := NewContext(context.Background(), mgr.With("user", "alice"))
+	mgr.FromContext(ctx).Info("with context")
+	mgr.FromContext(context.Background()).Info("without context")
+
+	want := `{"level":"info","user":"alice","message":"with context"}` + "\n" +
+		`{"level":"info","message":"without context"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetLevel(LevelInfo)
+	calls := 0
+	lazy := Lazy(func() any {
+		calls++
+		return map[string]int{"n": calls}
+	})
+
+	mgr.Info("shown", "summary", lazy)
+	mgr.Debug("trace only", "summary", lazy)
+	done := make(chan struct{})
+	go func() {
+		// Outside of a request, debug entries are discarded.
+		defer close(done)
+		mgr.Debug("discarded", "summary", lazy)
+	}()
+	<-done
+
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+	if got, want := buf.String(), `{"level":"info","summary":{"n":1},"message":"shown"}`+"\n"; got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	if msgs := traceLog(); len(msgs) != 2 {
+		t.Errorf("got %d trace messages, want 2", len(msgs))
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetRedactedKeys("Email")
+	mgr.AddRedactionPattern(regexp.MustCompile(`\b(?:\d[ -]?){12,15}\d\b`))
+	mgr.With("email", "alice@example.com").Info("payment", "note", "card 4111 1111 1111 1111 declined", "n", 1)
+
+	want := `{"level":"info","email":"[redacted]","note":"card [redacted] declined","n":1,"message":"payment"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	msgs := traceLog()
+	if len(msgs) != 1 || len(msgs[0].Fields) != 3 || msgs[0].Fields[0].Value != "[redacted]" || msgs[0].Fields[1].Value != "card [redacted] declined" {
+		t.Errorf("got trace messages %+v", msgs)
+	}
+}
+
+func TestSampling(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetSampling(LevelDebug, 3)
+	for i := 0; i < 6; i++ {
+		mgr.Debug("hot loop", "i", i)
+	}
+	mgr.Info("not sampled")
+
+	want := `{"level":"debug","i":0,"sample_rate":3,"message":"hot loop"}` + "\n" +
+		`{"level":"debug","i":3,"sample_rate":3,"message":"hot loop"}` + "\n" +
+		`{"level":"info","message":"not sampled"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	if msgs := traceLog(); len(msgs) != 3 {
+		t.Errorf("got %d trace messages, want 3", len(msgs))
+	}
+}
+
+func TestRepeatLimit(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetRepeatLimit(2, time.Hour)
+	for i := 0; i < 5; i++ {
+		mgr.Warn("retrying", "attempt", 1)
+	}
+	mgr.Warn("retrying", "attempt", 2)
+	mgr.flushRepeats()
+
+	want := `{"level":"warn","attempt":1,"message":"retrying"}` + "\n" +
+		`{"level":"warn","attempt":1,"message":"retrying"}` + "\n" +
+		`{"level":"warn","attempt":2,"message":"retrying"}` + "\n" +
+		`{"level":"warn","repeated":3,"message":"retrying"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	if msgs := traceLog(); len(msgs) != 4 {
+		t.Errorf("got %d trace messages, want 4", len(msgs))
+	}
+}
+
+// countingStringer counts the calls to its String method.
+type countingStringer struct{ calls *int }
+
+func (s countingStringer) String() string {
+	*s.calls++
+	return "value"
+}
+
+func TestRepeatKey(t *testing.T) {
+	var calls int
+	key := func(fields ...any) uint64 {
+		return repeatKey(LevelWarn, "retrying", []any{"app", "x"}, fields)
+	}
+	if key("attempt", 1) == key("attempt", 2) {
+		t.Error("entries with different primitive values have the same key")
+	}
+	if key("attempt", 1) == key("attempt", "1") {
+		t.Error("entries with values of different types have the same key")
+	}
+	if key("a", "bc") == key("ab", "c") {
+		t.Error("entries with different fields have the same key")
+	}
+	if key("err", errors.New("a")) != key("err", errors.New("b")) {
+		t.Error("entries with different non-primitive values have different keys")
+	}
+	key("value", countingStringer{&calls})
+	if calls != 0 {
+		t.Errorf("got %d String calls, want 0", calls)
+	}
+}
+
+func TestAddWriter(t *testing.T) {
+	var primary, secondary bytes.Buffer
+	out := NewOutput(&primary)
+	rt := reqtrack.New(zerolog.New(out), nil, nil)
+	mgr := NewManager(rt)
+	mgr.Info("before")
+	mgr.SetOutput(out)
+	mgr.AddWriter(&secondary)
+	mgr.Info("after")
+
+	want := `{"level":"info","message":"before"}` + "\n" + `{"level":"info","message":"after"}` + "\n"
+	if got := primary.String(); got != want {
+		t.Errorf("got primary output %q, want %q", got, want)
+	}
+	if got, want := secondary.String(), `{"level":"info","message":"after"}`+"\n"; got != want {
+		t.Errorf("got secondary output %q, want %q", got, want)
+	}
+}
+
+func TestSetConsoleOutput(t *testing.T) {
+	var primary, secondary bytes.Buffer
+	out := NewOutput(&primary)
+	rt := reqtrack.New(zerolog.New(out), nil, nil)
+	mgr := NewManager(rt)
+	mgr.SetOutput(out)
+	mgr.AddWriter(&secondary)
+
+	mgr.SetConsoleOutput(true)
+	mgr.Info("console")
+	mgr.SetConsoleOutput(false)
+	mgr.Info("json")
+
+	lines := strings.Split(strings.TrimSpace(primary.String()), "\n")
+	if len(lines) != 2 || strings.HasPrefix(lines[0], "{") || !strings.Contains(lines[0], "console") ||
+		lines[1] != `{"level":"info","message":"json"}` {
+		t.Errorf("got primary output %q", primary.String())
+	}
+	want := `{"level":"info","message":"console"}` + "\n" + `{"level":"info","message":"json"}` + "\n"
+	if got := secondary.String(); got != want {
+		t.Errorf("got secondary output %q, want %q", got, want)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.AddHook(func(level Level, msg string, fields []any) (bool, []any) {
+		return msg == "noisy", fields
+	})
+	mgr.AddHook(func(level Level, msg string, fields []any) (bool, []any) {
+		return true, append(fields, "tenant_id", "t1")
+	})
+	mgr.Info("noisy")
+	mgr.Info("dropped", "key", "val")
+	mgr.Critical("critical")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("got log output %q: %v", buf.String(), err)
+	}
+	if entry["message"] != "critical" || entry["tenant_id"] != "t1" {
+		t.Errorf("got log entry %v", entry)
+	}
+	if msgs := traceLog(); len(msgs) != 1 {
+		t.Errorf("got %d trace messages, want 1", len(msgs))
+	}
+}
+
+type testStringer struct{ name string }
+
+func (s *testStringer) String() string { return "stringer:" + s.name }
+
+func TestTypedCollections(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	var nilStringer *testStringer
+	mgr.With("tags", []string{"a", "b\"c"}).Info("typed",
+		"labels", map[string]string{"z": "1", "a": "\x01"},
+		"stringer", &testStringer{"x"},
+		"nil", fmt.Stringer(nilStringer))
+
+	want := `{"level":"info","tags":["a","b\"c"],"labels":{"a":"\u0001","z":"1"},"stringer":"stringer:x","nil":"<nil>","message":"typed"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	fields := traceLog()[0].Fields
+	wantFields := []traceField{
+		{Type: jsonType, Key: "tags", Value: `["a","b\"c"]`},
+		{Type: jsonType, Key: "labels", Value: `{"a":"\u0001","z":"1"}`},
+		{Type: strType, Key: "stringer", Value: "stringer:x"},
+		{Type: strType, Key: "nil", Value: "<nil>"},
+	}
+	if diff := cmp.Diff(wantFields, fields); diff != "" {
+		t.Errorf("trace fields mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestErrorChain(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	root := errors.New("connection refused")
+	err := fmt.Errorf("load user: %w", errs.B().Code(errs.Unavailable).Msg("db down").Meta("shard", 3).Cause(root).Err())
+	mgr.Error("request failed", "err", err)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"level":     "error",
+		"message":   "request failed",
+		"err":       "load user: unavailable: db down: connection refused",
+		"err_chain": []any{"unavailable: db down: connection refused", "connection refused"},
+		"err_code":  "unavailable",
+		"err_meta":  map[string]any{"shard": float64(3)},
+	}
+	if diff := cmp.Diff(want, entry); diff != "" {
+		t.Errorf("log entry mismatch (-want +got):\n%s", diff)
+	}
+
+	var keys []string
+	for _, f := range traceLog()[0].Fields {
+		keys = append(keys, f.Key)
+	}
+	if got, want := strings.Join(keys, ","), "err,err_chain,err_code,err_meta"; got != want {
+		t.Errorf("got trace fields %s, want %s", got, want)
+	}
+}
+
+func TestIncludeCaller(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetIncludeCaller(true)
+	_, file, line, _ := runtime.Caller(0)
+	mgr.Info("direct")
+	mgr.With("k", "v").Info("ctx")
+	Observe(mgr, "observed", func() (int, error) { return 0, nil })
+
+	dec := json.NewDecoder(buf)
+	wantLines := []int{line + 1, line + 2, line + 3}
+	for _, wantLine := range wantLines {
+		var entry map[string]any
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := entry["caller"], file+":"+strconv.Itoa(wantLine); got != want {
+			t.Errorf("got caller %v for %q, want %s", got, entry["message"], want)
+		}
+	}
+}
+
+func TestErr(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.With("user", "alice").Err(errs.B().Code(errs.NotFound).Msg("no such user").Err(), "lookup failed", "id", 1)
+	mgr.Err(nil, "no error")
+
+	dec := json.NewDecoder(buf)
+	var entry map[string]any
+	if err := dec.Decode(&entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["level"] != "error" || entry["error"] != "not_found: no such user" || entry["user"] != "alice" ||
+		entry["id"] != float64(1) || entry["error_stack"] == nil {
+		t.Errorf("got log entry %v", entry)
+	}
+	entry = nil
+	if err := dec.Decode(&entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["message"] != "no error" || entry["error"] != nil {
+		t.Errorf("got log entry %v", entry)
+	}
+	if msgs := traceLog(); len(msgs) != 2 || msgs[0].Level != LevelError || msgs[0].Fields[1].Key != "error" {
+		t.Errorf("got trace messages %+v", msgs)
+	}
+}
+
+func TestDedupWindow(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetDedupWindow(time.Hour)
+	for i := 0; i < 3; i++ {
+		mgr.Info("health check", "status", "ok")
+	}
+	mgr.Info("health check", "status", "degraded")
+	mgr.flushRepeats()
+
+	want := `{"level":"info","status":"ok","message":"health check"}` + "\n" +
+		`{"level":"info","status":"degraded","message":"health check"}` + "\n" +
+		`{"level":"info","repeated":2,"message":"health check"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+}
+
+func TestAsyncOutput(t *testing.T) {
+	w := &gatedWriter{entered: make(chan struct{}, 10), gate: make(chan struct{})}
+	out := NewOutput(w)
+	rt := reqtrack.New(zerolog.New(out), nil, nil)
+	mgr := NewManager(rt)
+	mgr.SetOutput(out)
+	mgr.SetAsyncOutput(1, DropNewest)
+
+	mgr.Info("a")
+	<-w.entered // "a" is being written, so the queue is empty
+	mgr.Info("b")
+	mgr.Info("c") // dropped, since "b" fills the queue
+	if got := mgr.DroppedOutput(); got != 1 {
+		t.Errorf("got %d dropped entries, want 1", got)
+	}
+
+	close(w.gate)
+	mgr.Error("d")
+	mgr.Info("e")
+	mgr.Shutdown(context.Background())
+
+	want := `{"level":"info","message":"a"}` + "\n" +
+		`{"level":"info","message":"b"}` + "\n" +
+		`{"level":"error","message":"d"}` + "\n" +
+		`{"level":"info","message":"e"}` + "\n"
+	if got := w.buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+}
+
+func TestAsyncOutput_DropOldest(t *testing.T) {
+	w := &gatedWriter{entered: make(chan struct{}, 10), gate: make(chan struct{})}
+	out := NewOutput(w)
+	rt := reqtrack.New(zerolog.New(out), nil, nil)
+	mgr := NewManager(rt)
+	mgr.SetOutput(out)
+	mgr.SetAsyncOutput(1, DropOldest)
+
+	mgr.Info("a")
+	<-w.entered // "a" is being written, so the queue is empty
+	flushed := make(chan struct{})
+	go func() {
+		mgr.flushOutput(context.Background())
+		close(flushed)
+	}()
+
+	// Pending flushes never block logging.
+	logged := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			mgr.Info("b")
+		}
+		mgr.Info("c")
+		close(logged)
+	}()
+	select {
+	case <-logged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("logging blocked while the queue was full")
+	}
+
+	close(w.gate)
+	<-flushed
+	mgr.Shutdown(context.Background())
+	want := `{"level":"info","message":"a"}` + "\n" +
+		`{"level":"info","message":"c"}` + "\n"
+	if got := w.buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	if got := mgr.DroppedOutput(); got != 5 {
+		t.Errorf("got %d dropped entries, want 5", got)
+	}
+}
+
+func TestSetAsyncOutput_Replace(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewOutput(&buf)
+	rt := reqtrack.New(zerolog.New(out), nil, nil)
+	mgr := NewManager(rt)
+	mgr.SetOutput(out)
+	mgr.SetAsyncOutput(10, Block)
+	prev := out.async.Load().(*asyncQueue)
+
+	mgr.Info("a")
+	mgr.SetAsyncOutput(0, Block)
+	select {
+	case <-prev.stop:
+	default:
+		t.Fatal("replaced queue not stopped")
+	}
+
+	// Loggers still holding the replaced queue write synchronously.
+	prev.enqueue([]byte("b\n"))
+	if got, want := buf.String(), `{"level":"info","message":"a"}`+"\nb\n"; got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+}
+
+func TestSetAsyncOutput_ReplaceConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewOutput(zerolog.SyncWriter(&buf))
+	rt := reqtrack.New(zerolog.New(out), nil, nil)
+	mgr := NewManager(rt)
+	mgr.SetOutput(out)
+	mgr.SetAsyncOutput(10, Block)
+
+	const goroutines, entries = 8, 2000
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < entries; j++ {
+				mgr.Info("entry")
+			}
+		}()
+	}
+	for i := 0; i < 1000; i++ {
+		mgr.SetAsyncOutput(10, Block)
+	}
+	wg.Wait()
+	mgr.SetAsyncOutput(0, Block)
+
+	if got := strings.Count(buf.String(), "\n"); got != goroutines*entries {
+		t.Errorf("got %d log entries, want %d", got, goroutines*entries)
+	}
+}
+
+func TestCritical_NeverDropped(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetSampling(LevelError, 3)
+	mgr.SetRepeatLimit(1, time.Hour)
+	mgr.SetDedupWindow(time.Hour)
+	for i := 0; i < 5; i++ {
+		mgr.Critical("audit", "user", "u1")
+	}
+	mgr.flushRepeats()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d log entries, want 5: %q", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry["message"] != "audit" || entry["critical"] != true || entry["sample_rate"] != nil || entry["repeated"] != nil {
+			t.Errorf("got log entry %v", entry)
+		}
+	}
+	if msgs := traceLog(); len(msgs) != 5 {
+		t.Errorf("got %d trace messages, want 5", len(msgs))
+	}
+}
+
+func TestCritical_AsyncOutput(t *testing.T) {
+	w := &gatedWriter{entered: make(chan struct{}, 10), gate: make(chan struct{})}
+	out := NewOutput(w)
+	rt := reqtrack.New(zerolog.New(out), nil, nil)
+	mgr := NewManager(rt)
+	mgr.SetOutput(out)
+	mgr.SetAsyncOutput(1, DropNewest)
+
+	mgr.Info("a")
+	<-w.entered // "a" is being written, so the queue is empty
+	mgr.Info("b")
+	mgr.Info("c") // dropped, since "b" fills the queue
+
+	// Critical entries are not dropped when the queue is full,
+	// and are written by the time Critical returns.
+	logged := make(chan struct{})
+	go func() {
+		mgr.Critical("audit")
+		close(logged)
+	}()
+	select {
+	case <-logged:
+		t.Fatal("Critical returned before its entry was written")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(w.gate)
+	<-logged
+
+	got := w.buf.String()
+	if !strings.Contains(got, `"message":"audit"`) {
+		t.Errorf("got log output %q, want it to include the critical entry", got)
+	}
+	if i, j := strings.Index(got, `"message":"b"`), strings.Index(got, `"message":"audit"`); i < 0 || i > j {
+		t.Errorf("got log output %q, want the critical entry after the queued entries", got)
+	}
+	if got := mgr.DroppedOutput(); got != 1 {
+		t.Errorf("got %d dropped entries, want 1", got)
+	}
+	mgr.Shutdown(context.Background())
+}
+
+// gatedWriter is a writer that blocks writes until gate is closed.
+type gatedWriter struct {
+	entered chan struct{}
+	gate    chan struct{}
+	buf     bytes.Buffer
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	w.entered <- struct{}{}
+	<-w.gate
+	return w.buf.Write(p)
+}
+
+func TestSelfMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewOutput(&buf)
+	rt := reqtrack.New(zerolog.New(out), nil, trace.DefaultFactory)
+	rt.BeginRequest(&model.Request{Traced: true, SpanID: model.SpanID{1, 2, 3}})
+	t.Cleanup(rt.FinishRequest)
+	mgr := NewManager(rt)
+	mgr.SetOutput(out)
+	mgr.SetLevel(LevelInfo)
+	mgr.SetSelfMetrics(true)
+	t.Cleanup(func() { mgr.SetSelfMetrics(false) })
+
+	summary := func() map[string]any {
+		t.Helper()
+		buf.Reset()
+		mgr.logSelfMetrics()
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("got log output %q: %v", buf.String(), err)
+		}
+		return entry
+	}
+
+	mgr.Info("a")
+	mgr.Info("b")
+	mgr.Debug("c") // below the output level, so only written to the trace
+	written := buf.Len()
+	entry := summary()
+	if entry["message"] != "rlog self-metrics" || entry["lines"] != 3.0 || entry["dropped"] != 1.0 || entry["sampled"] != 0.0 {
+		t.Errorf("got summary %v", entry)
+	}
+	if n, _ := entry["trace_bytes"].(float64); n <= 0 {
+		t.Errorf("got summary %v, want trace bytes", entry)
+	}
+	if entry["output_bytes"] != float64(written) {
+		t.Errorf("got summary %v, want %d output bytes", entry, written)
+	}
+
+	// Summaries are excluded from the statistics, which are reset.
+	if entry := summary(); entry["lines"] != 0.0 || entry["trace_bytes"] != 0.0 || entry["output_bytes"] != 0.0 {
+		t.Errorf("got summary %v after reset", entry)
+	}
+
+	// Sampled-out entries are counted, and summaries are never sampled.
+	mgr.SetSampling(LevelInfo, 10)
+	for i := 0; i < 10; i++ {
+		mgr.Info("sampled")
+	}
+	entry = summary()
+	if entry["lines"] != 10.0 || entry["dropped"] != 9.0 || entry["sampled"] != 9.0 {
+		t.Errorf("got summary %v after sampling", entry)
+	}
+	if _, ok := entry["sample_rate"]; ok {
+		t.Errorf("got sampled summary %v", entry)
+	}
+
+	// Entries dropped by hooks are counted too.
+	mgr.SetSampling(LevelInfo, 1)
+	mgr.AddHook(func(_ Level, msg string, fields []any) (bool, []any) { return msg == "hooked", fields })
+	mgr.Info("hooked")
+	if entry := summary(); entry["lines"] != 1.0 || entry["dropped"] != 1.0 || entry["sampled"] != 0.0 {
+		t.Errorf("got summary %v after hook", entry)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	var h heartbeat
+	var n int
+	h.set("count", func() { n++ })
+	if h.stop == nil {
+		t.Fatal("heartbeat not running with a task")
+	}
+	h.beat()
+	h.beat()
+	if n != 2 {
+		t.Errorf("got %d beats, want 2", n)
+	}
+	h.set("count", nil)
+	if h.stop != nil {
+		t.Error("heartbeat running without tasks")
+	}
+}
+
+func TestLogMetrics(t *testing.T) {
+	rt := reqtrack.New(zerolog.New(io.Discard), nil, nil)
+	rt.BeginRequest(&model.Request{SvcNum: 1})
+	t.Cleanup(rt.FinishRequest)
+	reg := metrics.NewRegistry(rt, 1)
+	mgr := NewManager(rt)
+	mgr.RegisterMetrics(reg)
+	mgr.SetLevel(LevelInfo)
+
+	mgr.Info("a")
+	mgr.Info("b")
+	mgr.Error("c")
+	mgr.Debug("hidden")
+
+	got := make(map[string]uint64)
+	for _, m := range reg.Collect() {
+		if m.Info.Name() == "e_logs_total" && len(m.Labels) == 1 {
+			got[m.Labels[0].Value] = m.Val.([]uint64)[0]
+		}
+	}
+	want := map[string]uint64{"trace": 0, "debug": 0, "info": 2, "warn": 0, "error": 1}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("got logs_total (-want +got):\n%s", diff)
+	}
+}
+
+func TestCaptureLogs(t *testing.T) {
+	rt := reqtrack.New(zerolog.New(io.Discard), nil, nil)
+	mgr := NewManager(rt)
+	mgr.Info("before test")
+
+	var logs *CapturedLogs
+	t.Run("sub", func(t *testing.T) {
+		logs = mgr.CaptureLogs(t)
+		rt.BeginRequest(&model.Request{Test: &model.TestData{Current: t}})
+		mgr.With("order_id", "123").Error("payment failed", "attempt", 2)
+		rt.FinishRequest()
+		mgr.Info("outside test")
+	})
+	mgr.Info("after test")
+
+	entries := logs.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("got %d captured entries, want 1: %+v", len(entries), entries)
+	}
+	rec, ok := logs.Find(LevelError, "payment failed")
+	if !ok {
+		t.Fatal("entry not found")
+	}
+	if v, _ := rec.Field("order_id"); v != "123" {
+		t.Errorf("got order_id %v, want 123", v)
+	}
+	if v, _ := rec.Field("attempt"); v != 2 {
+		t.Errorf("got attempt %v, want 2", v)
+	}
+	if n := len(mgr.config().sinks); n != 0 {
+		t.Errorf("got %d sinks after the test completed, want 0", n)
+	}
+}
+
+func TestStrictPairs(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.Info("lenient", 1, "a", "b", "c", "dangling")
+	if got, want := buf.String(), `{"level":"info","1":"a","b":"c","message":"lenient"}`+"\n"; got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	mgr.SetStrictPairs(true)
+	mgr.Info("strict", "a", 1, 2, "b", "dangling")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log entries, want 2: %s", len(lines), buf.String())
+	}
+	var diag map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &diag); err != nil {
+		t.Fatal(err)
+	}
+	want := "key 1 is of type int, not string; key dangling has no value"
+	if diag["message"] != "rlog: malformed key-value pairs" || diag["problem"] != want || diag["stack"] == nil {
+		t.Errorf("got diagnostic %v", diag)
+	}
+	if got, want := lines[1], `{"level":"info","a":1,"2":"b","message":"strict"}`; got != want {
+		t.Errorf("got log entry %q, want %q", got, want)
+	}
+}
+
+func TestFieldMarshaler(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetRedactedKeys("password")
+	mgr.Info("marshal",
+		"user", testUser{ID: "u1", Password: "secret", Team: testTeam{"core"}},
+		"team", testTeam{"infra"})
+
+	want := `{"level":"info","user":{"id":"u1","password":"[redacted]","team":{"team":"core"}},"team":{"team":"infra"},"message":"marshal"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	fields := traceLog()[0].Fields
+	wantFields := []traceField{
+		{Type: jsonType, Key: "user", Value: `{"id":"u1","password":"[redacted]","team":{"team":"core"}}`},
+		{Type: jsonType, Key: "team", Value: `{"team":"infra"}`},
+	}
+	if diff := cmp.Diff(wantFields, fields); diff != "" {
+		t.Errorf("trace fields mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type testUser struct {
+	ID       string
+	Password string
+	Team     testTeam
+}
+
+func (u testUser) MarshalLogFields() []Field {
+	return []Field{String("id", u.ID), String("password", u.Password), {Key: "team", Value: u.Team}}
+}
+
+type testTeam struct {
+	Name string
+}
+
+func (t testTeam) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("team", t.Name)
+}
+
+func TestNamed(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetLevel(LevelInfo)
+	mgr.SetNamedLevel("billing", LevelWarn)
+	mgr.SetNamedLevel("billing.invoicer", LevelDebug)
+
+	billing := mgr.Named("billing").With("a", 1)
+	billing.Info("hidden")
+	billing.Warn("billing")
+	billing.Named("invoicer").Debug("invoicer")
+	billing.Named("payments").Info("hidden")
+	mgr.Named("other").Debug("hidden")
+
+	want := `{"level":"warn","a":1,"logger":"billing","message":"billing"}` + "\n" +
+		`{"level":"debug","a":1,"logger":"billing.invoicer","message":"invoicer"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,37 @@
+// Package rlogproto adds support for logging protobuf values with rlog.
+//
+// Importing the package for its side effects registers the support:
+//
+//	import _ "encore.dev/rlog/rlogproto"
+//
+// Once registered, protobuf enum values are logged using the name
+// of the enum value (such as "STATUS_ACTIVE") rather than its number.
+package rlogproto
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"encore.dev/rlog"
+)
+
+func init() {
+	rlog.RegisterValueConverter(convert)
+}
+
+// convert converts protobuf values to their loggable representation.
+func convert(val any) (any, bool) {
+	if e, ok := val.(protoreflect.Enum); ok {
+		return enumName(e), true
+	}
+	return nil, false
+}
+
+// enumName returns the name of the enum value e.
+// Values not defined by the enum are rendered as their number.
+func enumName(e protoreflect.Enum) any {
+	num := e.Number()
+	if v := e.Descriptor().Values().ByNumber(num); v != nil {
+		return string(v.Name())
+	}
+	return int32(num)
+}
@@ -0,0 +1,28 @@
+package rlogproto
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		Val  any
+		Want any
+		OK   bool
+	}{
+		{structpb.NullValue_NULL_VALUE, "NULL_VALUE", true},
+		{descriptorpb.FieldDescriptorProto_TYPE_STRING, "TYPE_STRING", true},
+		{descriptorpb.FieldDescriptorProto_Type(999), int32(999), true},
+		{int32(5), nil, false},
+		{"TYPE_STRING", nil, false},
+	}
+	for _, test := range tests {
+		got, ok := convert(test.Val)
+		if got != test.Want || ok != test.OK {
+			t.Errorf("convert(%v) = %v, %v, want %v, %v", test.Val, got, ok, test.Want, test.OK)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+//go:build encore_app
+
+package rlogtest
+
+import (
+	"testing"
+
+	"encore.dev/rlog"
+)
+
+// Preserve snapshots the application's logging configuration and
+// restores it when t and all its subtests complete, so that configuration
+// changes made by the test do not affect other tests.
+func Preserve(t testing.TB) {
+	t.Helper()
+	cfg := rlog.SnapshotConfig()
+	t.Cleanup(func() {
+		rlog.RestoreConfig(cfg)
+	})
+}
@@ -0,0 +1,26 @@
+// Package rlogtest provides utilities for testing code that uses rlog.
+package rlogtest
+
+import (
+	"testing"
+
+	"encore.dev/rlog"
+)
+
+// ConfigManager is implemented by types whose logging configuration
+// can be snapshotted and restored, such as rlog.Manager.
+type ConfigManager interface {
+	SnapshotConfig() rlog.Config
+	RestoreConfig(rlog.Config)
+}
+
+// PreserveConfig snapshots the logging configuration of mgr and
+// restores it when t and all its subtests complete, so that configuration
+// changes made by the test do not affect other tests.
+func PreserveConfig(t testing.TB, mgr ConfigManager) {
+	t.Helper()
+	cfg := mgr.SnapshotConfig()
+	t.Cleanup(func() {
+		mgr.RestoreConfig(cfg)
+	})
+}
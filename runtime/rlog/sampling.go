@@ -0,0 +1,36 @@
+package rlog
+
+import (
+	"sync/atomic"
+)
+
+// numLevels is the number of log levels.
+const numLevels = int(LevelError) + 1
+
+// SetSampling configures the Manager to keep only 1 in n log entries at
+// the given level, discarding the rest from both the log output and traces.
+// A value of n less than or equal to 1 keeps all entries, which is the default.
+//
+// Sampling keeps verbose logging in hot loops from flooding the log output
+// and bloating traces. Kept entries include a "sample_rate" field recording n,
+// so that the number of entries they represent can be estimated.
+// Critical entries are never sampled.
+func (l *Manager) SetSampling(level Level, n int) {
+	if int(level) >= numLevels {
+		return
+	}
+	l.updateConfig(func(c *config) {
+		c.sampleRates[level] = n
+	})
+}
+
+// sample reports whether to keep an entry at the given level, and the
+// sample rate to record for it, which is zero if the level is not sampled.
+func (l *Manager) sample(cfg *config, level Level) (keep bool, rate int) {
+	if int(level) >= numLevels || cfg.sampleRates[level] <= 1 {
+		return true, 0
+	}
+	rate = cfg.sampleRates[level]
+	n := atomic.AddUint64(&l.sampleCounts[level], 1)
+	return (n-1)%uint64(rate) == 0, rate
+}
@@ -0,0 +1,112 @@
+package rlog
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// selfStats are aggregate statistics about the logging performed
+// by a Manager, collected when self-metrics are enabled.
+// All fields are accessed atomically.
+type selfStats struct {
+	lines      uint64 // log entries logged
+	dropped    uint64 // log entries not written to the log output
+	sampled    uint64 // log entries discarded by sampling or repeat limiting
+	encoded    uint64 // log entries encoded
+	traceBytes uint64 // bytes of log entries written to traces
+	encodeNs   uint64 // total time spent encoding log entries, in nanoseconds
+}
+
+// record records the statistics of a single encoded log entry.
+func (s *selfStats) record(dropped bool, traceBytes int, encodeTime time.Duration) {
+	atomic.AddUint64(&s.lines, 1)
+	if dropped {
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	atomic.AddUint64(&s.encoded, 1)
+	atomic.AddUint64(&s.traceBytes, uint64(traceBytes))
+	atomic.AddUint64(&s.encodeNs, uint64(encodeTime))
+}
+
+// recordDiscarded records a log entry discarded before it was encoded,
+// by sampling or repeat limiting if sampled is true.
+func (s *selfStats) recordDiscarded(sampled bool) {
+	atomic.AddUint64(&s.lines, 1)
+	atomic.AddUint64(&s.dropped, 1)
+	if sampled {
+		atomic.AddUint64(&s.sampled, 1)
+	}
+}
+
+// reset resets the statistics, and the count of bytes written to out
+// if it is non-nil, and returns them as key-value pairs.
+func (s *selfStats) reset(out *Output) []any {
+	lines := atomic.SwapUint64(&s.lines, 0)
+	dropped := atomic.SwapUint64(&s.dropped, 0)
+	sampled := atomic.SwapUint64(&s.sampled, 0)
+	encoded := atomic.SwapUint64(&s.encoded, 0)
+	traceBytes := atomic.SwapUint64(&s.traceBytes, 0)
+	encodeNs := atomic.SwapUint64(&s.encodeNs, 0)
+	var outputBytes uint64
+	if out != nil {
+		outputBytes = atomic.SwapUint64(&out.written, 0)
+	}
+
+	var avgEncode time.Duration
+	if encoded > 0 {
+		avgEncode = time.Duration(encodeNs / encoded)
+	}
+	return []any{
+		"lines", lines,
+		"dropped", dropped,
+		"sampled", sampled,
+		"output_bytes", outputBytes,
+		"trace_bytes", traceBytes,
+		"avg_encode_time", avgEncode,
+	}
+}
+
+// SetSelfMetrics configures whether the Manager collects statistics about
+// the logging it performs, for understanding and tuning the cost of logging.
+//
+// When enabled, a summary is logged on every heartbeat, once a minute:
+// the number of log entries, the number of entries not written to the
+// log output (such as due to the output level or hooks), the number of
+// those discarded by sampling or repeat limiting, the number of bytes
+// written to the log output and to traces, and the average time spent
+// encoding a log entry. The summary entries are themselves excluded
+// from the statistics, and are never sampled or repeat limited.
+func (l *Manager) SetSelfMetrics(enabled bool) {
+	l.updateConfig(func(c *config) {
+		c.selfMetrics = enabled
+	})
+	if enabled {
+		l.stats.reset(l.config().output)
+		l.beat.set("self-metrics", l.logSelfMetrics)
+	} else {
+		l.beat.set("self-metrics", nil)
+	}
+}
+
+// logSelfMetrics logs the self-metrics summary and resets the statistics.
+func (l *Manager) logSelfMetrics() {
+	cfg := l.config()
+	fields := l.stats.reset(cfg.output)
+	if cfg.selfMetrics {
+		logger := l.internalLogger()
+		l.doLog(LevelInfo, logger.Info(), "rlog self-metrics", nil, fields, logOpts{internal: true, unlimited: true})
+	}
+}
+
+// internalLogger returns the logger for rlog's own log entries,
+// whose bytes are not counted as written to the log output.
+func (l *Manager) internalLogger() *zerolog.Logger {
+	logger := l.rt.Logger()
+	if out := l.config().output; out != nil {
+		uncounted := logger.Output(uncountedWriter{out})
+		return &uncounted
+	}
+	return logger
+}
@@ -0,0 +1,86 @@
+package rlog
+
+import (
+	"time"
+)
+
+// Record is a log entry, as passed to a Sink.
+type Record struct {
+	// Time is when the entry was logged.
+	Time time.Time
+
+	// Level is the severity level of the entry.
+	Level Level
+
+	// Message is the log message.
+	Message string
+
+	// Fields are the fields of the entry, including those added by
+	// With and those attached automatically, in the order they are logged.
+	Fields []Field
+
+	// TraceID and SpanID identify the trace and span the entry was
+	// logged in. They are all zeroes if the entry was not logged
+	// as part of a request.
+	TraceID [16]byte
+	SpanID  [8]byte
+}
+
+// Field reports the value of the field with the given key.
+// If the key occurs multiple times, the last value is reported.
+func (r Record) Field(key string) (value any, ok bool) {
+	for i := len(r.Fields) - 1; i >= 0; i-- {
+		if r.Fields[i].Key == key {
+			return r.Fields[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// A Sink receives the log entries written to the log output,
+// in order to forward them to another destination.
+//
+// Emit is called synchronously when an entry is logged, and must therefore
+// be fast and safe for concurrent use. Implementations that perform I/O,
+// such as exporting entries over the network, should buffer entries
+// and never block the caller. The Record may be retained after Emit returns.
+type Sink interface {
+	Emit(rec Record)
+}
+
+// AddSink adds a sink that receives all log entries written to the log output.
+func (l *Manager) AddSink(s Sink) {
+	l.updateConfig(func(c *config) {
+		c.sinks = append(c.sinks[:len(c.sinks):len(c.sinks)], s)
+	})
+}
+
+// removeSink removes a sink added with AddSink.
+func (l *Manager) removeSink(s Sink) {
+	l.updateConfig(func(c *config) {
+		sinks := make([]Sink, 0, len(c.sinks))
+		for _, other := range c.sinks {
+			if other != s {
+				sinks = append(sinks, other)
+			}
+		}
+		c.sinks = sinks
+	})
+}
+
+// emitToSinks passes the log entry to the configured sinks.
+func (c *config) emitToSinks(rec Record, fieldLists ...[]any) {
+	n := 0
+	for _, fields := range fieldLists {
+		n += len(fields) / 2
+	}
+	rec.Fields = make([]Field, 0, n)
+	for _, fields := range fieldLists {
+		for i := 0; i < len(fields); i += 2 {
+			rec.Fields = append(rec.Fields, Field{Key: fields[i].(string), Value: fields[i+1]})
+		}
+	}
+	for _, s := range c.sinks {
+		s.Emit(rec)
+	}
+}
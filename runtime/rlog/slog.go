@@ -0,0 +1,97 @@
+//go:build go1.21
+
+package rlog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler returns a slog.Handler that writes log records through l,
+// so that libraries logging with log/slog are correlated with the
+// trace and span of the current request like any other rlog entry.
+//
+// Attributes in groups are logged with keys qualified by the group names,
+// separated by dots. Records are logged at the rlog level corresponding
+// to their slog level: below slog.LevelDebug at trace level, and above
+// slog.LevelError at error level.
+func (l *Manager) SlogHandler() slog.Handler {
+	return &slogHandler{mgr: l}
+}
+
+type slogHandler struct {
+	mgr    *Manager
+	fields []any  // key-value pairs added with WithAttrs
+	prefix string // key prefix of the open groups, such as "a.b."
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.mgr.enabled(fromSlogLevel(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := make([]any, 0, r.NumAttrs()*2)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.prefix, a)
+		return true
+	})
+	if ctx != nil {
+		fields = append(fields, h.mgr.config().pprofLabelFields(ctx)...)
+	}
+	h.mgr.logAdapted(fromSlogLevel(r.Level), r.Message, h.fields, fields)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := h.fields[:len(h.fields):len(h.fields)]
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, h.prefix, a)
+	}
+	return &slogHandler{mgr: h.mgr, fields: fields, prefix: h.prefix}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{mgr: h.mgr, fields: h.fields, prefix: h.prefix + name + "."}
+}
+
+// appendSlogAttr appends a as key-value pairs to fields, flattening groups.
+func appendSlogAttr(fields []any, prefix string, a slog.Attr) []any {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		// Groups with an empty key are inlined.
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			fields = appendSlogAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+	if a.Key == "" {
+		// Attributes with an empty key are ignored, as per the slog.Handler contract.
+		return fields
+	}
+	return append(fields, prefix+a.Key, v.Any())
+}
+
+// fromSlogLevel reports the Level corresponding to the slog level l.
+func fromSlogLevel(l slog.Level) Level {
+	switch {
+	case l < slog.LevelDebug:
+		return LevelTrace
+	case l < slog.LevelInfo:
+		return LevelDebug
+	case l < slog.LevelWarn:
+		return LevelInfo
+	case l < slog.LevelError:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
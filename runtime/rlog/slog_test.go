@@ -0,0 +1,42 @@
+//go:build go1.21
+
+package rlog
+
+import (
+	"context"
+	"log/slog"
+	"runtime/pprof"
+	"testing"
+
+	"encore.dev/appruntime/model"
+)
+
+func TestSlogHandler(t *testing.T) {
+	mgr, buf, traceLog := newTestManager(t)
+	mgr.SetLevel(LevelInfo)
+	logger := slog.New(mgr.SlogHandler()).With("a", 1).WithGroup("g")
+	logger.Info("hello", "b", 2, slog.Group("sub", "c", 3))
+	logger.Debug("hidden")
+
+	want := `{"level":"info","a":1,"g.b":2,"g.sub.c":3,"message":"hello"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+	msgs := traceLog()
+	if len(msgs) != 2 || msgs[0].SpanID != (model.SpanID{1, 2, 3}) || msgs[1].Level != LevelDebug {
+		t.Errorf("got trace messages %+v", msgs)
+	}
+}
+
+func TestSlogHandler_PprofLabels(t *testing.T) {
+	mgr, buf, _ := newTestManager(t)
+	mgr.SetIncludePprofLabels(true)
+	logger := slog.New(mgr.SlogHandler())
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("endpoint", "users.Get"))
+	logger.InfoContext(ctx, "hello")
+
+	want := `{"level":"info","pprof_endpoint":"users.Get","message":"hello"}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got log output %q, want %q", got, want)
+	}
+}
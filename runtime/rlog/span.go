@@ -0,0 +1,199 @@
+package rlog
+
+import (
+	"sync"
+	"time"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/reqtrack"
+	"encore.dev/appruntime/trace"
+	"encore.dev/internal/stack"
+)
+
+// StartSpan starts an application-defined span with the given name,
+// as a child of the current request's span. The span appears in the
+// request's trace alongside the spans created by Encore, which makes it
+// possible to break down the time spent on units of work that are
+// meaningful to the application.
+// The variadic key-value pairs are treated as they are in With,
+// and are recorded on the span.
+//
+// It returns a logger whose log entries are associated with the span,
+// and a function that ends the span and records its duration, along with
+// any key-value pairs describing the outcome. Only the first call to end
+// has any effect.
+//
+//	spanLog, end := mgr.StartSpan("resize image", "width", w)
+//	defer end()
+//	spanLog.Info("fetched original")
+//
+// If there is no current request, or the request is not traced,
+// no span is recorded and the returned logger behaves like With.
+func (l *Manager) StartSpan(name string, kv ...any) (spanLogger Ctx, end func(kv ...any)) {
+	_, spanLogger, end = l.startSpan(model.SpanID{}, name, kv)
+	return spanLogger, end
+}
+
+// StartChildSpan is like StartSpan, but starts the span as a child of the
+// span parent, which must belong to the current request, or of the request's
+// span if parent is zero. It also returns the id of the span,
+// which is zero if no span is recorded.
+//
+//publicapigen:drop
+func (l *Manager) StartChildSpan(parent model.SpanID, name string, kv ...any) (spanID model.SpanID, spanLogger Ctx, end func(kv ...any)) {
+	return l.startSpan(parent, name, kv)
+}
+
+func (l *Manager) startSpan(parent model.SpanID, name string, kv []any) (spanID model.SpanID, spanLogger Ctx, end func(kv ...any)) {
+	spanLogger = l.With()
+	curr := l.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return model.SpanID{}, spanLogger, func(...any) {}
+	}
+	spanID, err := model.GenSpanID()
+	if err != nil {
+		return model.SpanID{}, spanLogger, func(...any) {}
+	}
+	spanLogger.span = spanID
+	if parent.IsZero() {
+		parent = curr.Req.SpanID
+	}
+
+	cfg := l.config()
+	fields := cfg.resolveFields(l.checkPairs(kv))
+	tb := trace.GetBuffer(8 + 8 + 4 + len(name) + 4 + len(fields)/2*50)
+	tb.Bytes(spanID[:])
+	tb.Bytes(parent[:])
+	tb.UVarint(uint64(curr.Goctr))
+	tb.String(name)
+	addTraceBufFields(tb, fields, cfg.jsonEncoder)
+	tb.Stack(stack.Build(4))
+	curr.Trace.Add(trace.UserSpanStart, tb.Buf())
+	trace.PutBuffer(tb)
+
+	start := time.Now()
+	var once sync.Once
+	end = func(kv ...any) {
+		once.Do(func() {
+			dur := time.Since(start)
+			cfg := l.config()
+			fields := cfg.resolveFields(l.checkPairs(kv))
+			tb := trace.GetBuffer(8 + 8 + 4 + len(fields)/2*50)
+			tb.Bytes(spanID[:])
+			tb.Int64(int64(dur))
+			addTraceBufFields(tb, fields, cfg.jsonEncoder)
+			curr.Trace.Add(trace.UserSpanEnd, tb.Buf())
+			trace.PutBuffer(tb)
+		})
+	}
+	return spanID, spanLogger, end
+}
+
+// SetSpanAttrs records the key-value pairs as attributes of the span
+// spanID, which must belong to the current request, or of the current
+// request's span if spanID is zero. The variadic key-value pairs are
+// treated as they are in With.
+//
+// If there is no current request, or the request is not traced,
+// it does nothing.
+//
+//publicapigen:drop
+func (l *Manager) SetSpanAttrs(spanID model.SpanID, kv ...any) {
+	curr := l.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+	if spanID.IsZero() {
+		spanID = curr.Req.SpanID
+	}
+
+	cfg := l.config()
+	fields := cfg.resolveFields(l.checkPairs(kv))
+	tb := trace.GetBuffer(8 + 4 + len(fields)/2*50)
+	tb.Bytes(spanID[:])
+	tb.UVarint(uint64(curr.Goctr))
+	addTraceBufFields(tb, fields, cfg.jsonEncoder)
+	curr.Trace.Add(trace.UserSpanAttrs, tb.Buf())
+	trace.PutBuffer(tb)
+}
+
+// AddSpanEvent records a timestamped event with the given name
+// in the span spanID, which must belong to the current request,
+// or in the current request's span if spanID is zero.
+// The variadic key-value pairs are treated as they are in With,
+// and are recorded as attributes of the event.
+//
+// If there is no current request, or the request is not traced,
+// it does nothing.
+//
+//publicapigen:drop
+func (l *Manager) AddSpanEvent(spanID model.SpanID, name string, kv ...any) {
+	l.AddRequestSpanEvent(l.rt.Current(), spanID, name, kv...)
+}
+
+// AddRequestSpanEvent is like AddSpanEvent, but records the event in the
+// request curr, as returned by the request tracker's Current method,
+// rather than the current request. It is for recording events from
+// goroutines that may not belong to the request, such as those
+// sending events on a stream opened by the request.
+//
+//publicapigen:drop
+func (l *Manager) AddRequestSpanEvent(curr reqtrack.Current, spanID model.SpanID, name string, kv ...any) {
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+	if spanID.IsZero() {
+		spanID = curr.Req.SpanID
+	}
+
+	cfg := l.config()
+	fields := cfg.resolveFields(l.checkPairs(kv))
+	tb := trace.GetBuffer(8 + 4 + len(name) + 4 + len(fields)/2*50)
+	tb.Bytes(spanID[:])
+	tb.UVarint(uint64(curr.Goctr))
+	tb.String(name)
+	addTraceBufFields(tb, fields, cfg.jsonEncoder)
+	curr.Trace.Add(trace.UserSpanEvent, tb.Buf())
+	trace.PutBuffer(tb)
+}
+
+// AddSpanLink records a link from the span spanID, which must belong to
+// the current request, or from the current request's span if spanID is
+// zero, to the span linkedSpanID of the trace traceID, such as the trace
+// of the message that caused the work the span does.
+// The variadic key-value pairs are treated as they are in With,
+// and are recorded as attributes of the link.
+//
+// If there is no current request, or the request is not traced,
+// it does nothing.
+//
+//publicapigen:drop
+func (l *Manager) AddSpanLink(spanID model.SpanID, traceID model.TraceID, linkedSpanID model.SpanID, kv ...any) {
+	curr := l.rt.Current()
+	if curr.Req == nil || curr.Trace == nil {
+		return
+	}
+	if spanID.IsZero() {
+		spanID = curr.Req.SpanID
+	}
+
+	cfg := l.config()
+	fields := cfg.resolveFields(l.checkPairs(kv))
+	tb := trace.GetBuffer(8 + 4 + 16 + 8 + len(fields)/2*50)
+	tb.Bytes(spanID[:])
+	tb.UVarint(uint64(curr.Goctr))
+	tb.Bytes(traceID[:])
+	tb.Bytes(linkedSpanID[:])
+	addTraceBufFields(tb, fields, cfg.jsonEncoder)
+	curr.Trace.Add(trace.UserSpanLink, tb.Buf())
+	trace.PutBuffer(tb)
+}
+
+// addTraceBufFields writes the number of key-value pairs in fields
+// followed by each pair, in the same encoding as log entry fields.
+func addTraceBufFields(tb *trace.Buffer, fields []any, enc jsonEncoder) {
+	tb.UVarint(uint64(len(fields) / 2))
+	for i := 0; i < len(fields); i += 2 {
+		addTraceBufEntry(tb, fields[i].(string), fields[i+1], enc)
+	}
+}
@@ -0,0 +1,47 @@
+package rlog
+
+import (
+	"errors"
+)
+
+// SetStacklessErrors configures errors that are expected during normal
+// operation, such as sql.ErrNoRows or context.Canceled, and therefore
+// don't warrant a stack trace.
+//
+// When a logged error matches one of errs, as reported by errors.Is,
+// no stack trace is captured or recorded for the error, nor for the
+// log entry as a whole. Critical entries always include a stack trace.
+// Calling it again replaces the previously configured errors.
+func (l *Manager) SetStacklessErrors(errs ...error) {
+	l.updateConfig(func(c *config) {
+		c.stacklessErrors = append([]error(nil), errs...)
+	})
+}
+
+// stacklessError is a logged error that matches one of the
+// configured stackless errors.
+type stacklessError struct {
+	error
+}
+
+func (e stacklessError) Unwrap() error { return e.error }
+
+// stackless reports whether err matches a configured stackless error.
+func (c *config) stackless(err error) bool {
+	for _, target := range c.stacklessErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasStacklessError reports whether fields contain a stackless error.
+func hasStacklessError(fields []any) bool {
+	for i := 1; i < len(fields); i += 2 {
+		if _, ok := fields[i].(stacklessError); ok {
+			return true
+		}
+	}
+	return false
+}
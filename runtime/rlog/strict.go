@@ -0,0 +1,62 @@
+package rlog
+
+import (
+	"fmt"
+	"strings"
+
+	"encore.dev/internal/stack"
+)
+
+// SetStrictPairs configures how malformed key-value pairs are reported,
+// such as a key without a value or a key that is not a string.
+//
+// Malformed pairs are always logged leniently: keys that are not strings
+// are converted to strings using fmt.Sprint, and a trailing key without
+// a value is dropped. In strict mode, intended for development and tests,
+// each malformed call is additionally reported by an error-level log entry
+// with the caller's stack trace, and fails the current test, if any.
+// Strict mode is disabled by default.
+func (l *Manager) SetStrictPairs(strict bool) {
+	l.updateConfig(func(c *config) {
+		c.strictPairs = strict
+	})
+}
+
+// checkPairs returns pairs(keysAndValues), reporting
+// malformed key-value pairs in strict mode.
+func (l *Manager) checkPairs(keysAndValues []any) []any {
+	if l.config().strictPairs {
+		if problem := malformedPairs(keysAndValues); problem != "" {
+			l.reportMalformedPairs(problem)
+		}
+	}
+	return pairs(keysAndValues)
+}
+
+// malformedPairs describes what is wrong with keysAndValues,
+// or returns "" if they are well-formed.
+func malformedPairs(keysAndValues []any) string {
+	var problems []string
+	for i := 0; i < len(keysAndValues)-1; i += 2 {
+		if _, ok := keysAndValues[i].(string); !ok {
+			problems = append(problems, fmt.Sprintf("key %d is of type %T, not string", i/2, keysAndValues[i]))
+		}
+	}
+	if len(keysAndValues)%2 == 1 {
+		problems = append(problems, fmt.Sprintf("key %v has no value", keysAndValues[len(keysAndValues)-1]))
+	}
+	return strings.Join(problems, "; ")
+}
+
+// reportMalformedPairs reports malformed key-value pairs
+// passed to a logging call.
+func (l *Manager) reportMalformedPairs(problem string) {
+	st := stack.Build(3)
+	caller := callerOf(st)
+	if curr := l.rt.Current(); curr.Req != nil && curr.Req.Test != nil && curr.Req.Test.Current != nil {
+		curr.Req.Test.Current.Errorf("rlog: malformed key-value pairs at %s: %s", caller, problem)
+	}
+	fields := []any{"problem", problem}
+	l.doLog(LevelError, event(l.internalLogger(), LevelError), "rlog: malformed key-value pairs", nil, fields,
+		logOpts{internal: true, stack: true, unlimited: true})
+}
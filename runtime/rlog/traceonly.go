@@ -0,0 +1,43 @@
+package rlog
+
+// SetRequestTraceOnly configures whether the log entries of the current request
+// are recorded in the request's trace only, and not written to the log output.
+//
+// This makes it possible to retain full log detail in the trace of a request
+// being debugged, without adding to the volume of the log stream.
+// Log entries are still written to the log output if the request is not traced.
+//
+// The setting applies to the remainder of the request, including requests
+// it makes to other services, and should be set at the start of the request.
+// It can also be enabled for a request by the Encore Platform using a request header.
+// It does nothing if there is no current request.
+func (l *Manager) SetRequestTraceOnly(traceOnly bool) {
+	if req := l.rt.Current().Req; req != nil {
+		req.TraceOnlyLogs.Store(traceOnly)
+	}
+}
+
+// SetRequestDebug configures whether debug-level log entries of the current
+// request are written to the log output, regardless of the configured levels.
+//
+// This makes it possible to investigate individual requests in production
+// in detail, without emitting debug-level log entries for all requests.
+//
+// The setting applies to the remainder of the request, including requests
+// it makes to other services, and should be set at the start of the request.
+// It can also be enabled for a request by the Encore Platform using a request header.
+// It does nothing if there is no current request.
+func (l *Manager) SetRequestDebug(debug bool) {
+	if req := l.rt.Current().Req; req != nil {
+		req.DebugLogs.Store(debug)
+	}
+}
+
+// SetTraceOnlyKeepErrors configures whether error-level log entries are still
+// written to the log output for requests whose logs are otherwise recorded
+// in the trace only. See SetRequestTraceOnly.
+func (l *Manager) SetTraceOnlyKeepErrors(keep bool) {
+	l.updateConfig(func(c *config) {
+		c.traceOnlyKeepErrors = keep
+	})
+}
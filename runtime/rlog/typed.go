@@ -0,0 +1,99 @@
+package rlog
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog"
+)
+
+// stringerValue returns the string representation of s.
+// Unlike calling s.String directly, it does not panic
+// if s is a nil pointer whose String method does not handle nil.
+func stringerValue(s fmt.Stringer) string {
+	return fmt.Sprint(s)
+}
+
+// sortedKeys returns the keys of m in sorted order,
+// so that maps are logged deterministically.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stringMapDict returns m as a zerolog dictionary.
+func stringMapDict(m map[string]string) *zerolog.Event {
+	d := zerolog.Dict()
+	for _, k := range sortedKeys(m) {
+		d.Str(k, m[k])
+	}
+	return d
+}
+
+// appendJSONStrings appends ss to dst as a JSON array.
+func appendJSONStrings(dst []byte, ss []string) []byte {
+	dst = append(dst, '[')
+	for i, s := range ss {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, s)
+	}
+	return append(dst, ']')
+}
+
+// appendJSONStringMap appends m to dst as a JSON object.
+func appendJSONStringMap(dst []byte, m map[string]string) []byte {
+	dst = append(dst, '{')
+	for i, k := range sortedKeys(m) {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, k)
+		dst = append(dst, ':')
+		dst = appendJSONString(dst, m[k])
+	}
+	return append(dst, '}')
+}
+
+// appendJSONString appends s to dst as a JSON string.
+// Invalid UTF-8 is replaced with the Unicode replacement character,
+// as encoding/json does.
+func appendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				dst = append(dst, '\\', c)
+			case c == '\n':
+				dst = append(dst, '\\', 'n')
+			case c == '\r':
+				dst = append(dst, '\\', 'r')
+			case c == '\t':
+				dst = append(dst, '\\', 't')
+			case c < 0x20:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			default:
+				dst = append(dst, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, "\ufffd"...)
+		} else {
+			dst = append(dst, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(dst, '"')
+}
@@ -0,0 +1,112 @@
+package rlog
+
+import (
+	"encore.dev/appruntime/model"
+)
+
+// W3CTraceParent renders the current request's trace and span ids
+// as a W3C Trace Context "traceparent" header value.
+//
+// It reports false if there is no current request, or if the request
+// lacks a valid trace id or span id.
+//
+// See https://www.w3.org/TR/trace-context/#traceparent-header.
+func (l *Manager) W3CTraceParent() (string, bool) {
+	return traceParent(l.rt.Current().Req)
+}
+
+// SetIncludeTraceParent configures whether log entries emitted within
+// a request should include the W3C "traceparent" value as a field.
+func (l *Manager) SetIncludeTraceParent(include bool) {
+	l.updateConfig(func(c *config) {
+		c.includeTraceParent = include
+	})
+}
+
+// SetIncludeSpanID configures whether warnings and errors logged within
+// a traced request include the id of the span they were logged in as the
+// "span_id" field, alongside the "trace_id" field of the request's logger,
+// so that log entries can be linked to the exact span of the trace.
+func (l *Manager) SetIncludeSpanID(include bool) {
+	l.updateConfig(func(c *config) {
+		c.includeSpanID = include
+	})
+}
+
+// traceParent formats the traceparent value for req.
+// It reports false if req is nil or has no valid trace id or span id,
+// as the specification forbids all-zero ids.
+func traceParent(req *model.Request) (string, bool) {
+	if req == nil || req.TraceID.IsZero() || req.SpanID.IsZero() {
+		return "", false
+	}
+	return model.FormatTraceParent(req.TraceID, req.SpanID, req.Traced), true
+}
+
+// SetLogBaggageKeys configures the W3C Baggage members of incoming requests
+// that are attached as fields to every log entry emitted within the request,
+// such as "tenant_id". Only members with the given keys are attached, and by
+// default none are, as baggage is set by callers. Keys are matched
+// case-insensitively, and calling it again replaces the previous keys.
+//
+// At most 16 members are attached to each log entry, and values longer than
+// 256 bytes are truncated. Members never replace the fields set by the runtime,
+// such as "service" or "trace_id", or fields added to the Manager.
+func (l *Manager) SetLogBaggageKeys(keys ...string) {
+	l.updateConfig(func(c *config) {
+		if len(keys) == 0 {
+			c.baggageKeys = nil
+		} else {
+			c.baggageKeys = newKeyMatcher(keys...)
+		}
+	})
+}
+
+// Limits on the baggage members attached to log entries.
+const (
+	maxBaggageFields   = 16
+	maxBaggageValueLen = 256
+)
+
+// runtimeFieldKeys are the keys of the fields the runtime attaches to
+// log entries, which baggage members never replace.
+var runtimeFieldKeys = newKeyMatcher(
+	"level", "message", "time", "error", "caller", "stack",
+	"service", "endpoint", "topic", "subscription", "uid", "test",
+	"trace_id", "span_id", "traceparent", "release", "x_correlation_id",
+)
+
+// baggageFields returns the W3C Baggage members of req whose keys are
+// matched by allowed as key-value pairs, so that values such as a tenant id
+// are attached to every log entry emitted within the request.
+// Members whose keys are runtime fields or are among fields are skipped.
+func baggageFields(req *model.Request, allowed keyMatcher, fields []any) []any {
+	if req == nil || len(req.Baggage) == 0 || len(allowed) == 0 {
+		return nil
+	}
+	var res []any
+	for _, m := range req.Baggage {
+		if !allowed.match(m.Key) || runtimeFieldKeys.match(m.Key) || hasKey(fields, m.Key) || hasKey(res, m.Key) {
+			continue
+		}
+		val := m.Value
+		if len(val) > maxBaggageValueLen {
+			val = val[:maxBaggageValueLen]
+		}
+		res = append(res, m.Key, val)
+		if len(res) == 2*maxBaggageFields {
+			break
+		}
+	}
+	return res
+}
+
+// hasKey reports whether the key-value pairs fields include key.
+func hasKey(fields []any, key string) bool {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if k, ok := fields[i].(string); ok && k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package rlog
+
+// SetWorkflowExtractor configures a function that is called for every
+// log entry to attach the "workflow_id" and "run_id" fields, making it
+// possible to correlate the logs of long-running workflows across requests.
+//
+// The function must be safe for concurrent use and should be fast,
+// as it is called on the logging hot path. The fields are omitted
+// when it reports false. Passing nil removes any configured extractor.
+func (l *Manager) SetWorkflowExtractor(fn func() (workflowID, runID string, ok bool)) {
+	l.updateConfig(func(c *config) {
+		c.workflowExtractor = fn
+	})
+}
+
+// workflowFields returns the workflow fields for the calling goroutine.
+func workflowFields(fn func() (workflowID, runID string, ok bool)) []any {
+	if fn == nil {
+		return nil
+	}
+	workflowID, runID, ok := fn()
+	if !ok {
+		return nil
+	}
+	return []any{"workflow_id", workflowID, "run_id", runID}
+}
@@ -0,0 +1,113 @@
+package rlog
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ZapCore returns a zapcore.Core that writes log entries through l,
+// so that code logging with zap is correlated with the trace and
+// span of the current request like any other rlog entry.
+//
+//	logger := zap.New(rlog.ZapCore())
+//
+// Entries are logged at the corresponding rlog level, where DPanic,
+// Panic and Fatal entries are logged at error level; zap itself is still
+// responsible for panicking or exiting afterwards. The logger name,
+// if any, is logged as the "logger" field, and fields added within a
+// zap.Namespace are logged with keys qualified by the namespace, separated by dots.
+func (l *Manager) ZapCore() zapcore.Core {
+	return &zapCore{mgr: l}
+}
+
+type zapCore struct {
+	mgr    *Manager
+	fields []any  // key-value pairs added with With
+	prefix string // key prefix of the open namespaces, such as "a.b."
+}
+
+func (c *zapCore) Enabled(level zapcore.Level) bool {
+	return c.mgr.enabled(fromZapLevel(level))
+}
+
+func (c *zapCore) With(fields []zapcore.Field) zapcore.Core {
+	kv, prefix := appendZapFields(c.fields[:len(c.fields):len(c.fields)], c.prefix, fields)
+	return &zapCore{mgr: c.mgr, fields: kv, prefix: prefix}
+}
+
+func (c *zapCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *zapCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ctxFields := c.fields
+	if ent.LoggerName != "" {
+		ctxFields = append([]any{"logger", ent.LoggerName}, ctxFields...)
+	}
+	kv, _ := appendZapFields(nil, c.prefix, fields)
+	c.mgr.logAdapted(fromZapLevel(ent.Level), ent.Message, ctxFields, kv)
+	return nil
+}
+
+func (c *zapCore) Sync() error {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	c.mgr.flushSinks(ctx)
+	return nil
+}
+
+// appendZapFields appends fields as key-value pairs to kv.
+// It returns the resulting key prefix, which changes if
+// fields opens a namespace.
+func appendZapFields(kv []any, prefix string, fields []zapcore.Field) ([]any, string) {
+	for _, f := range fields {
+		switch f.Type {
+		case zapcore.NamespaceType:
+			prefix += f.Key + "."
+			continue
+		case zapcore.SkipType:
+			continue
+		case zapcore.ErrorType:
+			// Log errors as errors rather than strings,
+			// so that they are handled like any other error.
+			if err, ok := f.Interface.(error); ok {
+				kv = append(kv, prefix+f.Key, err)
+				continue
+			}
+		}
+
+		// Use zap's own encoding for everything else.
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+		keys := make([]string, 0, len(enc.Fields))
+		for k := range enc.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			kv = append(kv, prefix+k, enc.Fields[k])
+		}
+	}
+	return kv, prefix
+}
+
+// fromZapLevel reports the Level corresponding to the zap level l.
+func fromZapLevel(l zapcore.Level) Level {
+	switch {
+	case l < zapcore.DebugLevel:
+		return LevelTrace
+	case l == zapcore.DebugLevel:
+		return LevelDebug
+	case l == zapcore.InfoLevel:
+		return LevelInfo
+	case l == zapcore.WarnLevel:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
@@ -0,0 +1,21 @@
+//go:build encore_app
+
+package sse
+
+import (
+	"net/http"
+)
+
+//publicapigen:drop
+var Singleton *Manager // injected on app init
+
+// Open opens a stream of server-sent events in the response to req,
+// which must be made to a raw endpoint. It writes the response headers.
+// The stream should be closed with Close once no more events are sent;
+// it is closed when the endpoint returns otherwise.
+//
+// It reports an error with the code Unavailable if the application is
+// shutting down, and with the code Internal if w does not support streaming.
+func Open[T any](w http.ResponseWriter, req *http.Request, cfg StreamConfig) (*Stream[T], error) {
+	return open[T](Singleton, w, req, cfg)
+}
@@ -0,0 +1,330 @@
+// Package sse provides streaming of server-sent events from raw endpoints.
+//
+// A stream is opened with Open from within a raw endpoint, and keeps the
+// request open until it is closed:
+//
+//	//encore:api public raw path=/prices
+//	func Prices(w http.ResponseWriter, req *http.Request) {
+//		stream, err := sse.Open[*Price](w, req, sse.StreamConfig{})
+//		if err != nil {
+//			errs.HTTPError(w, err)
+//			return
+//		}
+//		defer stream.Close()
+//
+//		for {
+//			select {
+//			case <-stream.Done():
+//				return
+//			case p := <-updates:
+//				if err := stream.Send(p); err != nil {
+//					return
+//				}
+//			}
+//		}
+//	}
+//
+// Streams send heartbeats to keep idle connections from being closed by
+// proxies, record each event in the trace of the request that opened them,
+// and are drained when the application shuts down gracefully: Done is
+// closed, and the shutdown waits for the stream to be closed.
+//
+// Streams are only supported in raw endpoints. Declaring endpoints that
+// stream server-sent events, so that the event types are part of the
+// API schema and the generated clients, is not yet supported.
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/reqtrack"
+	"encore.dev/beta/errs"
+	"encore.dev/rlog"
+)
+
+// DefaultHeartbeat is the interval between heartbeats
+// of streams that do not configure one.
+const DefaultHeartbeat = 15 * time.Second
+
+// ErrClosed is reported when sending events on a closed stream.
+var ErrClosed = errors.New("sse: stream closed")
+
+//publicapigen:drop
+type Manager struct {
+	rt   *reqtrack.RequestTracker
+	rlog *rlog.Manager
+	json jsoniter.API
+
+	mu       sync.Mutex
+	draining bool
+	drain    chan struct{} // closed when draining begins
+	streams  map[*stream]bool
+	wg       sync.WaitGroup // open streams
+}
+
+//publicapigen:drop
+func NewManager(rt *reqtrack.RequestTracker, rlog *rlog.Manager, json jsoniter.API) *Manager {
+	return &Manager{
+		rt:      rt,
+		rlog:    rlog,
+		json:    json,
+		drain:   make(chan struct{}),
+		streams: make(map[*stream]bool),
+	}
+}
+
+// Shutdown drains the open streams, waiting for them to be closed
+// until force is done, after which the remaining streams are closed.
+//
+//publicapigen:drop
+func (m *Manager) Shutdown(force context.Context) {
+	m.mu.Lock()
+	if !m.draining {
+		m.draining = true
+		close(m.drain)
+	}
+	m.mu.Unlock()
+
+	closed := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-force.Done():
+		m.mu.Lock()
+		streams := make([]*stream, 0, len(m.streams))
+		for s := range m.streams {
+			streams = append(streams, s)
+		}
+		m.mu.Unlock()
+		for _, s := range streams {
+			s.close()
+		}
+	}
+}
+
+// StreamConfig configures a stream.
+type StreamConfig struct {
+	// Heartbeat is the interval between the comments sent to keep the
+	// connection from being closed by proxies while no events are sent.
+	// If zero, DefaultHeartbeat is used. If negative, no heartbeats are sent.
+	Heartbeat time.Duration
+
+	// Retry, if positive, is sent to clients as the time to wait before
+	// reconnecting when the connection is lost.
+	Retry time.Duration
+}
+
+// Event is a server-sent event.
+type Event[T any] struct {
+	// ID is the id of the event, which clients send back in the
+	// Last-Event-ID header when reconnecting. It is omitted if empty.
+	ID string
+
+	// Name is the name of the event, with which clients listen for it.
+	// If empty, the event is dispatched as a "message" event.
+	Name string
+
+	// Data is the payload of the event, which is sent encoded as JSON.
+	Data T
+}
+
+// Stream is a stream of server-sent events with payloads of type T.
+// Its methods are safe for concurrent use.
+type Stream[T any] struct {
+	s *stream
+}
+
+// TODO: support declaring endpoints that stream server-sent events,
+// with the parser recording the event type in the API schema and the
+// generated clients consuming the stream, rather than only raw endpoints.
+func open[T any](mgr *Manager, w http.ResponseWriter, req *http.Request, cfg StreamConfig) (*Stream[T], error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errs.B().Code(errs.Internal).Msg("response does not support streaming").Err()
+	}
+
+	mgr.mu.Lock()
+	if mgr.draining {
+		mgr.mu.Unlock()
+		return nil, errs.B().Code(errs.Unavailable).Msg("server is shutting down").Retryable(0).Err()
+	}
+	s := &stream{
+		mgr:     mgr,
+		req:     mgr.rt.Current(),
+		w:       w,
+		flusher: flusher,
+		done:    make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	mgr.streams[s] = true
+	mgr.wg.Add(1)
+	mgr.mu.Unlock()
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no") // disable proxy buffering in nginx
+	w.WriteHeader(http.StatusOK)
+	if cfg.Retry > 0 {
+		_ = s.write("retry: " + strconv.FormatInt(cfg.Retry.Milliseconds(), 10) + "\n\n")
+	} else {
+		s.flusher.Flush()
+	}
+
+	heartbeat := cfg.Heartbeat
+	if heartbeat == 0 {
+		heartbeat = DefaultHeartbeat
+	}
+	go s.watch(req, heartbeat)
+	return &Stream[T]{s: s}, nil
+}
+
+// Send sends an event with the payload data.
+// It reports ErrClosed if the stream is closed.
+func (s *Stream[T]) Send(data T) error {
+	return s.SendEvent(Event[T]{Data: data})
+}
+
+// SendEvent sends the event ev.
+// It reports ErrClosed if the stream is closed.
+func (s *Stream[T]) SendEvent(ev Event[T]) error {
+	if strings.ContainsAny(ev.ID, "\r\n\x00") || strings.ContainsAny(ev.Name, "\r\n") {
+		return fmt.Errorf("sse: event id and name must not contain newlines")
+	}
+	data, err := s.s.mgr.json.Marshal(ev.Data)
+	if err != nil {
+		return fmt.Errorf("sse: encode event: %w", err)
+	}
+
+	var b strings.Builder
+	if ev.ID != "" {
+		b.WriteString("id: " + ev.ID + "\n")
+	}
+	if ev.Name != "" {
+		b.WriteString("event: " + ev.Name + "\n")
+	}
+	// Encoded JSON has no newlines, but guard against custom marshalers.
+	for _, line := range strings.Split(string(data), "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	if err := s.s.write(b.String()); err != nil {
+		return err
+	}
+
+	name := ev.Name
+	if name == "" {
+		name = "message"
+	}
+	s.s.mgr.rlog.AddRequestSpanEvent(s.s.req, model.SpanID{}, "sse.event", "event", name, "id", ev.ID, "bytes", len(data))
+	return nil
+}
+
+// Done returns a channel that is closed when the client disconnects,
+// when the application begins shutting down, or when the stream is closed.
+// The endpoint should then stop sending events and close the stream.
+func (s *Stream[T]) Done() <-chan struct{} {
+	return s.s.done
+}
+
+// Close closes the stream. It does not close the connection,
+// which is closed when the endpoint returns.
+func (s *Stream[T]) Close() {
+	s.s.close()
+}
+
+// stream is the untyped implementation of Stream.
+type stream struct {
+	mgr     *Manager
+	req     reqtrack.Current // the request that opened the stream
+	w       http.ResponseWriter
+	flusher http.Flusher
+
+	doneOnce  sync.Once
+	done      chan struct{} // closed when the stream should end
+	closeOnce sync.Once
+	closed    chan struct{} // closed by close
+
+	mu       sync.Mutex // guards writes
+	isClosed bool
+}
+
+// watch sends heartbeats until the stream is closed, and ends the
+// stream when the client disconnects or the application shuts down.
+func (s *stream) watch(req *http.Request, heartbeat time.Duration) {
+	var tick <-chan time.Time
+	if heartbeat > 0 {
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	drain := s.mgr.drain
+	for {
+		select {
+		case <-tick:
+			if s.write(": heartbeat\n\n") != nil {
+				s.end()
+			}
+		case <-req.Context().Done():
+			// The client disconnected or the endpoint returned;
+			// writing to the response is no longer allowed.
+			s.close()
+			return
+		case <-drain:
+			s.end()
+			drain = nil
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// write writes data to the response and flushes it.
+func (s *stream) write(data string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isClosed {
+		return ErrClosed
+	}
+	if _, err := s.w.Write([]byte(data)); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// end signals that the stream should end.
+func (s *stream) end() {
+	s.doneOnce.Do(func() { close(s.done) })
+}
+
+// close closes the stream and marks it as no longer open.
+func (s *stream) close() {
+	s.closeOnce.Do(func() {
+		s.end()
+		s.mu.Lock()
+		s.isClosed = true
+		s.mu.Unlock()
+		close(s.closed)
+
+		s.mgr.mu.Lock()
+		delete(s.mgr.streams, s)
+		s.mgr.mu.Unlock()
+		s.mgr.wg.Done()
+	})
+}
@@ -0,0 +1,163 @@
+package sse
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/reqtrack"
+	rttrace "encore.dev/appruntime/trace"
+	"encore.dev/beta/errs"
+	"encore.dev/rlog"
+)
+
+type price struct {
+	Symbol string `json:"symbol"`
+	Cents  int    `json:"cents"`
+}
+
+func newTestManager() (*Manager, *reqtrack.RequestTracker) {
+	rt := reqtrack.New(zerolog.Nop(), nil, rttrace.DefaultFactory)
+	return NewManager(rt, rlog.NewManager(rt), jsoniter.ConfigCompatibleWithStandardLibrary), rt
+}
+
+func TestStream_Send(t *testing.T) {
+	mgr, rt := newTestManager()
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	tr := rt.Current().Trace
+	tr.BeginRequest(req, 0)
+
+	w := httptest.NewRecorder()
+	stream, err := open[price](mgr, w, httptest.NewRequest("GET", "/prices", nil), StreamConfig{Retry: 2 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(price{Symbol: "ACME", Cents: 1250}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.SendEvent(Event[price]{ID: "7", Name: "close", Data: price{Symbol: "ACME"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.SendEvent(Event[price]{Name: "a\nb"}); err == nil {
+		t.Error("got no error for event name with newline")
+	}
+	stream.Close()
+	if err := stream.Send(price{}); err != ErrClosed {
+		t.Errorf("got error %v after close, want ErrClosed", err)
+	}
+	tr.FinishRequest(req, &model.Response{})
+	rt.FinishRequest()
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("got content type %q", ct)
+	}
+	want := "retry: 2000\n\n" +
+		"data: {\"symbol\":\"ACME\",\"cents\":1250}\n\n" +
+		"id: 7\nevent: close\ndata: {\"symbol\":\"ACME\",\"cents\":0}\n\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("got body %q, want %q", got, want)
+	}
+
+	spans, err := rttrace.DecodeSpans(tr.GetAndClear())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 1 || len(spans[0].Events) != 2 || spans[0].Events[1].Name != "sse.event" {
+		t.Errorf("got spans %+v", spans)
+	}
+}
+
+func TestStream_SendFromOtherGoroutine(t *testing.T) {
+	mgr, rt := newTestManager()
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	tr := rt.Current().Trace
+	tr.BeginRequest(req, 0)
+
+	stream, err := open[price](mgr, httptest.NewRecorder(), httptest.NewRequest("GET", "/prices", nil), StreamConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Events sent from goroutines outside the request, such as a
+	// broadcaster shared by several streams, are recorded in the
+	// trace of the request that opened the stream.
+	done := make(chan error)
+	go func() { done <- stream.Send(price{Symbol: "ACME"}) }()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	stream.Close()
+	tr.FinishRequest(req, &model.Response{})
+	rt.FinishRequest()
+
+	spans, err := rttrace.DecodeSpans(tr.GetAndClear())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 1 || len(spans[0].Events) != 1 || spans[0].Events[0].Name != "sse.event" {
+		t.Errorf("got spans %+v", spans)
+	}
+}
+
+func TestStream_Heartbeat(t *testing.T) {
+	mgr, _ := newTestManager()
+	w := httptest.NewRecorder()
+	stream, err := open[price](mgr, w, httptest.NewRequest("GET", "/prices", nil), StreamConfig{Heartbeat: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	stream.Close()
+	if !strings.HasPrefix(w.Body.String(), ": heartbeat\n\n") {
+		t.Errorf("got body %q, want heartbeats", w.Body.String())
+	}
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	mgr, _ := newTestManager()
+	stream, err := open[price](mgr, httptest.NewRecorder(), httptest.NewRequest("GET", "/prices", nil), StreamConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The endpoint closes the stream once it is told to end.
+	go func() {
+		<-stream.Done()
+		stream.Close()
+	}()
+	force, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	mgr.Shutdown(force)
+	if force.Err() != nil {
+		t.Error("shutdown did not drain the stream before being forced")
+	}
+
+	_, err = open[price](mgr, httptest.NewRecorder(), httptest.NewRequest("GET", "/prices", nil), StreamConfig{})
+	if errs.Code(err) != errs.Unavailable {
+		t.Errorf("got error %v when shutting down, want Unavailable", err)
+	}
+}
+
+func TestManager_ShutdownForce(t *testing.T) {
+	mgr, _ := newTestManager()
+	stream, err := open[price](mgr, httptest.NewRecorder(), httptest.NewRequest("GET", "/prices", nil), StreamConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Streams that are not closed are closed when the shutdown is forced.
+	force, cancel := context.WithCancel(context.Background())
+	cancel()
+	mgr.Shutdown(force)
+	if err := stream.Send(price{}); err != ErrClosed {
+		t.Errorf("got error %v after forced shutdown, want ErrClosed", err)
+	}
+}
@@ -0,0 +1,105 @@
+//go:build encore_app
+
+package trace
+
+import (
+	"context"
+)
+
+//publicapigen:drop
+var Singleton *Manager // injected on app init
+
+// StartSpan starts a span with the given name, as a child of the span
+// in ctx, if any, or of the current request's span otherwise.
+// The variadic key-value pairs are treated as they are in rlog.With,
+// and are recorded on the span.
+//
+// It returns a context for starting nested spans, and the span,
+// which must be ended with End:
+//
+//	ctx, span := trace.StartSpan(ctx, "resize-image", "width", w)
+//	defer span.End()
+//	span.Logger().Info("fetched original")
+func StartSpan(ctx context.Context, name string, kv ...any) (context.Context, *Span) {
+	return Singleton.StartSpan(ctx, name, kv...)
+}
+
+// StartOperation is like StartSpan, but starts a span representing
+// an operation of the given kind, which the trace timeline shows as a
+// distinct operation. Ending the span with EndErr records whether
+// the operation failed:
+//
+//	ctx, span := trace.StartOperation(ctx, trace.OperationHTTP, "GET /v1/rates")
+//	resp, err := client.Do(req.WithContext(ctx))
+//	span.EndErr(err)
+func StartOperation(ctx context.Context, kind OperationKind, name string, kv ...any) (context.Context, *Span) {
+	return Singleton.StartOperation(ctx, kind, name, kv...)
+}
+
+// SetAttr records an attribute with the given key and value on the
+// current request's span, replacing any previous value for the key:
+//
+//	trace.SetAttr("cache", "miss")
+func SetAttr(key string, value any) {
+	Singleton.SetAttr(key, value)
+}
+
+// AddEvent records a timestamped event with the given name on the
+// current request's span. The variadic key-value pairs are treated
+// as they are in rlog.With, and are recorded on the event:
+//
+//	trace.AddEvent("retry", "attempt", 2)
+func AddEvent(name string, kv ...any) {
+	Singleton.AddEvent(name, kv...)
+}
+
+// Baggage returns the value of the W3C Baggage member with the given key,
+// which flows with the current request across service calls and Pub/Sub
+// messages, or "" if there is no such member:
+//
+//	tenantID := trace.Baggage("tenant_id")
+func Baggage(key string) string {
+	return Singleton.Baggage(key)
+}
+
+// AllBaggage returns the W3C Baggage of the current request
+// as a map from key to value.
+func AllBaggage() map[string]string {
+	return Singleton.AllBaggage()
+}
+
+// CurrentSpanContext returns the span context of the current request's span,
+// for linking to it from other traces with AddLink.
+func CurrentSpanContext() SpanContext {
+	return Singleton.CurrentSpanContext()
+}
+
+// CurrentTraceID returns the id of the current request's trace, as it
+// appears in log entries and the Encore dashboard, or "" if there is no
+// current request. Including it in error responses makes it possible to
+// find the trace of a request a user reports a problem with:
+//
+//	return &errs.Error{
+//		Code:    errs.Internal,
+//		Message: "could not process order (ref " + trace.CurrentTraceID() + ")",
+//	}
+func CurrentTraceID() string {
+	return Singleton.CurrentTraceID()
+}
+
+// CurrentSpanID returns the id of the current request's span,
+// or "" if there is no current request.
+func CurrentSpanID() string {
+	return Singleton.CurrentSpanID()
+}
+
+// AddLink links the current request's span to the span identified by sc,
+// such as the span of the Pub/Sub message that caused a batch job.
+// The variadic key-value pairs are treated as they are in rlog.With,
+// and are recorded on the link:
+//
+//	sc, _ := trace.ParseSpanContext(job.Origin)
+//	trace.AddLink(sc, "job_id", job.ID)
+func AddLink(sc SpanContext, kv ...any) {
+	Singleton.AddLink(sc, kv...)
+}
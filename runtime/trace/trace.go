@@ -0,0 +1,292 @@
+// Package trace provides APIs for adding application-defined spans
+// to the traces Encore records.
+//
+// Spans started with StartSpan appear in the trace of the current request,
+// nested within the span they are started in, which makes it possible to
+// break down the time spent on units of work that are meaningful to the
+// application:
+//
+//	ctx, span := trace.StartSpan(ctx, "resize-image", "width", w)
+//	defer span.End()
+//
+// Cache lookups, calls to external HTTP APIs and queue interactions that
+// Encore does not record itself can be recorded as typed operations with
+// StartOperation, which the trace timeline shows as such:
+//
+//	ctx, span := trace.StartOperation(ctx, trace.OperationCache, "get user", "key", key)
+//	defer span.End("hit", ok)
+//
+// Attributes and timestamped events can also be recorded on the current
+// request's span with SetAttr and AddEvent, or on an application-defined
+// span with the corresponding Span methods:
+//
+//	trace.SetAttr("cache", "miss")
+//	trace.AddEvent("retry", "attempt", 2)
+//
+// The ids of the current request's trace and span, as they appear in log
+// entries, are returned by CurrentTraceID and CurrentSpanID, for including
+// in error responses and support tickets.
+//
+// W3C Baggage received with a request flows with it across service calls
+// and Pub/Sub messages, is attached to its log entries, and can be read
+// with Baggage.
+package trace
+
+import (
+	"context"
+
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/reqtrack"
+	rttrace "encore.dev/appruntime/trace"
+	"encore.dev/rlog"
+)
+
+//publicapigen:drop
+type Manager struct {
+	rt   *reqtrack.RequestTracker
+	rlog *rlog.Manager
+}
+
+//publicapigen:drop
+func NewManager(rt *reqtrack.RequestTracker, rlog *rlog.Manager) *Manager {
+	return &Manager{rt: rt, rlog: rlog}
+}
+
+// Span is an application-defined span, started with StartSpan.
+type Span struct {
+	mgr     *Manager
+	id      model.SpanID // zero if the span is not recorded
+	traceID model.TraceID
+	logger  rlog.Ctx
+	end     func(kv ...any)
+}
+
+// spanKey is the context key for the span a context belongs to.
+type spanKey struct{}
+
+// spanRef identifies a span in a context.
+type spanRef struct {
+	req *model.Request // the request the span belongs to
+	id  model.SpanID
+}
+
+// StartSpan starts a span with the given name, as a child of the span
+// in ctx if it belongs to the current request, or of the current
+// request's span otherwise. The variadic key-value pairs are treated
+// as they are in rlog.With, and are recorded on the span.
+//
+// It returns a context for starting nested spans, and the span,
+// which must be ended with End. If there is no current request,
+// or the request is not traced, no span is recorded.
+func (m *Manager) StartSpan(ctx context.Context, name string, kv ...any) (context.Context, *Span) {
+	curr := m.rt.Current()
+	var parent model.SpanID
+	if ref, ok := ctx.Value(spanKey{}).(spanRef); ok && curr.Req != nil && ref.req == curr.Req {
+		parent = ref.id
+	}
+
+	id, logger, end := m.rlog.StartChildSpan(parent, name, kv...)
+	span := &Span{mgr: m, id: id, logger: logger, end: end}
+	if !id.IsZero() {
+		span.traceID = curr.Req.TraceID
+		ctx = context.WithValue(ctx, spanKey{}, spanRef{req: curr.Req, id: id})
+	}
+	return ctx, span
+}
+
+// OperationKind is the kind of operation a span started with
+// StartOperation represents.
+type OperationKind string
+
+// The kinds of operations that can be recorded with StartOperation.
+const (
+	// OperationCache is a lookup in or update of a cache.
+	OperationCache OperationKind = rttrace.OperationCache
+	// OperationHTTP is an outgoing HTTP call to an external service.
+	OperationHTTP OperationKind = rttrace.OperationHTTP
+	// OperationPublish is the publishing of a message to a queue.
+	OperationPublish OperationKind = rttrace.OperationPublish
+	// OperationConsume is the receiving of a message from a queue.
+	OperationConsume OperationKind = rttrace.OperationConsume
+)
+
+// StartOperation is like StartSpan, but starts a span representing an
+// operation of the given kind, such as a cache lookup or a call to an
+// external HTTP API made with a client Encore does not instrument.
+// Such spans are shown as distinct operations in the trace timeline,
+// rather than as generic units of work.
+//
+// The span should be ended with EndErr, to record whether the
+// operation failed.
+func (m *Manager) StartOperation(ctx context.Context, kind OperationKind, name string, kv ...any) (context.Context, *Span) {
+	return m.StartSpan(ctx, name, append([]any{rttrace.OperationAttr, string(kind)}, kv...)...)
+}
+
+// SetAttr records an attribute with the given key and value on the
+// current request's span, replacing any previous value for the key.
+// If there is no current request, or the request is not traced,
+// it does nothing.
+func (m *Manager) SetAttr(key string, value any) {
+	m.rlog.SetSpanAttrs(model.SpanID{}, key, value)
+}
+
+// AddEvent records a timestamped event with the given name on the
+// current request's span. The variadic key-value pairs are treated
+// as they are in rlog.With, and are recorded on the event.
+// If there is no current request, or the request is not traced,
+// it does nothing.
+func (m *Manager) AddEvent(name string, kv ...any) {
+	m.rlog.AddSpanEvent(model.SpanID{}, name, kv...)
+}
+
+// SetAttr records an attribute with the given key and value on the span,
+// replacing any previous value for the key.
+func (s *Span) SetAttr(key string, value any) {
+	if !s.id.IsZero() {
+		s.mgr.rlog.SetSpanAttrs(s.id, key, value)
+	}
+}
+
+// AddEvent records a timestamped event with the given name on the span.
+// The variadic key-value pairs are treated as they are in rlog.With,
+// and are recorded on the event.
+func (s *Span) AddEvent(name string, kv ...any) {
+	if !s.id.IsZero() {
+		s.mgr.rlog.AddSpanEvent(s.id, name, kv...)
+	}
+}
+
+// SpanContext identifies a span of a trace, for linking to it with AddLink.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+}
+
+// IsValid reports whether sc identifies a span,
+// meaning neither its trace id nor its span id is zero.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// String formats sc as a W3C Trace Context "traceparent" value,
+// which can be parsed with ParseSpanContext.
+func (sc SpanContext) String() string {
+	return model.FormatTraceParent(sc.TraceID, sc.SpanID, true)
+}
+
+// ParseSpanContext parses a W3C Trace Context "traceparent" value,
+// such as one formatted by SpanContext.String.
+// It reports false if s is not a valid value.
+func ParseSpanContext(s string) (SpanContext, bool) {
+	traceID, spanID, _, ok := model.ParseTraceParent(s)
+	return SpanContext{TraceID: traceID, SpanID: spanID}, ok
+}
+
+// CurrentSpanContext returns the span context of the current request's span,
+// for linking to it from other traces. It returns the zero SpanContext if
+// there is no current request.
+func (m *Manager) CurrentSpanContext() SpanContext {
+	req := m.rt.Current().Req
+	if req == nil {
+		return SpanContext{}
+	}
+	return SpanContext{TraceID: req.TraceID, SpanID: req.SpanID}
+}
+
+// CurrentTraceID returns the id of the current request's trace, in the
+// format used for the "trace_id" field of log entries and in the Encore
+// dashboard. It returns "" if there is no current request.
+func (m *Manager) CurrentTraceID() string {
+	if req := m.rt.Current().Req; req != nil {
+		return req.TraceID.String()
+	}
+	return ""
+}
+
+// CurrentSpanID returns the id of the current request's span, in the
+// same format as CurrentTraceID. It returns "" if there is no current request.
+func (m *Manager) CurrentSpanID() string {
+	if req := m.rt.Current().Req; req != nil {
+		return req.SpanID.String()
+	}
+	return ""
+}
+
+// AddLink links the current request's span to the span identified by sc,
+// such as the span of the Pub/Sub message that caused a batch job.
+// The variadic key-value pairs are treated as they are in rlog.With,
+// and are recorded on the link. Invalid span contexts are ignored.
+// If there is no current request, or the request is not traced,
+// it does nothing.
+func (m *Manager) AddLink(sc SpanContext, kv ...any) {
+	if sc.IsValid() {
+		m.rlog.AddSpanLink(model.SpanID{}, sc.TraceID, sc.SpanID, kv...)
+	}
+}
+
+// Baggage returns the value of the W3C Baggage member with the given key,
+// which flows with the current request across service calls and Pub/Sub
+// messages. It returns "" if there is no such member, or no current request.
+func (m *Manager) Baggage(key string) string {
+	if req := m.rt.Current().Req; req != nil {
+		for _, member := range req.Baggage {
+			if member.Key == key {
+				return member.Value
+			}
+		}
+	}
+	return ""
+}
+
+// AllBaggage returns the W3C Baggage of the current request as a map
+// from key to value. It returns nil if there is no current request.
+func (m *Manager) AllBaggage() map[string]string {
+	req := m.rt.Current().Req
+	if req == nil {
+		return nil
+	}
+	baggage := make(map[string]string, len(req.Baggage))
+	for _, member := range req.Baggage {
+		baggage[member.Key] = member.Value
+	}
+	return baggage
+}
+
+// SpanContext returns the span context of the span, for linking to it
+// from other traces. It returns the zero SpanContext if the span
+// is not recorded.
+func (s *Span) SpanContext() SpanContext {
+	if s.id.IsZero() {
+		return SpanContext{}
+	}
+	return SpanContext{TraceID: s.traceID, SpanID: s.id}
+}
+
+// AddLink links the span to the span identified by sc.
+// The variadic key-value pairs are treated as they are in rlog.With,
+// and are recorded on the link. Invalid span contexts are ignored.
+func (s *Span) AddLink(sc SpanContext, kv ...any) {
+	if !s.id.IsZero() && sc.IsValid() {
+		s.mgr.rlog.AddSpanLink(s.id, sc.TraceID, sc.SpanID, kv...)
+	}
+}
+
+// End ends the span and records its duration, along with any key-value
+// pairs describing the outcome. Only the first call to End has any effect.
+func (s *Span) End(kv ...any) {
+	s.end(kv...)
+}
+
+// EndErr is like End, but also records err as the error of the operation
+// the span represents, if it is non-nil, as the "error" key-value pair.
+func (s *Span) EndErr(err error, kv ...any) {
+	if err != nil {
+		kv = append([]any{"error", err}, kv...)
+	}
+	s.end(kv...)
+}
+
+// Logger returns a logger whose log entries are associated with the span.
+func (s *Span) Logger() rlog.Ctx {
+	return s.logger
+}
@@ -0,0 +1,224 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/config"
+	"encore.dev/appruntime/model"
+	"encore.dev/appruntime/reqtrack"
+	rttrace "encore.dev/appruntime/trace"
+	"encore.dev/rlog"
+)
+
+func TestStartSpan(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, rttrace.DefaultFactory)
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	tr := rt.Current().Trace
+	rt.Current().Trace.BeginRequest(req, 0)
+	mgr := NewManager(rt, rlog.NewManager(rt))
+
+	ctx, outer := mgr.StartSpan(context.Background(), "outer")
+	_, inner := mgr.StartSpan(ctx, "inner", "n", 1)
+	inner.Logger().Info("in inner")
+	inner.End()
+	outer.End()
+	tr.FinishRequest(req, &model.Response{})
+	rt.FinishRequest()
+
+	spans, err := rttrace.DecodeSpans(tr.GetAndClear())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+	reqSpan, outerSpan, innerSpan := spans[0], spans[1], spans[2]
+	if outerSpan.Name != "outer" || outerSpan.ParentID != reqSpan.SpanID || outerSpan.SpanID != outer.id {
+		t.Errorf("got outer span %+v", outerSpan)
+	}
+	if innerSpan.Name != "inner" || innerSpan.ParentID != outerSpan.SpanID || innerSpan.TraceID != req.TraceID {
+		t.Errorf("got inner span %+v", innerSpan)
+	}
+	if len(innerSpan.Events) != 1 || len(innerSpan.Attrs) != 1 || innerSpan.Attrs[0].Value != int64(1) {
+		t.Errorf("got inner span events %+v and attributes %+v", innerSpan.Events, innerSpan.Attrs)
+	}
+}
+
+func TestStartOperation(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, rttrace.DefaultFactory)
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	tr := rt.Current().Trace
+	tr.BeginRequest(req, 0)
+	mgr := NewManager(rt, rlog.NewManager(rt))
+
+	ctx := context.Background()
+	_, lookup := mgr.StartOperation(ctx, OperationCache, "get user", "key", "u1")
+	lookup.EndErr(nil, "hit", false)
+	_, call := mgr.StartOperation(ctx, OperationHTTP, "GET /rates")
+	call.EndErr(errors.New("timeout"))
+	_, publish := mgr.StartOperation(ctx, OperationPublish, "orders")
+	publish.End()
+	tr.FinishRequest(req, &model.Response{})
+	rt.FinishRequest()
+
+	spans, err := rttrace.DecodeSpans(tr.GetAndClear())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 4 {
+		t.Fatalf("got %d spans, want 4", len(spans))
+	}
+	lookupSpan, callSpan, publishSpan := spans[1], spans[2], spans[3]
+	if lookupSpan.Name != "get user" || lookupSpan.Kind != rttrace.SpanKindClient || lookupSpan.Err != "" ||
+		!hasAttr(lookupSpan.Attrs, rttrace.OperationAttr, "cache") || !hasAttr(lookupSpan.Attrs, "hit", false) {
+		t.Errorf("got cache span %+v", lookupSpan)
+	}
+	if callSpan.Kind != rttrace.SpanKindClient || callSpan.Err != "timeout" {
+		t.Errorf("got http span %+v", callSpan)
+	}
+	if publishSpan.Kind != rttrace.SpanKindProducer || publishSpan.Err != "" {
+		t.Errorf("got publish span %+v", publishSpan)
+	}
+}
+
+func TestStartSpan_NoRequest(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, rttrace.DefaultFactory)
+	mgr := NewManager(rt, rlog.NewManager(rt))
+	ctx := context.Background()
+	if got, span := mgr.StartSpan(ctx, "span"); got != ctx || !span.id.IsZero() {
+		t.Errorf("got context %v and span %+v, want no span", got, span)
+	}
+}
+
+func TestAttrsAndEvents(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, rttrace.DefaultFactory)
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	tr := rt.Current().Trace
+	tr.BeginRequest(req, 0)
+	mgr := NewManager(rt, rlog.NewManager(rt))
+
+	mgr.SetAttr("cache", "hit")
+	mgr.SetAttr("cache", "miss")
+	mgr.AddEvent("retry", "attempt", 2)
+	_, span := mgr.StartSpan(context.Background(), "span")
+	span.SetAttr("ok", true)
+	span.AddEvent("done")
+	span.End()
+	tr.FinishRequest(req, &model.Response{})
+	rt.FinishRequest()
+
+	spans, err := rttrace.DecodeSpans(tr.GetAndClear())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	reqSpan, userSpan := spans[0], spans[1]
+	if !hasAttr(reqSpan.Attrs, "cache", "miss") || hasAttr(reqSpan.Attrs, "cache", "hit") {
+		t.Errorf("got request span attributes %+v", reqSpan.Attrs)
+	}
+	if len(reqSpan.Events) != 1 || reqSpan.Events[0].Name != "retry" || !hasAttr(reqSpan.Events[0].Attrs, "attempt", int64(2)) {
+		t.Errorf("got request span events %+v", reqSpan.Events)
+	}
+	if !hasAttr(userSpan.Attrs, "ok", true) || len(userSpan.Events) != 1 || userSpan.Events[0].Name != "done" {
+		t.Errorf("got span attributes %+v and events %+v", userSpan.Attrs, userSpan.Events)
+	}
+}
+
+func hasAttr(attrs []rttrace.Attr, key string, value any) bool {
+	for _, a := range attrs {
+		if a.Key == key && a.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStartSpan_SampledOut(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, rttrace.DefaultFactory)
+	zero := 0.0
+	rt.SetTraceSampler(rttrace.NewSampler(&config.TraceSampling{
+		TraceSamplingRule: config.TraceSamplingRule{Rate: &zero},
+	}, nil))
+	rt.BeginSampledOperation("svc", "Endpoint", "", rttrace.NoParent)
+	defer rt.FinishOperation()
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	defer rt.FinishRequest()
+
+	if curr := rt.Current(); curr.Trace != nil || req.Traced {
+		t.Fatalf("sampled-out request has trace %v and Traced = %v", curr.Trace, req.Traced)
+	}
+	mgr := NewManager(rt, rlog.NewManager(rt))
+	if _, span := mgr.StartSpan(context.Background(), "span"); !span.id.IsZero() {
+		t.Errorf("started span %v in sampled-out request", span.id)
+	}
+}
+
+func TestAddLink(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, rttrace.DefaultFactory)
+	req := &model.Request{Type: model.Test, Traced: true, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	tr := rt.Current().Trace
+	tr.BeginRequest(req, 0)
+	mgr := NewManager(rt, rlog.NewManager(rt))
+
+	if got := mgr.CurrentSpanContext(); got.TraceID != req.TraceID || got.SpanID != req.SpanID {
+		t.Errorf("got current span context %v", got)
+	}
+	sc, ok := ParseSpanContext("00-0a000000000000000000000000000000-0b00000000000000-01")
+	if !ok || sc.String() != "00-0a000000000000000000000000000000-0b00000000000000-01" {
+		t.Fatalf("got span context %v, %v", sc, ok)
+	}
+	mgr.AddLink(sc, "reason", "batch")
+	mgr.AddLink(SpanContext{}) // ignored
+	_, span := mgr.StartSpan(context.Background(), "span")
+	span.AddLink(mgr.CurrentSpanContext())
+	if got := span.SpanContext(); got.TraceID != req.TraceID || got.SpanID != span.id {
+		t.Errorf("got span context %v", got)
+	}
+	span.End()
+	tr.FinishRequest(req, &model.Response{})
+	rt.FinishRequest()
+
+	spans, err := rttrace.DecodeSpans(tr.GetAndClear())
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqSpan, userSpan := spans[0], spans[1]
+	if len(reqSpan.Links) != 1 || reqSpan.Links[0].TraceID != sc.TraceID || reqSpan.Links[0].SpanID != sc.SpanID ||
+		!hasAttr(reqSpan.Links[0].Attrs, "reason", "batch") {
+		t.Errorf("got request span links %+v", reqSpan.Links)
+	}
+	if len(userSpan.Links) != 1 || userSpan.Links[0].SpanID != req.SpanID {
+		t.Errorf("got span links %+v", userSpan.Links)
+	}
+}
+
+func TestCurrentIDs(t *testing.T) {
+	rt := reqtrack.New(zerolog.Nop(), nil, rttrace.DefaultFactory)
+	mgr := NewManager(rt, rlog.NewManager(rt))
+	if traceID, spanID := mgr.CurrentTraceID(), mgr.CurrentSpanID(); traceID != "" || spanID != "" {
+		t.Errorf("got ids %q, %q without a request", traceID, spanID)
+	}
+
+	// The ids are returned even if the request is not traced,
+	// as they are still included in its log entries.
+	req := &model.Request{Type: model.Test, TraceID: model.TraceID{1}, SpanID: model.SpanID{2}}
+	rt.BeginRequest(req)
+	defer rt.FinishRequest()
+	if got, want := mgr.CurrentTraceID(), req.TraceID.String(); got != want {
+		t.Errorf("got trace id %q, want %q", got, want)
+	}
+	if got, want := mgr.CurrentSpanID(), req.SpanID.String(); got != want {
+		t.Errorf("got span id %q, want %q", got, want)
+	}
+}